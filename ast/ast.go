@@ -0,0 +1,238 @@
+package ast
+
+import (
+	"strings"
+
+	"github.com/Clement-Jean/protein/lexer"
+	"github.com/Clement-Jean/protein/parser"
+	"github.com/Clement-Jean/protein/source"
+)
+
+// File is a typed view over the ParseTree of a proto file.
+// Every declaration keeps the index of its root node in Tree
+// so that callers can go back to the tree and the tokens.
+type File struct {
+	Src  *source.Buffer
+	Toks *lexer.TokenizedBuffer
+	Tree parser.ParseTree
+
+	Syntax   *Syntax
+	Edition  *Edition
+	Package  *Package
+	Imports  []*Import
+	Options  []*Option
+	Messages []*Message
+	Enums    []*Enum
+	Services []*Service
+	Extends  []*Extend
+}
+
+// Token is a terminal of the parse tree along with its text. Missing
+// tokens were assumed by the parser, they have no text and Idx is the
+// token in front of which they would be.
+type Token struct {
+	Idx     uint32
+	Kind    lexer.TokenKind
+	Text    string
+	Missing bool
+}
+
+type FullIdent struct {
+	Parts    []Token
+	Absolute bool // starts with a dot
+}
+
+func (fi FullIdent) String() string {
+	var b strings.Builder
+	for i, part := range fi.Parts {
+		if i != 0 || fi.Absolute {
+			b.WriteByte('.')
+		}
+		b.WriteString(part.Text)
+	}
+	return b.String()
+}
+
+// Type is the type of a field. Scalar is the kind of the builtin
+// type (e.g. TokenKindTypeInt32) or TokenKindIdentifier when the
+// type refers to a message or an enum.
+type Type struct {
+	Scalar lexer.TokenKind
+	Name   FullIdent
+}
+
+func (t Type) IsScalar() bool {
+	return t.Scalar != lexer.TokenKindIdentifier
+}
+
+func (t Type) String() string {
+	return t.Name.String()
+}
+
+// Value is a constant or an aggregate (text format message).
+// Node is the index of the value in the tree, for aggregates
+// this is the closing brace/angle and Token is the opening one.
+type Value struct {
+	Token
+	Node int
+}
+
+func (v Value) IsAggregate() bool {
+	return v.Kind == lexer.TokenKindLeftBrace || v.Kind == lexer.TokenKindLeftAngle
+}
+
+type Syntax struct {
+	Node  int
+	Value Value
+}
+
+type Edition struct {
+	Node  int
+	Value Value
+}
+
+type Package struct {
+	Node int
+	Name FullIdent
+}
+
+type Import struct {
+	Node   int
+	Public bool
+	Weak   bool
+	Path   Value
+}
+
+// OptionName is a part of an option name. Extension is set
+// when the part was written between parentheses.
+type OptionName struct {
+	Name      FullIdent
+	Extension bool
+}
+
+type Option struct {
+	Node  int
+	Name  []OptionName
+	Value Value
+}
+
+func (o *Option) NameString() string {
+	var b strings.Builder
+	for i, part := range o.Name {
+		if i != 0 {
+			b.WriteByte('.')
+		}
+		if part.Extension {
+			b.WriteByte('(')
+		}
+		b.WriteString(part.Name.String())
+		if part.Extension {
+			b.WriteByte(')')
+		}
+	}
+	return b.String()
+}
+
+type Label uint8
+
+const (
+	LabelNone Label = iota
+	LabelOptional
+	LabelRequired
+	LabelRepeated
+)
+
+type Map struct {
+	Key   Type
+	Value Type
+}
+
+type Field struct {
+	Node       int
+	Label      Label
+	LabelToken Token // only set when Label is not LabelNone
+	Type       Type
+	Map        *Map // set for map fields, Type is then empty
+	Name       Token
+	Number     Value
+	Options    []*Option
+	Oneof      *Oneof
+	Extend     *Extend // set for extensions
+}
+
+type Oneof struct {
+	Node    int
+	Name    Token
+	Fields  []*Field
+	Options []*Option
+}
+
+// Range is a range of numbers. For single numbers Start and End
+// are the same and End has the kind TokenKindMax for open ranges.
+type Range struct {
+	Start Value
+	End   Value
+}
+
+type Reserved struct {
+	Node   int
+	Ranges []Range
+	Names  []Value
+}
+
+type Extensions struct {
+	Node   int
+	Ranges []Range
+}
+
+type Message struct {
+	Node       int
+	Name       Token
+	Fields     []*Field // including the fields in Oneofs
+	Oneofs     []*Oneof
+	Messages   []*Message
+	Enums      []*Enum
+	Options    []*Option
+	Reserved   []*Reserved
+	Extensions []*Extensions
+	Extends    []*Extend
+}
+
+// Extend declares the Fields as extensions of the message Extendee.
+type Extend struct {
+	Node     int
+	Extendee FullIdent
+	Fields   []*Field
+}
+
+type EnumValue struct {
+	Node    int
+	Name    Token
+	Number  Value
+	Options []*Option
+}
+
+type Enum struct {
+	Node     int
+	Name     Token
+	Values   []*EnumValue
+	Options  []*Option
+	Reserved []*Reserved
+}
+
+type RPC struct {
+	Node         int
+	Name         Token
+	Input        FullIdent
+	InputStream  bool
+	Output       FullIdent
+	OutputStream bool
+	Options      []*Option
+}
+
+type Service struct {
+	Node    int
+	Name    Token
+	RPCs    []*RPC
+	Options []*Option
+}
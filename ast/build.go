@@ -0,0 +1,578 @@
+package ast
+
+import (
+	"slices"
+
+	"github.com/Clement-Jean/protein/lexer"
+	"github.com/Clement-Jean/protein/parser"
+	"github.com/Clement-Jean/protein/source"
+)
+
+// New builds the typed view of a file. The tree can contain errors,
+// the declarations that could not be understood are skipped.
+func New(src *source.Buffer, toks *lexer.TokenizedBuffer, tree parser.ParseTree) *File {
+	f := &File{
+		Src:  src,
+		Toks: toks,
+		Tree: tree,
+	}
+
+	for root := range f.Tree.Roots() {
+		f.buildTopLevel(root)
+	}
+	return f
+}
+
+func (f *File) children(idx int) []int {
+	return slices.Collect(f.Tree.Children(idx))
+}
+
+func (f *File) size(idx int) int {
+	return max(int(f.Tree[idx].SubtreeSize), 1)
+}
+
+func (f *File) kind(idx int) lexer.TokenKind {
+	tokIdx := f.Tree[idx].TokIdx
+	if f.Tree[idx].IsMissing() {
+		return f.Tree[idx].Missing
+	}
+	if tokIdx >= uint32(len(f.Toks.TokenInfos)) {
+		return lexer.TokenKindError
+	}
+	return f.Toks.TokenInfos[tokIdx].Kind
+}
+
+// TokenSpan returns the [start, end) offsets of the token at tokIdx.
+func (f *File) TokenSpan(tokIdx uint32) (start, end uint32) {
+	return f.Toks.TokenSpan(tokIdx)
+}
+
+// NodeSpan returns the [start, end) offsets covering all the tokens
+// in the subtree rooted at idx.
+func (f *File) NodeSpan(idx int) (start, end uint32) {
+	first := true
+	for i := idx - f.size(idx) + 1; i <= idx; i++ {
+		tokIdx := f.Tree[i].TokIdx
+		if f.Tree[i].IsMissing() || tokIdx >= uint32(len(f.Toks.TokenInfos)) {
+			continue
+		}
+
+		s, e := f.TokenSpan(tokIdx)
+		if first || s < start {
+			start = s
+		}
+		if first || e > end {
+			end = e
+		}
+		first = false
+	}
+	return start, end
+}
+
+func (f *File) token(idx int) Token {
+	tokIdx := f.Tree[idx].TokIdx
+	if f.Tree[idx].IsMissing() {
+		return Token{Idx: tokIdx, Kind: f.Tree[idx].Missing, Missing: true}
+	}
+	if tokIdx >= uint32(len(f.Toks.TokenInfos)) {
+		return Token{Idx: tokIdx, Kind: lexer.TokenKindError}
+	}
+
+	start, end := f.TokenSpan(tokIdx)
+	return Token{
+		Idx:  tokIdx,
+		Kind: f.Toks.TokenInfos[tokIdx].Kind,
+		Text: string(f.Src.Range(start, end)),
+	}
+}
+
+func (f *File) value(idx int) Value {
+	kind := f.kind(idx)
+	if (kind == lexer.TokenKindRightBrace || kind == lexer.TokenKindRightAngle) && f.size(idx) > 1 {
+		// aggregate, the first child is the opening symbol
+		return Value{Token: f.token(idx - f.size(idx) + 1), Node: idx}
+	}
+	return Value{Token: f.token(idx), Node: idx}
+}
+
+// subtreeTokens returns the tokens of all the nodes of a subtree
+// sorted by position in the source.
+func (f *File) subtreeTokens(idx int) []Token {
+	var tokIdxs []int
+	for i := idx - f.size(idx) + 1; i <= idx; i++ {
+		if !f.Tree[i].IsMissing() && f.Tree[i].TokIdx < uint32(len(f.Toks.TokenInfos)) {
+			tokIdxs = append(tokIdxs, i)
+		}
+	}
+	slices.SortFunc(tokIdxs, func(a, b int) int {
+		return int(f.Tree[a].TokIdx) - int(f.Tree[b].TokIdx)
+	})
+
+	toks := make([]Token, 0, len(tokIdxs))
+	for _, i := range tokIdxs {
+		tok := f.token(i)
+		if len(toks) != 0 && toks[len(toks)-1].Idx == tok.Idx {
+			continue
+		}
+		toks = append(toks, tok)
+	}
+	return toks
+}
+
+// fullIdent builds an identifier out of one or more sibling subtrees.
+// A leading dot is not always part of the identifier subtree.
+func (f *File) fullIdent(idxs ...int) FullIdent {
+	var fi FullIdent
+	for _, idx := range idxs {
+		for _, tok := range f.subtreeTokens(idx) {
+			if len(fi.Parts) == 0 && tok.Kind == lexer.TokenKindDot {
+				fi.Absolute = true
+			} else if tok.Kind.IsIdentifier() {
+				fi.Parts = append(fi.Parts, tok)
+			}
+		}
+	}
+	return fi
+}
+
+func (f *File) typ(idxs ...int) Type {
+	t := Type{Scalar: lexer.TokenKindIdentifier, Name: f.fullIdent(idxs...)}
+	if len(t.Name.Parts) == 1 && !t.Name.Absolute && t.Name.Parts[0].Kind.IsScalarType() {
+		t.Scalar = t.Name.Parts[0].Kind
+	}
+	return t
+}
+
+func (f *File) optionName(idx int) []OptionName {
+	var names []OptionName
+	var ext *OptionName
+
+	for _, tok := range f.subtreeTokens(idx) {
+		switch {
+		case tok.Kind == lexer.TokenKindLeftParen:
+			ext = &OptionName{Extension: true}
+		case tok.Kind == lexer.TokenKindRightParen:
+			if ext != nil {
+				names = append(names, *ext)
+				ext = nil
+			}
+		case tok.Kind == lexer.TokenKindDot:
+			if ext != nil && len(ext.Name.Parts) == 0 {
+				ext.Name.Absolute = true
+			}
+		case tok.Kind.IsIdentifier():
+			if ext != nil {
+				ext.Name.Parts = append(ext.Name.Parts, tok)
+				break
+			}
+			names = append(names, OptionName{Name: FullIdent{Parts: []Token{tok}}})
+		}
+	}
+	return names
+}
+
+// option builds an option out of an assignment node (name = value).
+func (f *File) option(idx int) *Option {
+	if f.kind(idx) != lexer.TokenKindEqual {
+		return nil
+	}
+
+	children := f.children(idx)
+	if len(children) != 2 {
+		return nil
+	}
+
+	return &Option{
+		Node:  idx,
+		Name:  f.optionName(children[0]),
+		Value: f.value(children[1]),
+	}
+}
+
+// compactOptions builds the options between square brackets.
+func (f *File) compactOptions(idx int) (opts []*Option) {
+	for _, child := range f.children(idx) {
+		switch f.kind(child) {
+		case lexer.TokenKindComma:
+			opts = append(opts, f.compactOptions(child)...)
+		case lexer.TokenKindEqual:
+			if opt := f.option(child); opt != nil {
+				opts = append(opts, opt)
+			}
+		}
+	}
+	return opts
+}
+
+// statementOption builds an option out of the children of
+// an option statement (option name = value;).
+func (f *File) statementOption(idx int, children []int) *Option {
+	if len(children) < 2 {
+		return nil
+	}
+
+	opt := f.option(children[1])
+	if opt != nil {
+		opt.Node = idx
+	}
+	return opt
+}
+
+func (f *File) ranges(idx int, reserved *Reserved) (ranges []Range) {
+	for _, child := range f.children(idx) {
+		switch f.kind(child) {
+		case lexer.TokenKindComma:
+			ranges = append(ranges, f.ranges(child, reserved)...)
+		case lexer.TokenKindTo:
+			bounds := f.children(child)
+			if len(bounds) == 2 {
+				ranges = append(ranges, Range{
+					Start: f.value(bounds[0]),
+					End:   f.value(bounds[1]),
+				})
+			}
+		case lexer.TokenKindInt:
+			v := f.value(child)
+			ranges = append(ranges, Range{Start: v, End: v})
+		case lexer.TokenKindStr:
+			if reserved != nil {
+				reserved.Names = append(reserved.Names, f.value(child))
+			}
+		}
+	}
+	return ranges
+}
+
+func (f *File) reserved(idx int) *Reserved {
+	r := &Reserved{Node: idx}
+	r.Ranges = f.ranges(idx, r)
+	return r
+}
+
+func (f *File) extensions(idx int) *Extensions {
+	return &Extensions{Node: idx, Ranges: f.ranges(idx, nil)}
+}
+
+func (f *File) buildTopLevel(idx int) {
+	children := f.children(idx)
+	if len(children) == 0 {
+		return
+	}
+
+	switch f.kind(children[0]) {
+	case lexer.TokenKindSyntax:
+		if v, ok := f.stringValue(children); ok {
+			f.Syntax = &Syntax{Node: idx, Value: v}
+		}
+	case lexer.TokenKindEdition:
+		if v, ok := f.stringValue(children); ok {
+			f.Edition = &Edition{Node: idx, Value: v}
+		}
+	case lexer.TokenKindImport:
+		imp := &Import{Node: idx}
+		for _, child := range children[1:] {
+			switch f.kind(child) {
+			case lexer.TokenKindPublic:
+				imp.Public = true
+			case lexer.TokenKindWeak:
+				imp.Weak = true
+			case lexer.TokenKindStr:
+				imp.Path = f.value(child)
+			}
+		}
+		if imp.Path.Kind == lexer.TokenKindStr {
+			f.Imports = append(f.Imports, imp)
+		}
+	case lexer.TokenKindPackage:
+		if len(children) > 1 {
+			f.Package = &Package{Node: idx, Name: f.fullIdent(children[1])}
+		}
+	case lexer.TokenKindOption:
+		if opt := f.statementOption(idx, children); opt != nil {
+			f.Options = append(f.Options, opt)
+		}
+	case lexer.TokenKindMessage:
+		f.Messages = append(f.Messages, f.message(idx, children))
+	case lexer.TokenKindEnum:
+		f.Enums = append(f.Enums, f.enum(idx, children))
+	case lexer.TokenKindService:
+		f.Services = append(f.Services, f.service(idx, children))
+	case lexer.TokenKindExtend:
+		f.Extends = append(f.Extends, f.extend(idx, children))
+	}
+}
+
+func (f *File) stringValue(children []int) (Value, bool) {
+	for _, child := range children {
+		if f.kind(child) == lexer.TokenKindStr {
+			return f.value(child), true
+		}
+	}
+	return Value{}, false
+}
+
+// body returns the children of a block (message, enum, ...)
+// after the keyword, the name and the opening brace.
+func body(children []int) []int {
+	if len(children) < 3 {
+		return nil
+	}
+	return children[3:]
+}
+
+func (f *File) name(children []int) Token {
+	if len(children) < 2 {
+		return Token{}
+	}
+	return f.token(children[1])
+}
+
+func (f *File) message(idx int, children []int) *Message {
+	m := &Message{Node: idx, Name: f.name(children)}
+
+	for _, member := range body(children) {
+		memberChildren := f.children(member)
+		if len(memberChildren) == 0 {
+			continue
+		}
+
+		switch f.kind(memberChildren[0]) {
+		case lexer.TokenKindOption:
+			if opt := f.statementOption(member, memberChildren); opt != nil {
+				m.Options = append(m.Options, opt)
+			}
+		case lexer.TokenKindReserved:
+			m.Reserved = append(m.Reserved, f.reserved(member))
+		case lexer.TokenKindExtensions:
+			m.Extensions = append(m.Extensions, f.extensions(member))
+		case lexer.TokenKindOneOf:
+			oneof := f.oneof(member, memberChildren)
+			m.Oneofs = append(m.Oneofs, oneof)
+			m.Fields = append(m.Fields, oneof.Fields...)
+		case lexer.TokenKindMessage:
+			m.Messages = append(m.Messages, f.message(member, memberChildren))
+		case lexer.TokenKindEnum:
+			m.Enums = append(m.Enums, f.enum(member, memberChildren))
+		case lexer.TokenKindExtend:
+			m.Extends = append(m.Extends, f.extend(member, memberChildren))
+		default:
+			if field := f.field(member, memberChildren); field != nil {
+				m.Fields = append(m.Fields, field)
+			}
+		}
+	}
+	return m
+}
+
+func label(kind lexer.TokenKind) Label {
+	switch kind {
+	case lexer.TokenKindOptional:
+		return LabelOptional
+	case lexer.TokenKindRequired:
+		return LabelRequired
+	case lexer.TokenKindRepeated:
+		return LabelRepeated
+	}
+	return LabelNone
+}
+
+func (f *File) field(idx int, children []int) *Field {
+	field := &Field{Node: idx}
+
+	i := 0
+	if field.Label = label(f.kind(children[i])); field.Label != LabelNone {
+		field.LabelToken = f.token(children[i])
+		i++
+	}
+
+	if i < len(children) && f.kind(children[i]) == lexer.TokenKindMap {
+		i++
+		if i >= len(children) {
+			return nil
+		}
+
+		if generic := f.children(children[i]); len(generic) == 2 {
+			kv := f.children(generic[1])
+			if len(kv) == 2 {
+				field.Map = &Map{Key: f.typ(kv[0]), Value: f.typ(kv[1])}
+			}
+		}
+		i++
+	} else {
+		start := i
+		for i < len(children) && f.kind(children[i]) != lexer.TokenKindEqual {
+			i++
+		}
+		field.Type = f.typ(children[start:i]...)
+	}
+
+	if i >= len(children) || f.kind(children[i]) != lexer.TokenKindEqual {
+		return nil
+	}
+
+	assign := f.children(children[i])
+	if len(assign) != 2 {
+		return nil
+	}
+	field.Name = f.token(assign[0])
+	field.Number = f.value(assign[1])
+	i++
+
+	if i < len(children) && f.kind(children[i]) == lexer.TokenKindRightSquare {
+		field.Options = f.compactOptions(children[i])
+	}
+	return field
+}
+
+func (f *File) oneof(idx int, children []int) *Oneof {
+	oneof := &Oneof{Node: idx, Name: f.name(children)}
+
+	for _, member := range body(children) {
+		memberChildren := f.children(member)
+		if len(memberChildren) == 0 {
+			continue
+		}
+
+		if f.kind(memberChildren[0]) == lexer.TokenKindOption {
+			if opt := f.statementOption(member, memberChildren); opt != nil {
+				oneof.Options = append(oneof.Options, opt)
+			}
+			continue
+		}
+
+		if field := f.field(member, memberChildren); field != nil {
+			field.Oneof = oneof
+			oneof.Fields = append(oneof.Fields, field)
+		}
+	}
+	return oneof
+}
+
+func (f *File) extend(idx int, children []int) *Extend {
+	e := &Extend{Node: idx}
+
+	// the extendee can be made of a leading dot and an identifier
+	i := 1
+	for i < len(children) && f.kind(children[i]) != lexer.TokenKindLeftBrace && !f.Tree[children[i]].HasError {
+		i++
+	}
+	e.Extendee = f.fullIdent(children[1:i]...)
+	if i >= len(children) || f.kind(children[i]) != lexer.TokenKindLeftBrace {
+		return e
+	}
+
+	for _, member := range children[i+1:] {
+		memberChildren := f.children(member)
+		if len(memberChildren) == 0 {
+			continue
+		}
+
+		if field := f.field(member, memberChildren); field != nil {
+			field.Extend = e
+			e.Fields = append(e.Fields, field)
+		}
+	}
+	return e
+}
+
+func (f *File) enum(idx int, children []int) *Enum {
+	e := &Enum{Node: idx, Name: f.name(children)}
+
+	for _, member := range body(children) {
+		memberChildren := f.children(member)
+		if len(memberChildren) == 0 {
+			continue
+		}
+
+		switch f.kind(memberChildren[0]) {
+		case lexer.TokenKindOption:
+			if opt := f.statementOption(member, memberChildren); opt != nil {
+				e.Options = append(e.Options, opt)
+			}
+		case lexer.TokenKindReserved:
+			e.Reserved = append(e.Reserved, f.reserved(member))
+		case lexer.TokenKindEqual:
+			assign := f.children(memberChildren[0])
+			if len(assign) != 2 {
+				continue
+			}
+
+			value := &EnumValue{
+				Node:   member,
+				Name:   f.token(assign[0]),
+				Number: f.value(assign[1]),
+			}
+			if len(memberChildren) > 1 && f.kind(memberChildren[1]) == lexer.TokenKindRightSquare {
+				value.Options = f.compactOptions(memberChildren[1])
+			}
+			e.Values = append(e.Values, value)
+		}
+	}
+	return e
+}
+
+func (f *File) service(idx int, children []int) *Service {
+	s := &Service{Node: idx, Name: f.name(children)}
+
+	for _, member := range body(children) {
+		memberChildren := f.children(member)
+		if len(memberChildren) == 0 {
+			continue
+		}
+
+		switch f.kind(memberChildren[0]) {
+		case lexer.TokenKindOption:
+			if opt := f.statementOption(member, memberChildren); opt != nil {
+				s.Options = append(s.Options, opt)
+			}
+		case lexer.TokenKindRPC:
+			if rpc := f.rpc(member, memberChildren); rpc != nil {
+				s.RPCs = append(s.RPCs, rpc)
+			}
+		}
+	}
+	return s
+}
+
+func (f *File) rpc(idx int, children []int) *RPC {
+	if len(children) < 2 || f.kind(children[1]) != lexer.TokenKindReturns {
+		return nil
+	}
+
+	signature := f.children(children[1])
+	if len(signature) != 3 {
+		return nil
+	}
+
+	rpc := &RPC{Node: idx, Name: f.token(signature[0])}
+	rpc.Input, rpc.InputStream = f.rpcType(signature[1])
+	rpc.Output, rpc.OutputStream = f.rpcType(signature[2])
+
+	if len(children) > 2 && f.kind(children[2]) == lexer.TokenKindRightBrace {
+		for _, member := range f.children(children[2]) {
+			memberChildren := f.children(member)
+			if len(memberChildren) != 0 && f.kind(memberChildren[0]) == lexer.TokenKindOption {
+				if opt := f.statementOption(member, memberChildren); opt != nil {
+					rpc.Options = append(rpc.Options, opt)
+				}
+			}
+		}
+	}
+	return rpc
+}
+
+// rpcType reads the content of the parentheses in a rpc definition.
+func (f *File) rpcType(idx int) (FullIdent, bool) {
+	children := f.children(idx)
+	switch len(children) {
+	case 0, 1:
+		return FullIdent{}, false
+	}
+
+	// ( [stream] Type
+	stream := len(children) > 2 && f.kind(children[1]) == lexer.TokenKindStream
+	if stream {
+		return f.fullIdent(children[2:]...), true
+	}
+	return f.fullIdent(children[1:]...), false
+}
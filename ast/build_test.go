@@ -0,0 +1,151 @@
+package ast_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Clement-Jean/protein/ast"
+	"github.com/Clement-Jean/protein/lexer"
+	"github.com/Clement-Jean/protein/parser"
+	"github.com/Clement-Jean/protein/source"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMissing(t *testing.T) {
+	src, err := source.NewFromReader(strings.NewReader(`syntax "proto3"
+message M {
+  int32 = 1
+  int32 b 2;
+  int32 c = 3; // comment
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := lexer.NewFromSource(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	toks, errs := l.Lex()
+	if len(errs) != 0 {
+		t.Fatal(errs)
+	}
+
+	tree, errs := parser.New(toks).Parse()
+	if len(errs) != 6 {
+		t.Fatalf("expected 6 errors, got %v", errs)
+	}
+
+	file := ast.New(src, toks, tree)
+	if v, _ := file.Syntax.Value.String(); v != "proto3" {
+		t.Errorf("expected proto3, got %s", v)
+	}
+	if len(file.Messages) != 1 {
+		t.Fatalf("expected a message, got %d", len(file.Messages))
+	}
+
+	var got []ast.Token
+	for _, field := range file.Messages[0].Fields {
+		got = append(got, field.Name)
+	}
+
+	expected := []ast.Token{
+		{Idx: 7, Kind: lexer.TokenKindIdentifier, Missing: true},
+		{Idx: 10, Kind: lexer.TokenKindIdentifier, Text: "b"},
+		{Idx: 14, Kind: lexer.TokenKindIdentifier, Text: "c"},
+	}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("fields mismatch (-want +got):\n%s", diff)
+	}
+
+	// the insertion offsets of the missing tokens
+	var inserts []string
+	for i, node := range tree {
+		if !node.IsMissing() {
+			continue
+		}
+
+		offset := tree.InsertOffset(i, src, toks)
+		line := src.Bytes()[:offset]
+		line = line[strings.LastIndexByte(string(line), '\n')+1:]
+		inserts = append(inserts, string(line)+"<"+node.Missing.String()+">")
+	}
+
+	expectedInserts := []string{
+		`syntax<=>`,
+		`syntax "proto3"<;>`,
+		`  int32<Identifier>`,
+		`  int32 = 1<;>`,
+		`  int32 b<=>`,
+		`  int32 c = 3;<}>`,
+	}
+	if diff := cmp.Diff(expectedInserts, inserts); diff != "" {
+		t.Errorf("inserts mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestTextFields(t *testing.T) {
+	src, err := source.NewFromReader(strings.NewReader(`option (o) = {
+  a: 1, b { c: "x" };
+  [pkg.ext]: true;
+  d: [1, 2] e: []
+  f < g: 1 >
+  h: [{}, {}]
+};`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := lexer.NewFromSource(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	toks, errs := l.Lex()
+	if len(errs) != 0 {
+		t.Fatal(errs)
+	}
+
+	tree, errs := parser.New(toks).Parse()
+	if len(errs) != 0 {
+		t.Fatal(errs)
+	}
+
+	file := ast.New(src, toks, tree)
+	if len(file.Options) != 1 {
+		t.Fatalf("expected an option, got %d", len(file.Options))
+	}
+
+	var got []string
+	for _, field := range file.TextFields(file.Options[0].Value) {
+		var values []string
+		for _, v := range field.Values {
+			values = append(values, v.Text)
+		}
+
+		name := field.Name.String()
+		if field.Extension {
+			name = "[" + name + "]"
+		}
+		if field.List {
+			got = append(got, name+": ["+strings.Join(values, ", ")+"]")
+		} else {
+			got = append(got, name+": "+strings.Join(values, ", "))
+		}
+	}
+
+	expected := []string{
+		"a: 1",
+		"b: {",
+		"[pkg.ext]: true",
+		"d: [1, 2]",
+		"e: []",
+		"f: <",
+		"h: [{, {]",
+	}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("fields mismatch (-want +got):\n%s", diff)
+	}
+}
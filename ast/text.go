@@ -0,0 +1,77 @@
+package ast
+
+import "github.com/Clement-Jean/protein/lexer"
+
+// TextField is a field of an aggregate value (text format). Extension
+// is set when the name is written between square brackets. The
+// elements of a list are in Values, other fields have a single value.
+type TextField struct {
+	Node      int
+	Name      FullIdent
+	Extension bool
+	List      bool
+	Values    []Value
+}
+
+// TextFields returns the fields of the aggregate value v.
+func (f *File) TextFields(v Value) []TextField {
+	if !v.IsAggregate() {
+		return nil
+	}
+
+	var fields []TextField
+	items := f.textItems(f.children(v.Node)[1:])
+	for i := 0; i < len(items); i++ {
+		field := TextField{Node: items[i]}
+
+		// the colon can be omitted before aggregates and lists,
+		// the name and the value are then siblings
+		var name, value int
+		if f.kind(items[i]) == lexer.TokenKindColon {
+			children := f.children(items[i])
+			if len(children) != 2 {
+				continue
+			}
+			name, value = children[0], children[1]
+		} else if i+1 < len(items) {
+			name, value = items[i], items[i+1]
+			i++
+		} else {
+			continue
+		}
+
+		if f.kind(name) == lexer.TokenKindRightSquare {
+			field.Extension = true
+			field.Name = f.fullIdent(name)
+		} else {
+			field.Name = FullIdent{Parts: []Token{f.token(name)}}
+		}
+
+		children := f.children(value)
+		if f.kind(value) == lexer.TokenKindRightSquare && len(children) != 0 && f.kind(children[0]) == lexer.TokenKindLeftSquare {
+			field.List = true
+			for _, elem := range f.textItems(children[1:]) {
+				field.Values = append(field.Values, f.value(elem))
+			}
+		} else {
+			field.Values = []Value{f.value(value)}
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// textItems flattens the separators between the fields of an
+// aggregate or the elements of a list.
+func (f *File) textItems(idxs []int) []int {
+	var items []int
+	for _, idx := range idxs {
+		switch f.kind(idx) {
+		case lexer.TokenKindComma, lexer.TokenKindSemicolon:
+			items = append(items, f.textItems(f.children(idx))...)
+		default:
+			items = append(items, idx)
+		}
+	}
+	return items
+}
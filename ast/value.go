@@ -0,0 +1,146 @@
+package ast
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Clement-Jean/protein/lexer"
+)
+
+func (v Value) String() (string, error) {
+	if v.Kind != lexer.TokenKindStr {
+		return "", fmt.Errorf("expected %s, got %s", lexer.TokenKindStr, v.Kind)
+	}
+	return unquote(v.Text)
+}
+
+func (v Value) Int() (int64, error) {
+	if v.Kind != lexer.TokenKindInt {
+		return 0, fmt.Errorf("expected %s, got %s", lexer.TokenKindInt, v.Kind)
+	}
+	return strconv.ParseInt(v.Text, 0, 64)
+}
+
+func (v Value) Uint() (uint64, error) {
+	if v.Kind != lexer.TokenKindInt {
+		return 0, fmt.Errorf("expected %s, got %s", lexer.TokenKindInt, v.Kind)
+	}
+	return strconv.ParseUint(strings.TrimPrefix(v.Text, "+"), 0, 64)
+}
+
+func (v Value) Float() (float64, error) {
+	switch v.Kind {
+	case lexer.TokenKindInt:
+		i, err := v.Int()
+		return float64(i), err
+	case lexer.TokenKindFloat:
+		return strconv.ParseFloat(v.Text, 64)
+	case lexer.TokenKindIdentifier:
+		switch v.Text {
+		case "inf":
+			return strconv.ParseFloat("+Inf", 64)
+		case "nan":
+			return strconv.ParseFloat("NaN", 64)
+		}
+	}
+	return 0, fmt.Errorf("expected %s, got %s", lexer.TokenKindFloat, v.Kind)
+}
+
+func (v Value) Bool() (bool, error) {
+	switch v.Kind {
+	case lexer.TokenKindTrue:
+		return true, nil
+	case lexer.TokenKindFalse:
+		return false, nil
+	}
+	return false, fmt.Errorf("expected %s or %s, got %s", lexer.TokenKindTrue, lexer.TokenKindFalse, v.Kind)
+}
+
+var errInvalidEscape = errors.New("invalid escape sequence")
+
+// unquote decodes a single or double quoted proto string.
+func unquote(s string) (string, error) {
+	if len(s) < 2 || (s[0] != '"' && s[0] != '\'') || s[len(s)-1] != s[0] {
+		return "", errors.New("invalid string literal")
+	}
+	s = s[1 : len(s)-1]
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' {
+			b.WriteByte(s[i])
+			continue
+		}
+
+		i++
+		if i >= len(s) {
+			return "", errInvalidEscape
+		}
+
+		switch c := s[i]; c {
+		case 'a':
+			b.WriteByte('\a')
+		case 'b':
+			b.WriteByte('\b')
+		case 'f':
+			b.WriteByte('\f')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case 'v':
+			b.WriteByte('\v')
+		case '\\', '\'', '"', '?':
+			b.WriteByte(c)
+		case 'x', 'X':
+			end := i + 1
+			for end < len(s) && end < i+3 && isHex(s[end]) {
+				end++
+			}
+			if end == i+1 {
+				return "", errInvalidEscape
+			}
+			n, _ := strconv.ParseUint(s[i+1:end], 16, 8)
+			b.WriteByte(byte(n))
+			i = end - 1
+		case 'u', 'U':
+			size := 4
+			if c == 'U' {
+				size = 8
+			}
+			if i+1+size > len(s) {
+				return "", errInvalidEscape
+			}
+			n, err := strconv.ParseUint(s[i+1:i+1+size], 16, 32)
+			if err != nil || !utf8.ValidRune(rune(n)) {
+				return "", errInvalidEscape
+			}
+			b.WriteRune(rune(n))
+			i += size
+		default:
+			if c < '0' || c > '7' {
+				return "", errInvalidEscape
+			}
+			end := i
+			for end < len(s) && end < i+3 && s[end] >= '0' && s[end] <= '7' {
+				end++
+			}
+			n, _ := strconv.ParseUint(s[i:end], 8, 16)
+			if n > 0xFF {
+				return "", errInvalidEscape
+			}
+			b.WriteByte(byte(n))
+			i = end - 1
+		}
+	}
+	return b.String(), nil
+}
+
+func isHex(b byte) bool {
+	return (b >= '0' && b <= '9') || (b >= 'a' && b <= 'f') || (b >= 'A' && b <= 'F')
+}
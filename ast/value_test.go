@@ -0,0 +1,42 @@
+package ast_test
+
+import (
+	"testing"
+
+	"github.com/Clement-Jean/protein/ast"
+	"github.com/Clement-Jean/protein/lexer"
+)
+
+func TestValueString(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{`"abc"`, "abc"},
+		{`'abc'`, "abc"},
+		{`"a\nb\tc"`, "a\nb\tc"},
+		{`"\x41\101"`, "AA"},
+		{`"é"`, "é"},
+		{`"\'\"\\"`, `'"\`},
+	}
+
+	for _, test := range tests {
+		v := ast.Value{Token: ast.Token{Kind: lexer.TokenKindStr, Text: test.text}}
+		s, err := v.String()
+		if err != nil {
+			t.Fatalf("%s: %v", test.text, err)
+		}
+		if s != test.expected {
+			t.Errorf("%s: expected %q, got %q", test.text, test.expected, s)
+		}
+	}
+}
+
+func TestValueStringInvalid(t *testing.T) {
+	for _, text := range []string{`"\q"`, `"\u12"`, `"\400"`, `"abc`} {
+		v := ast.Value{Token: ast.Token{Kind: lexer.TokenKindStr, Text: text}}
+		if _, err := v.String(); err == nil {
+			t.Errorf("%s: expected error", text)
+		}
+	}
+}
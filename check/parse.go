@@ -0,0 +1,260 @@
+package check
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+
+	"github.com/Clement-Jean/protein/ast"
+	"github.com/Clement-Jean/protein/diagnostic"
+	"github.com/Clement-Jean/protein/lexer"
+	"github.com/Clement-Jean/protein/parser"
+	"github.com/Clement-Jean/protein/rewrite"
+	"github.com/Clement-Jean/protein/source"
+)
+
+// insertTexts are the texts inserted for the missing tokens.
+var insertTexts = map[lexer.TokenKind]string{
+	lexer.TokenKindSemicolon:  ";",
+	lexer.TokenKindRightBrace: "\n}",
+	lexer.TokenKindEqual:      " =",
+}
+
+// topLevelKeywords start the statements which are only allowed at
+// the top level of a file. In a message, they are parsed as fields
+// (e.g. service S {) because the message misses its closing brace.
+var topLevelKeywords = []lexer.TokenKind{
+	lexer.TokenKindSyntax,
+	lexer.TokenKindEdition,
+	lexer.TokenKindPackage,
+	lexer.TokenKindImport,
+	lexer.TokenKindService,
+}
+
+// Parse turns the parser errors into diagnostics, with fixes when the
+// intent is clear. pf is the source.File given to the parser, nil if
+// there was none. The errors which are not parser.ExpectedError are
+// skipped.
+func Parse(file *ast.File, pf *source.File, errs []error) []diagnostic.Diagnostic {
+	missing := make(map[uint32][]int) // token index -> missing nodes
+	for i, node := range file.Tree {
+		if node.IsMissing() && node.HasError {
+			missing[node.TokIdx] = append(missing[node.TokIdx], i)
+		}
+	}
+
+	var diags []diagnostic.Diagnostic
+	closed := 0
+	for _, err := range errs {
+		e, ok := err.(*parser.ExpectedError)
+		if !ok {
+			continue
+		}
+
+		tokIdx := tokenAt(file.Toks, e.Offset(pf))
+		start, end := file.TokenSpan(tokIdx)
+		d := diagnostic.Diagnostic{
+			Severity: diagnostic.SeverityError,
+			Msg:      e.Error(),
+			Start:    start,
+			End:      end,
+		}
+
+		if depth, ok := topLevelInBlock(file, tokIdx); ok {
+			// the statement is not a field, the only fix is to close
+			// the blocks once before the first of them
+			if depth > closed {
+				d.Fixes = append(d.Fixes, closeBlocks(file, tokIdx, depth-closed))
+				closed = depth
+			}
+		} else if fix, ok := insertMissing(file, missing[tokIdx], e.Expected); ok {
+			// the braces inserted before the top level statements
+			// are the ones missing later
+			if closed > 0 && fix.Edits[0].NewText == insertTexts[lexer.TokenKindRightBrace] {
+				closed--
+			} else {
+				d.Fixes = append(d.Fixes, fix)
+			}
+		} else if typo, ok := unknownKeyword(file, tokIdx, e); ok {
+			d = typo
+		} else if fix, ok := wrapRPCType(file, tokIdx, e.Expected); ok {
+			d.Fixes = append(d.Fixes, fix)
+		}
+		diags = append(diags, d)
+	}
+	return diags
+}
+
+// tokenAt returns the index of the last token starting at offset,
+// the BOF token and the first token both start at 0.
+func tokenAt(toks *lexer.TokenizedBuffer, offset uint32) uint32 {
+	idx, _ := slices.BinarySearchFunc(toks.TokenInfos, offset+1, func(tok lexer.TokenInfo, offset uint32) int {
+		return int(tok.Offset) - int(offset)
+	})
+	return uint32(max(idx-1, 0))
+}
+
+// unknownKeyword reports the misspelled keywords, which are lexed as
+// identifiers. The keyword is either the unexpected token or the
+// identifier starting the statement (e.g. repaeted int32 a = 1;).
+func unknownKeyword(file *ast.File, tokIdx uint32, e *parser.ExpectedError) (diagnostic.Diagnostic, bool) {
+	toks := file.Toks.TokenInfos
+	if e.Got == lexer.TokenKindIdentifier {
+		expected := func(yield func(string) bool) {
+			for _, kind := range e.Expected {
+				if kind.IsIdentifier() && kind != lexer.TokenKindIdentifier && !yield(kind.String()) {
+					return
+				}
+			}
+		}
+		if d, ok := suggestKeyword(file, tokIdx, expected); ok {
+			return d, true
+		}
+	}
+
+	start := statementStart(toks, tokIdx)
+	if start == tokIdx || toks[start].Kind != lexer.TokenKindIdentifier {
+		return diagnostic.Diagnostic{}, false
+	}
+
+	keywords := func(yield func(string) bool) {
+		for keyword := range lexer.Keywords() {
+			if !yield(keyword) {
+				return
+			}
+		}
+	}
+	return suggestKeyword(file, start, keywords)
+}
+
+// statementStart returns the index of the first token, comments
+// excluded, of the statement containing tokIdx.
+func statementStart(toks []lexer.TokenInfo, tokIdx uint32) uint32 {
+	start := tokIdx
+	for start > 0 {
+		kind := toks[start-1].Kind
+		if kind == lexer.TokenKindSemicolon || kind == lexer.TokenKindLeftBrace ||
+			kind == lexer.TokenKindRightBrace || kind == lexer.TokenKindBOF {
+			break
+		}
+		start--
+	}
+	for start < tokIdx && toks[start].Kind == lexer.TokenKindComment {
+		start++
+	}
+	return start
+}
+
+// topLevelInBlock reports whether the statement containing tokIdx
+// starts with a top level keyword but is in a block (e.g. message M {
+// int32 a = 1; service S {}), and the depth of the block.
+func topLevelInBlock(file *ast.File, tokIdx uint32) (int, bool) {
+	toks := file.Toks.TokenInfos
+	start := statementStart(toks, tokIdx)
+	if !slices.Contains(topLevelKeywords, toks[start].Kind) {
+		return 0, false
+	}
+
+	depth := 0
+	for _, tok := range toks[:start] {
+		switch tok.Kind {
+		case lexer.TokenKindLeftBrace:
+			depth++
+		case lexer.TokenKindRightBrace:
+			depth--
+		}
+	}
+	return depth, depth > 0
+}
+
+// closeBlocks inserts n closing braces before the statement containing
+// tokIdx.
+func closeBlocks(file *ast.File, tokIdx uint32, n int) diagnostic.Fix {
+	toks := file.Toks.TokenInfos
+	start := toks[statementStart(toks, tokIdx)].Offset
+
+	// before the indentation of the statement
+	src := file.Src.Bytes()
+	offset := start
+	for offset > 0 && (src[offset-1] == ' ' || src[offset-1] == '\t') {
+		offset--
+	}
+	text := strings.Repeat("}\n", n)
+	if offset != 0 && src[offset-1] != '\n' {
+		offset, text = start, strings.Repeat("} ", n)
+	}
+	return diagnostic.Fix{
+		Message: "insert '}'",
+		Edits:   []rewrite.Edit{{Start: offset, End: offset, NewText: text}},
+	}
+}
+
+func suggestKeyword(file *ast.File, tokIdx uint32, keywords iter.Seq[string]) (diagnostic.Diagnostic, bool) {
+	start, end := file.TokenSpan(tokIdx)
+	word := string(file.Src.Range(start, end))
+	keyword, ok := closest(word, keywords)
+	if !ok {
+		return diagnostic.Diagnostic{}, false
+	}
+
+	return diagnostic.Diagnostic{
+		Severity: diagnostic.SeverityError,
+		Msg:      fmt.Sprintf("unknown keyword %q, did you mean %q?", word, keyword),
+		Start:    start,
+		End:      end,
+		Fixes: []diagnostic.Fix{{
+			Message: fmt.Sprintf("replace %s with %s", word, keyword),
+			Edits:   []rewrite.Edit{{Start: start, End: end, NewText: keyword}},
+		}},
+	}, true
+}
+
+func insertMissing(file *ast.File, nodes []int, expected []lexer.TokenKind) (diagnostic.Fix, bool) {
+	for _, idx := range nodes {
+		kind := file.Tree[idx].Missing
+		text, ok := insertTexts[kind]
+		if !ok || !slices.Contains(expected, kind) {
+			continue
+		}
+
+		offset := file.Tree.InsertOffset(idx, file.Src, file.Toks)
+		return diagnostic.Fix{
+			Message: fmt.Sprintf("insert '%s'", kind),
+			Edits:   []rewrite.Edit{{Start: offset, End: offset, NewText: text}},
+		}, true
+	}
+	return diagnostic.Fix{}, false
+}
+
+// wrapRPCType adds the parentheses around an rpc input or output
+// type (e.g. rpc Get GetRequest returns ...). Only rpcs expect a
+// single opening parenthesis.
+func wrapRPCType(file *ast.File, tokIdx uint32, expected []lexer.TokenKind) (diagnostic.Fix, bool) {
+	if !slices.Equal(expected, []lexer.TokenKind{lexer.TokenKindLeftParen}) {
+		return diagnostic.Fix{}, false
+	}
+
+	// [stream] ident {. ident}
+	toks := file.Toks.TokenInfos
+	last := tokIdx
+	if toks[last].Kind == lexer.TokenKindStream && toks[last+1].Kind.IsIdentifier() {
+		last++
+	}
+	if !toks[last].Kind.IsIdentifier() {
+		return diagnostic.Fix{}, false
+	}
+	for last+2 < uint32(len(toks)) && toks[last+1].Kind == lexer.TokenKindDot && toks[last+2].Kind.IsIdentifier() {
+		last += 2
+	}
+
+	start, _ := file.TokenSpan(tokIdx)
+	_, end := file.TokenSpan(last)
+	return diagnostic.Fix{
+		Message: "wrap the type in parentheses",
+		Edits: []rewrite.Edit{
+			{Start: start, End: start, NewText: "("},
+			{Start: end, End: end, NewText: ")"},
+		},
+	}, true
+}
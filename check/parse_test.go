@@ -0,0 +1,216 @@
+package check_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Clement-Jean/protein/ast"
+	"github.com/Clement-Jean/protein/check"
+	"github.com/Clement-Jean/protein/lexer"
+	"github.com/Clement-Jean/protein/parser"
+	"github.com/Clement-Jean/protein/rewrite"
+	"github.com/Clement-Jean/protein/source"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+		fixed    string
+	}{
+		{
+			name:  "valid",
+			input: `syntax = "proto3"; message M { int32 a = 1; }`,
+		},
+		{
+			name: "missing_semicolon",
+			input: `syntax = "proto3"
+message M {
+  int32 a = 1 // comment
+  int32 b = 2;
+}
+`,
+			expected: []string{`expected ";", got "message"`, `expected ";", got a comment`},
+			fixed: `syntax = "proto3";
+message M {
+  int32 a = 1; // comment
+  int32 b = 2;
+}
+`,
+		},
+		{
+			name: "missing_equal",
+			input: `syntax "proto3";
+message M { int32 a 1; }
+`,
+			expected: []string{`expected "=", got a string`, `expected "=", got an integer`},
+			fixed: `syntax = "proto3";
+message M { int32 a = 1; }
+`,
+		},
+		{
+			name: "missing_right_brace",
+			input: `message M {
+  message N {
+    int32 a = 1;`,
+			expected: []string{`expected "}", got end of file`, `expected "}", got end of file`},
+			fixed: `message M {
+  message N {
+    int32 a = 1;
+}
+}`,
+		},
+		{
+			name: "unclosed_message",
+			input: `syntax = "proto3";
+message M {
+  int32 a = 1;
+service S {
+  rpc Get (M) returns (M);
+}
+`,
+			expected: []string{`expected "=", got "{"`},
+			fixed: `syntax = "proto3";
+message M {
+  int32 a = 1;
+}
+service S {
+  rpc Get (M) returns (M);
+}
+`,
+		},
+		{
+			name:     "unclosed_message_inline",
+			input:    `message M { int32 a = 1; package pkg;`,
+			expected: []string{`expected "=", got ";"`, `expected "}", got end of file`},
+			fixed:    `message M { int32 a = 1; } package pkg;`,
+		},
+		{
+			name: "unclosed_nested_message",
+			input: `message M {
+  message N {
+    int32 a = 1;
+import "x.proto";
+package pkg;
+`,
+			expected: []string{
+				`expected an identifier, got a string`,
+				`expected "=", got ";"`,
+				`expected "}", got end of file`,
+				`expected "}", got end of file`,
+			},
+			fixed: `message M {
+  message N {
+    int32 a = 1;
+}
+}
+import "x.proto";
+package pkg;
+`,
+		},
+		{
+			name:     "missing_identifier",
+			input:    `message M { int32 = 1; }`,
+			expected: []string{`expected an identifier, got "="`},
+		},
+		{
+			name:     "returns_typo",
+			input:    `service S { rpc Get (Req) return (Res); }`,
+			expected: []string{`unknown keyword "return", did you mean "returns"?`},
+			fixed:    `service S { rpc Get (Req) returns (Res); }`,
+		},
+		{
+			name:     "returns_unrelated",
+			input:    `service S { rpc Get (Req) gives (Res); }`,
+			expected: []string{`expected "returns", got an identifier`},
+		},
+		{
+			name: "keyword_typo",
+			input: `mesage M {
+  int32 a = 1;
+}
+message N {
+  repaeted int32 a = 1;
+  Foo bar baz = 2;
+}
+`,
+			expected: []string{
+				`unknown keyword "mesage", did you mean "message"?`,
+				`unknown keyword "repaeted", did you mean "repeated"?`,
+				`expected "=", got an identifier`,
+			},
+			fixed: `message M {
+  int32 a = 1;
+}
+message N {
+  repeated int32 a = 1;
+  Foo bar baz = 2;
+}
+`,
+		},
+		{
+			name:     "bare_rpc_type",
+			input:    `service S { rpc Get pkg.Req returns (Res); }`,
+			expected: []string{`expected "(", got an identifier`},
+			fixed:    `service S { rpc Get (pkg.Req) returns (Res); }`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			src, err := source.NewFromReader(strings.NewReader(test.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			l, err := lexer.NewFromSource(src)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			toks, errs := l.Lex()
+			if len(errs) != 0 {
+				t.Fatal(errs)
+			}
+
+			fset := source.NewFileSet()
+			pf, err := fset.AddFile("test.proto", src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tree, errs := parser.NewForFile(pf, toks, nil).Parse()
+			file := ast.New(src, toks, tree)
+			diags := check.Parse(file, pf, errs)
+
+			var got []string
+			var edits []rewrite.Edit
+			for _, d := range diags {
+				got = append(got, d.Msg)
+				for _, fix := range d.Fixes {
+					edits = append(edits, fix.Edits...)
+				}
+			}
+			if diff := cmp.Diff(test.expected, got); diff != "" {
+				t.Errorf("%s mismatch (-want +got):\n%s", t.Name(), diff)
+			}
+
+			if len(edits) == 0 {
+				if test.fixed != "" {
+					t.Error("expected fixes")
+				}
+				return
+			}
+
+			fixed, err := rewrite.Apply(src.Bytes(), edits)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.fixed, string(fixed)); diff != "" {
+				t.Errorf("%s fix mismatch (-want +got):\n%s", t.Name(), diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package check
+
+import "iter"
+
+// closest returns the candidate closest to word, if it is close
+// enough to be a typo: one edit for short words, two otherwise.
+// word itself is never suggested.
+func closest(word string, candidates iter.Seq[string]) (string, bool) {
+	limit := 1
+	if len(word) > 4 {
+		limit = 2
+	}
+
+	best, bestDist := "", limit+1
+	for candidate := range candidates {
+		if d := distance(word, candidate); d != 0 && d < bestDist {
+			best, bestDist = candidate, d
+		}
+	}
+	return best, bestDist <= limit
+}
+
+// distance is the number of insertions, deletions, substitutions and
+// transpositions of adjacent bytes needed to go from a to b (optimal
+// string alignment).
+func distance(a, b string) int {
+	// rows i-2, i-1 and i of the matrix
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				curr[j] = min(curr[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+	return prev[len(b)]
+}
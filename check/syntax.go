@@ -0,0 +1,255 @@
+package check
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Clement-Jean/protein/ast"
+	"github.com/Clement-Jean/protein/diagnostic"
+	"github.com/Clement-Jean/protein/lexer"
+	"github.com/Clement-Jean/protein/rewrite"
+)
+
+type syntaxChecker struct {
+	file   *ast.File
+	proto3 bool
+	diags  []diagnostic.Diagnostic
+}
+
+// Syntax reports the constructs that are not allowed by the syntax
+// of the file (proto2 when there is no syntax statement). Files
+// using editions are not checked here.
+func Syntax(file *ast.File) []diagnostic.Diagnostic {
+	if file.Edition != nil {
+		return nil
+	}
+
+	c := &syntaxChecker{file: file}
+	if file.Syntax != nil {
+		s, _ := file.Syntax.Value.String()
+		switch s {
+		case "proto2":
+		case "proto3":
+			c.proto3 = true
+		default:
+			c.report(file.Syntax.Value.Token, fmt.Sprintf("unknown syntax %q", s))
+			return c.diags
+		}
+	}
+
+	for _, msg := range file.Messages {
+		c.message(msg)
+	}
+	for _, enum := range file.Enums {
+		c.enum(enum)
+	}
+	for _, ext := range file.Extends {
+		c.extend(ext)
+	}
+	if c.proto3 {
+		c.groups()
+	}
+	return c.diags
+}
+
+func (c *syntaxChecker) report(tok ast.Token, msg string, fixes ...diagnostic.Fix) {
+	start, end := c.file.TokenSpan(tok.Idx)
+	c.diags = append(c.diags, diagnostic.Diagnostic{
+		Severity: diagnostic.SeverityError,
+		Msg:      msg,
+		Start:    start,
+		End:      end,
+		Fixes:    fixes,
+	})
+}
+
+func (c *syntaxChecker) message(msg *ast.Message) {
+	for _, field := range msg.Fields {
+		c.field(field)
+	}
+
+	if c.proto3 {
+		for _, ext := range msg.Extensions {
+			start, end := c.file.NodeSpan(ext.Node)
+			c.diags = append(c.diags, diagnostic.Diagnostic{
+				Severity: diagnostic.SeverityError,
+				Msg:      "extension ranges are not allowed in proto3",
+				Start:    start,
+				End:      end,
+				Fixes: []diagnostic.Fix{{
+					Message: "remove the extension range",
+					Edits:   []rewrite.Edit{rewrite.DeleteNode(c.file, ext.Node)},
+				}},
+			})
+		}
+	}
+
+	for _, nested := range msg.Messages {
+		c.message(nested)
+	}
+	for _, enum := range msg.Enums {
+		c.enum(enum)
+	}
+	for _, ext := range msg.Extends {
+		c.extend(ext)
+	}
+}
+
+func (c *syntaxChecker) extend(ext *ast.Extend) {
+	for _, field := range ext.Fields {
+		c.field(field)
+	}
+}
+
+func (c *syntaxChecker) field(field *ast.Field) {
+	switch {
+	case c.proto3 && field.Label == ast.LabelRequired:
+		c.report(field.LabelToken, "required fields are not allowed in proto3", diagnostic.Fix{
+			Message: "remove the required label",
+			Edits:   []rewrite.Edit{rewrite.DeleteToken(c.file, field.LabelToken.Idx)},
+		})
+	case !c.proto3 && field.Label == ast.LabelNone && field.Oneof == nil && field.Map == nil:
+		start, _ := c.file.NodeSpan(field.Node)
+		c.report(field.Name, "fields must have a label in proto2", diagnostic.Fix{
+			Message: "add the optional label",
+			Edits:   []rewrite.Edit{{Start: start, End: start, NewText: "optional "}},
+		})
+	}
+
+	if !c.proto3 {
+		return
+	}
+
+	for i, opt := range field.Options {
+		if len(opt.Name) != 1 || opt.Name[0].Extension || opt.Name[0].Name.String() != "default" {
+			continue
+		}
+
+		c.report(opt.Name[0].Name.Parts[0], "default values are not allowed in proto3", diagnostic.Fix{
+			Message: "remove the default value",
+			Edits:   []rewrite.Edit{c.deleteCompactOption(field, i)},
+		})
+	}
+}
+
+// deleteCompactOption returns the edit removing the ith option of
+// a field, along with the brackets if it is the only option.
+func (c *syntaxChecker) deleteCompactOption(field *ast.Field, i int) rewrite.Edit {
+	opts := field.Options
+	start, end := c.file.NodeSpan(opts[i].Node)
+
+	switch {
+	case len(opts) == 1:
+		_, start = c.file.TokenSpan(field.Number.Idx)
+		toks := c.file.Toks.TokenInfos
+		optEnd := end
+		for j := field.Number.Idx + 1; j < uint32(len(toks)); j++ {
+			if toks[j].Kind == lexer.TokenKindRightSquare && toks[j].Offset >= optEnd {
+				_, end = c.file.TokenSpan(j)
+				break
+			}
+		}
+	case i+1 < len(opts):
+		end, _ = c.file.NodeSpan(opts[i+1].Node)
+	default:
+		_, start = c.file.NodeSpan(opts[i-1].Node)
+	}
+	return rewrite.Edit{Start: start, End: end}
+}
+
+func (c *syntaxChecker) enum(enum *ast.Enum) {
+	if !c.proto3 || len(enum.Values) == 0 {
+		return
+	}
+
+	first := enum.Values[0]
+	if v, err := first.Number.Int(); err == nil && v == 0 {
+		return
+	}
+
+	decl := fmt.Sprintf("%s_UNSPECIFIED = 0;", upperSnake(enum.Name.Text))
+	src := c.file.Src.Bytes()
+	start, _ := c.file.NodeSpan(first.Node)
+	lineStart := start
+	for lineStart > 0 && (src[lineStart-1] == ' ' || src[lineStart-1] == '\t') {
+		lineStart--
+	}
+
+	edit := rewrite.Edit{Start: start, End: start, NewText: decl + " "}
+	if lineStart == 0 || src[lineStart-1] == '\n' {
+		edit = rewrite.Edit{Start: lineStart, End: lineStart, NewText: string(src[lineStart:start]) + decl + "\n"}
+	}
+
+	c.report(first.Number.Token, "the first enum value must be zero in proto3", diagnostic.Fix{
+		Message: fmt.Sprintf("add %s", decl),
+		Edits:   []rewrite.Edit{edit},
+	})
+}
+
+// groups reports the group fields. The parser does not support them
+// (they are parsing errors) so they are detected on the tokens: group
+// Name = Number, optional options and the opening brace of the body.
+// A field whose type is a message named group ends with a semicolon
+// instead and is not reported.
+func (c *syntaxChecker) groups() {
+	var toks []uint32 // the comments are skipped
+	for i, tok := range c.file.Toks.TokenInfos {
+		if tok.Kind != lexer.TokenKindComment {
+			toks = append(toks, uint32(i))
+		}
+	}
+	kind := func(i int) lexer.TokenKind {
+		if i >= len(toks) {
+			return lexer.TokenKindEOF
+		}
+		return c.file.Toks.TokenInfos[toks[i]].Kind
+	}
+
+	for i := range toks {
+		if kind(i) != lexer.TokenKindIdentifier || !kind(i+1).IsIdentifier() ||
+			kind(i+2) != lexer.TokenKindEqual || kind(i+3) != lexer.TokenKindInt {
+			continue
+		}
+
+		start, end := c.file.TokenSpan(toks[i])
+		if string(c.file.Src.Range(start, end)) != "group" {
+			continue
+		}
+
+		body := i + 4
+		if kind(body) == lexer.TokenKindLeftSquare {
+			for depth := 0; body < len(toks); body++ {
+				if k := kind(body); k == lexer.TokenKindLeftSquare {
+					depth++
+				} else if k == lexer.TokenKindRightSquare {
+					if depth--; depth == 0 {
+						break
+					}
+				}
+			}
+			body++
+		}
+		if kind(body) != lexer.TokenKindLeftBrace {
+			continue
+		}
+
+		c.diags = append(c.diags, diagnostic.Diagnostic{
+			Severity: diagnostic.SeverityError,
+			Msg:      "groups are not allowed in proto3, use a message field",
+			Start:    start,
+			End:      end,
+		})
+	}
+}
+
+// upperSnake converts a CamelCase name to UPPER_SNAKE_CASE.
+func upperSnake(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if r >= 'A' && r <= 'Z' && i != 0 && name[i-1] >= 'a' && name[i-1] <= 'z' {
+			b.WriteByte('_')
+		}
+		b.WriteRune(r)
+	}
+	return strings.ToUpper(b.String())
+}
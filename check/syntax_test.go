@@ -0,0 +1,253 @@
+package check_test
+
+import (
+	"testing"
+
+	"github.com/Clement-Jean/protein/check"
+	"github.com/Clement-Jean/protein/internal/asttest"
+	"github.com/Clement-Jean/protein/rewrite"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSyntax(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+		fixed    string
+	}{
+		{
+			name: "proto3_valid",
+			input: `syntax = "proto3";
+message M { optional int32 a = 1; repeated int32 b = 2; }
+enum E { E_UNSPECIFIED = 0; }
+`,
+		},
+		{
+			name: "proto3_required",
+			input: `syntax = "proto3";
+message M {
+  required int32 a = 1;
+}
+`,
+			expected: []string{"required fields are not allowed in proto3"},
+			fixed: `syntax = "proto3";
+message M {
+  int32 a = 1;
+}
+`,
+		},
+		{
+			name: "proto3_default",
+			input: `syntax = "proto3";
+message M {
+  int32 a = 1 [default = 2];
+  int32 b = 2 [default = 2, deprecated = true];
+  int32 c = 3 [deprecated = true, default = 2];
+}
+`,
+			expected: []string{
+				"default values are not allowed in proto3",
+				"default values are not allowed in proto3",
+				"default values are not allowed in proto3",
+			},
+			fixed: `syntax = "proto3";
+message M {
+  int32 a = 1;
+  int32 b = 2 [deprecated = true];
+  int32 c = 3 [deprecated = true];
+}
+`,
+		},
+		{
+			name: "proto3_extend",
+			input: `syntax = "proto3";
+import "google/protobuf/descriptor.proto";
+extend google.protobuf.FieldOptions {
+  required int32 a = 1000 [default = 2];
+}
+message M {
+  extend google.protobuf.MessageOptions {
+    required string b = 1000;
+    optional group C = 1001 {}
+  }
+}
+`,
+			expected: []string{
+				"required fields are not allowed in proto3",
+				"required fields are not allowed in proto3",
+				"default values are not allowed in proto3",
+				"groups are not allowed in proto3, use a message field",
+			},
+			fixed: `syntax = "proto3";
+import "google/protobuf/descriptor.proto";
+extend google.protobuf.FieldOptions {
+  int32 a = 1000;
+}
+message M {
+  extend google.protobuf.MessageOptions {
+    string b = 1000;
+    optional group C = 1001 {}
+  }
+}
+`,
+		},
+		{
+			name: "proto3_extensions",
+			input: `syntax = "proto3";
+message M {
+  extensions 100 to max;
+  message N { extensions 1; int32 a = 1; }
+}
+`,
+			expected: []string{
+				"extension ranges are not allowed in proto3",
+				"extension ranges are not allowed in proto3",
+			},
+			fixed: `syntax = "proto3";
+message M {
+  message N { int32 a = 1; }
+}
+`,
+		},
+		{
+			name: "proto3_enum_first_value",
+			input: `syntax = "proto3";
+enum PhoneType {
+  PHONE_TYPE_MOBILE = 1;
+}
+message M { enum Inline { A = 1; } }
+`,
+			expected: []string{
+				"the first enum value must be zero in proto3",
+				"the first enum value must be zero in proto3",
+			},
+			fixed: `syntax = "proto3";
+enum PhoneType {
+  PHONE_TYPE_UNSPECIFIED = 0;
+  PHONE_TYPE_MOBILE = 1;
+}
+message M { enum Inline { INLINE_UNSPECIFIED = 0; A = 1; } }
+`,
+		},
+		{
+			name: "proto3_group",
+			input: `syntax = "proto3";
+message M {
+  repeated group Result = 1 {}
+  optional group /* comment */ WithOptions = 2 [deprecated = true, (opt) = { a: [1] }] {}
+}
+`,
+			expected: []string{
+				"groups are not allowed in proto3, use a message field",
+				"groups are not allowed in proto3, use a message field",
+			},
+		},
+		{
+			// groups are found on the tokens, a field of type group is
+			// not one
+			name: "proto3_group_type",
+			input: `syntax = "proto3";
+message group {}
+message M {
+  group g = 1;
+  group h = 2 [deprecated = true];
+}
+`,
+		},
+		{
+			name: "proto2_missing_label",
+			input: `syntax = "proto2";
+message M {
+  int32 a = 1;
+  .pkg.M b = 2;
+  map<string, int32> c = 3;
+  oneof o { int32 d = 4; }
+  required int32 e = 5 [default = 1];
+}
+`,
+			expected: []string{
+				"fields must have a label in proto2",
+				"fields must have a label in proto2",
+			},
+			fixed: `syntax = "proto2";
+message M {
+  optional int32 a = 1;
+  optional .pkg.M b = 2;
+  map<string, int32> c = 3;
+  oneof o { int32 d = 4; }
+  required int32 e = 5 [default = 1];
+}
+`,
+		},
+		{
+			name: "proto2_extend_missing_label",
+			input: `syntax = "proto2";
+extend M { int32 a = 100; }
+message M {
+  extensions 100 to 200;
+  extend M { repeated int32 b = 101; int32 c = 102; }
+}
+`,
+			expected: []string{
+				"fields must have a label in proto2",
+				"fields must have a label in proto2",
+			},
+			fixed: `syntax = "proto2";
+extend M { optional int32 a = 100; }
+message M {
+  extensions 100 to 200;
+  extend M { repeated int32 b = 101; optional int32 c = 102; }
+}
+`,
+		},
+		{
+			name:     "proto2_by_default",
+			input:    `message M { int32 a = 1; }`,
+			expected: []string{"fields must have a label in proto2"},
+			fixed:    `message M { optional int32 a = 1; }`,
+		},
+		{
+			name:  "editions",
+			input: `edition = "2023"; message M { int32 a = 1; }`,
+		},
+		{
+			name:     "unknown_syntax",
+			input:    `syntax = "proto4";`,
+			expected: []string{`unknown syntax "proto4"`},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// some constructs (e.g. groups) are parsing errors
+			file, _ := asttest.Parse(t, test.input)
+			diags := check.Syntax(file)
+
+			var got []string
+			var edits []rewrite.Edit
+			for _, d := range diags {
+				got = append(got, d.Msg)
+				for _, fix := range d.Fixes {
+					edits = append(edits, fix.Edits...)
+				}
+			}
+			if diff := cmp.Diff(test.expected, got); diff != "" {
+				t.Errorf("%s mismatch (-want +got):\n%s", t.Name(), diff)
+			}
+
+			if len(edits) == 0 {
+				return
+			}
+
+			fixed, err := rewrite.Apply(file.Src.Bytes(), edits)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.fixed, string(fixed)); diff != "" {
+				t.Errorf("%s fix mismatch (-want +got):\n%s", t.Name(), diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package check
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+
+	"github.com/Clement-Jean/protein/ast"
+	"github.com/Clement-Jean/protein/diagnostic"
+	"github.com/Clement-Jean/protein/lexer"
+	"github.com/Clement-Jean/protein/rewrite"
+	"github.com/Clement-Jean/protein/symbols"
+)
+
+type typeChecker struct {
+	file  *ast.File
+	table *symbols.Table
+	diags []diagnostic.Diagnostic
+}
+
+// Types reports the field, extendee and rpc types which cannot be
+// resolved, suggesting the closest scalar type or declaration. deps
+// are the files imported by file, the types of missing imports are
+// reported.
+func Types(file *ast.File, deps ...*ast.File) []diagnostic.Diagnostic {
+	// the duplicates are not type errors, the first definition is kept
+	table, _ := symbols.New(append([]*ast.File{file}, deps...)...)
+	c := &typeChecker{file: file, table: table}
+
+	pkg := packageName(file)
+	for _, msg := range file.Messages {
+		c.message(join(pkg, msg.Name.Text), msg)
+	}
+	for _, ext := range file.Extends {
+		c.extend(pkg, ext)
+	}
+	for _, service := range file.Services {
+		for _, rpc := range service.RPCs {
+			c.check(pkg, rpc.Input)
+			c.check(pkg, rpc.Output)
+		}
+	}
+	return c.diags
+}
+
+func packageName(file *ast.File) string {
+	if file.Package == nil {
+		return ""
+	}
+	return file.Package.Name.String()
+}
+
+func join(scope, name string) string {
+	if scope == "" {
+		return name
+	}
+	return scope + "." + name
+}
+
+func (c *typeChecker) message(scope string, msg *ast.Message) {
+	c.fields(scope, msg.Fields)
+	for _, nested := range msg.Messages {
+		c.message(join(scope, nested.Name.Text), nested)
+	}
+	for _, ext := range msg.Extends {
+		c.extend(scope, ext)
+	}
+}
+
+// extend checks the extendee and the extension fields, resolved in
+// the scope of the extend block.
+func (c *typeChecker) extend(scope string, ext *ast.Extend) {
+	c.check(scope, ext.Extendee)
+	c.fields(scope, ext.Fields)
+}
+
+func (c *typeChecker) fields(scope string, fields []*ast.Field) {
+	for _, field := range fields {
+		switch {
+		case field.Map != nil:
+			if !field.Map.Value.IsScalar() {
+				c.check(scope, field.Map.Value.Name)
+			}
+		case !field.Type.IsScalar():
+			c.check(scope, field.Type.Name)
+		}
+	}
+}
+
+func (c *typeChecker) check(scope string, name ast.FullIdent) {
+	if len(name.Parts) == 0 || name.Parts[0].Missing {
+		return
+	}
+	if _, ok := c.table.Resolve(scope, name); ok {
+		return
+	}
+
+	start, _ := c.file.TokenSpan(name.Parts[0].Idx)
+	_, end := c.file.TokenSpan(name.Parts[len(name.Parts)-1].Idx)
+	if name.Absolute {
+		// the leading dot is a token on its own
+		start = c.file.Toks.TokenInfos[name.Parts[0].Idx-1].Offset
+	}
+
+	written := name.String()
+	d := diagnostic.Diagnostic{
+		Severity: diagnostic.SeverityError,
+		Msg:      fmt.Sprintf("unknown type %q", written),
+		Start:    start,
+		End:      end,
+	}
+
+	if suggestion, ok := closest(written, c.candidates()); ok {
+		d.Msg += fmt.Sprintf(", did you mean %q?", suggestion)
+		d.Fixes = []diagnostic.Fix{{
+			Message: fmt.Sprintf("replace %s with %s", written, suggestion),
+			Edits:   []rewrite.Edit{{Start: start, End: end, NewText: suggestion}},
+		}}
+	}
+	c.diags = append(c.diags, d)
+}
+
+// candidates returns the scalar types and the names of the
+// declarations with all their possible qualifications (e.g. Inner,
+// Outer.Inner, pkg.Outer.Inner and .pkg.Outer.Inner).
+func (c *typeChecker) candidates() iter.Seq[string] {
+	var decls []string
+	for sym := range c.table.Symbols() {
+		if sym.Kind.IsType() {
+			decls = append(decls, sym.Name)
+		}
+	}
+	slices.Sort(decls)
+	return func(yield func(string) bool) {
+		for scalar := range lexer.ScalarTypes() {
+			if !yield(scalar.String()) {
+				return
+			}
+		}
+		for _, decl := range decls {
+			if !yield("." + decl) {
+				return
+			}
+			for i := range len(decl) {
+				if (i == 0 || decl[i-1] == '.') && !yield(decl[i:]) {
+					return
+				}
+			}
+		}
+	}
+}
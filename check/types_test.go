@@ -0,0 +1,144 @@
+package check_test
+
+import (
+	"testing"
+
+	"github.com/Clement-Jean/protein/check"
+	"github.com/Clement-Jean/protein/internal/asttest"
+	"github.com/Clement-Jean/protein/rewrite"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTypes(t *testing.T) {
+	dep := asttest.NewFile(t, `package other; message Dep { enum Kind { K = 0; } }`)
+
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+		fixed    string
+	}{
+		{
+			name: "valid",
+			input: `package pkg.v1;
+message Outer {
+  message Inner {}
+  Inner a = 1;
+  Outer.Inner b = 2;
+  .pkg.v1.Outer c = 3;
+  pkg.v1.Outer.Inner d = 4;
+  other.Dep e = 5;
+  .other.Dep.Kind f = 6;
+  map<string, Inner> g = 7;
+  extend other.Dep { optional Inner h = 100; }
+}
+extend .other.Dep { optional Outer.Inner i = 101; }
+service S { rpc Get (Outer) returns (other.Dep); }
+`,
+		},
+		{
+			name: "scalar_typo",
+			input: `message M {
+  strin a = 1;
+  map<string, uint23> b = 2;
+}
+`,
+			expected: []string{
+				`unknown type "strin", did you mean "string"?`,
+				`unknown type "uint23", did you mean "uint32"?`,
+			},
+			fixed: `message M {
+  string a = 1;
+  map<string, uint32> b = 2;
+}
+`,
+		},
+		{
+			name: "declaration_typo",
+			input: `package pkg;
+message Request {}
+message M { .pkg.Reqest a = 1; }
+service S { rpc Get (Requets) returns (Dep); }
+`,
+			expected: []string{
+				`unknown type ".pkg.Reqest", did you mean ".pkg.Request"?`,
+				`unknown type "Requets", did you mean "Request"?`,
+				`unknown type "Dep"`,
+			},
+			fixed: `package pkg;
+message Request {}
+message M { .pkg.Request a = 1; }
+service S { rpc Get (Request) returns (Dep); }
+`,
+		},
+		{
+			name: "extend_typo",
+			input: `syntax = "proto2";
+package pkg;
+message Request { extensions 100 to 200; }
+extend Reqest { optional strin a = 100; }
+message M {
+  message Inner {}
+  extend Request { optional Iner b = 101; }
+}
+`,
+			expected: []string{
+				`unknown type "Iner", did you mean "Inner"?`,
+				`unknown type "Reqest", did you mean "Request"?`,
+				`unknown type "strin", did you mean "string"?`,
+			},
+			fixed: `syntax = "proto2";
+package pkg;
+message Request { extensions 100 to 200; }
+extend Request { optional string a = 100; }
+message M {
+  message Inner {}
+  extend Request { optional Inner b = 101; }
+}
+`,
+		},
+		{
+			name: "scoping",
+			input: `package pkg;
+message A { message B {} }
+message C {
+  message A {}
+  A.B b = 1;
+}
+`,
+			expected: []string{`unknown type "A.B"`},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			file := asttest.NewFile(t, test.input)
+			diags := check.Types(file, dep)
+
+			var got []string
+			var edits []rewrite.Edit
+			for _, d := range diags {
+				got = append(got, d.Msg)
+				for _, fix := range d.Fixes {
+					edits = append(edits, fix.Edits...)
+				}
+			}
+			if diff := cmp.Diff(test.expected, got); diff != "" {
+				t.Errorf("%s mismatch (-want +got):\n%s", t.Name(), diff)
+			}
+
+			if len(edits) == 0 {
+				return
+			}
+
+			fixed, err := rewrite.Apply(file.Src.Bytes(), edits)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.fixed, string(fixed)); diff != "" {
+				t.Errorf("%s fix mismatch (-want +got):\n%s", t.Name(), diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+// Command protein reports the problems of proto files.
+//
+// Usage:
+//
+//	protein [-fix] file...
+//
+// With -fix, the suggested fixes are applied to the files and only
+// the remaining problems are reported. The fixed files keep their
+// encoding (UTF-8, with or without BOM, or UTF-16).
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/Clement-Jean/protein/ast"
+	"github.com/Clement-Jean/protein/check"
+	"github.com/Clement-Jean/protein/diagnostic"
+	"github.com/Clement-Jean/protein/lexer"
+	"github.com/Clement-Jean/protein/parser"
+	"github.com/Clement-Jean/protein/rewrite"
+	"github.com/Clement-Jean/protein/source"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("protein", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	fix := flags.Bool("fix", false, "apply the suggested fixes")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: protein [-fix] file...")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return 2
+	}
+
+	status := 0
+	for _, filename := range flags.Args() {
+		ok, err := checkFile(filename, *fix, stdout)
+		if err != nil {
+			fmt.Fprintf(stderr, "%s: %v\n", filename, err)
+			status = 1
+		} else if !ok {
+			status = 1
+		}
+	}
+	return status
+}
+
+// checkFile reports the problems of filename and whether it has none.
+func checkFile(filename string, fix bool, out io.Writer) (bool, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return false, err
+	}
+
+	d := diagnose(filename, data)
+	if fix {
+		if fixed, n := applyFixes(d.data, d.diags); n != 0 {
+			fi, err := os.Stat(filename)
+			if err != nil {
+				return false, err
+			}
+			if err := os.WriteFile(filename, d.enc.Encode(fixed), fi.Mode()); err != nil {
+				return false, err
+			}
+			plural := "es"
+			if n == 1 {
+				plural = ""
+			}
+			fmt.Fprintf(out, "%s: applied %d fix%s\n", filename, n, plural)
+
+			d = diagnose(filename, fixed)
+		}
+	}
+
+	for _, err := range d.errs {
+		fmt.Fprintf(out, "%s: error: %v\n", filename, err)
+	}
+	if err := diagnostic.Render(out, d.file, d.data, d.diags); err != nil {
+		return false, err
+	}
+	return len(d.diags) == 0 && len(d.errs) == 0, nil
+}
+
+// diagnosis is the result of diagnose.
+type diagnosis struct {
+	data  []byte          // the content as UTF-8
+	enc   source.Encoding // the encoding in which the file was written
+	file  *source.File    // the positions of the diagnostics
+	diags []diagnostic.Diagnostic
+	errs  []error // the errors without position
+}
+
+// diagnose returns the diagnostics of the parser and syntax checks.
+func diagnose(filename string, data []byte) diagnosis {
+	src, err := source.NewFromReader(bytes.NewReader(data))
+	if err != nil {
+		return diagnosis{data: data, errs: []error{err}}
+	}
+
+	d := diagnosis{data: src.Bytes(), enc: src.Encoding()}
+	if d.file, err = source.NewFileSet().AddFile(filename, src); err != nil {
+		d.errs = []error{err}
+		return d
+	}
+
+	l, err := lexer.NewFromSource(src)
+	if err != nil {
+		d.errs = []error{err}
+		return d
+	}
+
+	toks, errs := l.Lex()
+	d.errs = append(src.Validate(), errs...)
+
+	tree, parseErrs := parser.NewForFile(d.file, toks, nil).Parse()
+
+	file := ast.New(src, toks, tree)
+	d.diags = check.Parse(file, d.file, parseErrs)
+	if len(d.diags) == 0 {
+		d.diags = check.Syntax(file)
+		if len(file.Imports) == 0 {
+			// the imported types cannot be resolved without the imports
+			d.diags = append(d.diags, check.Types(file)...)
+		}
+	}
+	return d
+}
+
+// applyFixes applies the first fix of each diagnostic, skipping the
+// ones overlapping a previous fix, and returns the number applied.
+func applyFixes(data []byte, diags []diagnostic.Diagnostic) ([]byte, int) {
+	var edits []rewrite.Edit
+	n := 0
+	for _, d := range diags {
+		if len(d.Fixes) == 0 {
+			continue
+		}
+
+		fixEdits := d.Fixes[0].Edits
+		if slices.ContainsFunc(fixEdits, func(e rewrite.Edit) bool { return overlaps(edits, e) }) {
+			continue
+		}
+		edits = append(edits, fixEdits...)
+		n++
+	}
+	if n == 0 {
+		return data, 0
+	}
+
+	fixed, err := rewrite.Apply(data, edits)
+	if err != nil {
+		return data, 0
+	}
+	return fixed, n
+}
+
+// overlaps reports whether e replaces bytes also replaced by one of
+// the edits. Insertions at the same offset are kept in order.
+func overlaps(edits []rewrite.Edit, e rewrite.Edit) bool {
+	for _, other := range edits {
+		if e.Start < other.End && other.Start < e.End {
+			return true
+		}
+	}
+	return false
+}
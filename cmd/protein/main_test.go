@@ -0,0 +1,99 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRunFix(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "test.proto")
+	input := `syntax = "proto3"
+message M {
+  required int32 a 1;
+  int32 b = 2`
+	if err := os.WriteFile(filename, []byte(input), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr strings.Builder
+	if status := run([]string{filename}, &stdout, &stderr); status != 1 {
+		t.Fatalf("expected status 1, got %d (%s)", status, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "fix: insert ';'") {
+		t.Errorf("expected the fixes to be listed, got:\n%s", stdout.String())
+	}
+
+	// the syntax problems are fixed once the file parses
+	for range 2 {
+		stdout.Reset()
+		run([]string{"-fix", filename}, &stdout, &stderr)
+	}
+	if stderr.Len() != 0 {
+		t.Fatal(stderr.String())
+	}
+
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `syntax = "proto3";
+message M {
+  int32 a = 1;
+  int32 b = 2;
+}`
+	if diff := cmp.Diff(expected, string(b)); diff != "" {
+		t.Errorf("fix mismatch (-want +got):\n%s", diff)
+	}
+
+	stdout.Reset()
+	if status := run([]string{filename}, &stdout, &stderr); status != 0 {
+		t.Errorf("expected status 0, got %d:\n%s", status, stdout.String())
+	}
+}
+
+func TestRunFixUTF16(t *testing.T) {
+	encode := func(s string) []byte {
+		b := []byte{0xFF, 0xFE}
+		for _, u := range utf16.Encode([]rune(s)) {
+			b = append(b, byte(u), byte(u>>8))
+		}
+		return b
+	}
+
+	filename := filepath.Join(t.TempDir(), "test.proto")
+	if err := os.WriteFile(filename, encode(`syntax = "proto3"
+message M {} // é`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr strings.Builder
+	if status := run([]string{"-fix", filename}, &stdout, &stderr); status != 0 {
+		t.Fatalf("expected status 0, got %d:\n%s%s", status, stdout.String(), stderr.String())
+	}
+
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := encode(`syntax = "proto3";
+message M {} // é`)
+	if diff := cmp.Diff(expected, b); diff != "" {
+		t.Errorf("fix mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestRunUsage(t *testing.T) {
+	var stdout, stderr strings.Builder
+	if status := run(nil, &stdout, &stderr); status != 2 {
+		t.Errorf("expected status 2, got %d", status)
+	}
+	if !strings.HasPrefix(stderr.String(), "usage: protein") {
+		t.Errorf("expected the usage, got %s", stderr.String())
+	}
+}
@@ -0,0 +1,38 @@
+package diagnostic
+
+import "github.com/Clement-Jean/protein/rewrite"
+
+type Severity uint8
+
+const (
+	SeverityError Severity = iota
+	SeverityWarning
+)
+
+func (s Severity) String() string {
+	if s == SeverityWarning {
+		return "warning"
+	}
+	return "error"
+}
+
+// Fix is a suggestion solving a diagnostic. The edits are expressed
+// against the source the diagnostic was reported on.
+type Fix struct {
+	Message string
+	Edits   []rewrite.Edit
+}
+
+// Diagnostic is a problem found in the source, located at the
+// [Start, End) offsets.
+type Diagnostic struct {
+	Severity Severity
+	Msg      string
+	Start    uint32
+	End      uint32
+	Fixes    []Fix
+}
+
+func (d *Diagnostic) Error() string {
+	return d.Msg
+}
@@ -0,0 +1,59 @@
+package diagnostic
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/Clement-Jean/protein/source"
+)
+
+// Render writes the diagnostics in the following format:
+//
+//	file.proto:3:3: error: message
+//	  required int32 a = 1;
+//	  ^~~~~~~~
+//	  fix: remove the label
+//
+// The positions are the ones of file, whose content is src.
+func Render(w io.Writer, file *source.File, src []byte, diags []Diagnostic) error {
+	bw := bufio.NewWriter(w)
+
+	for _, d := range diags {
+		pos := file.Position(file.Pos(d.Start))
+		fmt.Fprintf(bw, "%s: %s: %s\n", pos, d.Severity, d.Msg)
+
+		col := pos.Column
+		start := int(d.Start) - col + 1
+		end := bytes.IndexByte(src[start:], '\n')
+		if end == -1 {
+			end = len(src)
+		} else {
+			end += start
+		}
+
+		text := bytes.TrimRight(src[start:end], "\r")
+		bw.Write(text)
+		bw.WriteByte('\n')
+
+		// keep the tabs to align the caret with the text
+		for _, b := range text[:min(col-1, len(text))] {
+			if b == '\t' {
+				bw.WriteByte('\t')
+			} else {
+				bw.WriteByte(' ')
+			}
+		}
+		bw.WriteByte('^')
+		for i := int(d.Start) + 1; i < int(d.End) && i < start+len(text); i++ {
+			bw.WriteByte('~')
+		}
+		bw.WriteByte('\n')
+
+		for _, fix := range d.Fixes {
+			fmt.Fprintf(bw, "  fix: %s\n", fix.Message)
+		}
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,55 @@
+package diagnostic_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Clement-Jean/protein/diagnostic"
+	"github.com/Clement-Jean/protein/source"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRender(t *testing.T) {
+	src, err := source.NewFromReader(strings.NewReader("syntax = \"proto3\";\nmessage M {\n\trequired int32 a = 1;\n}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	file, err := source.NewFileSet().AddFile("test.proto", src)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diags := []diagnostic.Diagnostic{
+		{
+			Severity: diagnostic.SeverityError,
+			Msg:      "required fields are not allowed in proto3",
+			Start:    32,
+			End:      40,
+			Fixes:    []diagnostic.Fix{{Message: "remove the required label"}},
+		},
+		{
+			Severity: diagnostic.SeverityWarning,
+			Msg:      "unused",
+			Start:    0,
+			End:      6,
+		},
+	}
+
+	var b strings.Builder
+	if err := diagnostic.Render(&b, file, src.Bytes(), diags); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `test.proto:3:2: error: required fields are not allowed in proto3
+	required int32 a = 1;
+	^~~~~~~~
+  fix: remove the required label
+test.proto:1:1: warning: unused
+syntax = "proto3";
+^~~~~~
+`
+	if diff := cmp.Diff(expected, b.String()); diff != "" {
+		t.Errorf("Render mismatch (-want +got):\n%s", diff)
+	}
+}
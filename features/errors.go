@@ -0,0 +1,12 @@
+package features
+
+// Error is an invalid use of features (or of a construct replaced
+// by features) located at the token TokIdx.
+type Error struct {
+	Msg    string
+	TokIdx uint32
+}
+
+func (e *Error) Error() string {
+	return e.Msg
+}
@@ -0,0 +1,222 @@
+package features
+
+import "slices"
+
+// Edition identifies the set of defaults to use. The proto2 and
+// proto3 syntaxes are handled as legacy editions.
+type Edition uint8
+
+const (
+	EditionUnknown Edition = iota
+	EditionProto2
+	EditionProto3
+	Edition2023
+	Edition2024
+)
+
+var editionNames = []string{"unknown", "proto2", "proto3", "2023", "2024"}
+
+func (e Edition) String() string {
+	if int(e) < len(editionNames) {
+		return editionNames[e]
+	}
+	return editionNames[EditionUnknown]
+}
+
+// IsEditions reports whether e is an edition (not a syntax).
+func (e Edition) IsEditions() bool {
+	return e >= Edition2023
+}
+
+// ParseEdition returns the edition named s (e.g. "2023").
+func ParseEdition(s string) Edition {
+	if idx := slices.Index(editionNames, s); idx > int(EditionProto3) {
+		return Edition(idx)
+	}
+	return EditionUnknown
+}
+
+type FieldPresence uint8
+
+const (
+	FieldPresenceUnknown FieldPresence = iota
+	FieldPresenceExplicit
+	FieldPresenceImplicit
+	FieldPresenceLegacyRequired
+)
+
+type EnumType uint8
+
+const (
+	EnumTypeUnknown EnumType = iota
+	EnumTypeOpen
+	EnumTypeClosed
+)
+
+type RepeatedFieldEncoding uint8
+
+const (
+	RepeatedFieldEncodingUnknown RepeatedFieldEncoding = iota
+	RepeatedFieldEncodingPacked
+	RepeatedFieldEncodingExpanded
+)
+
+type UTF8Validation uint8
+
+const (
+	UTF8ValidationUnknown UTF8Validation = iota
+	UTF8ValidationVerify  UTF8Validation = 2 // same number as in descriptor.proto
+	UTF8ValidationNone    UTF8Validation = 3
+)
+
+type MessageEncoding uint8
+
+const (
+	MessageEncodingUnknown MessageEncoding = iota
+	MessageEncodingLengthPrefixed
+	MessageEncodingDelimited
+)
+
+type JSONFormat uint8
+
+const (
+	JSONFormatUnknown JSONFormat = iota
+	JSONFormatAllow
+	JSONFormatLegacyBestEffort
+)
+
+// Set is the resolved value of the features for an element.
+type Set struct {
+	FieldPresence         FieldPresence
+	EnumType              EnumType
+	RepeatedFieldEncoding RepeatedFieldEncoding
+	UTF8Validation        UTF8Validation
+	MessageEncoding       MessageEncoding
+	JSONFormat            JSONFormat
+}
+
+// Defaults returns the features of an edition before any override.
+func Defaults(edition Edition) Set {
+	switch edition {
+	case EditionProto2:
+		return Set{
+			FieldPresence:         FieldPresenceExplicit,
+			EnumType:              EnumTypeClosed,
+			RepeatedFieldEncoding: RepeatedFieldEncodingExpanded,
+			UTF8Validation:        UTF8ValidationNone,
+			MessageEncoding:       MessageEncodingLengthPrefixed,
+			JSONFormat:            JSONFormatLegacyBestEffort,
+		}
+	case EditionProto3:
+		return Set{
+			FieldPresence:         FieldPresenceImplicit,
+			EnumType:              EnumTypeOpen,
+			RepeatedFieldEncoding: RepeatedFieldEncodingPacked,
+			UTF8Validation:        UTF8ValidationVerify,
+			MessageEncoding:       MessageEncodingLengthPrefixed,
+			JSONFormat:            JSONFormatAllow,
+		}
+	case Edition2023, Edition2024:
+		return Set{
+			FieldPresence:         FieldPresenceExplicit,
+			EnumType:              EnumTypeOpen,
+			RepeatedFieldEncoding: RepeatedFieldEncodingPacked,
+			UTF8Validation:        UTF8ValidationVerify,
+			MessageEncoding:       MessageEncodingLengthPrefixed,
+			JSONFormat:            JSONFormatAllow,
+		}
+	}
+	return Set{}
+}
+
+type target uint8
+
+const (
+	targetFile target = 1 << iota
+	targetMessage
+	targetField
+	targetOneof
+	targetEnum
+)
+
+var targetNames = map[target]string{
+	targetFile:    "file",
+	targetMessage: "message",
+	targetField:   "field",
+	targetOneof:   "oneof",
+	targetEnum:    "enum",
+}
+
+// feature describes a field of FeatureSet. The position of a value
+// in values is its number.
+type feature struct {
+	name    string
+	values  []string
+	targets target
+	set     func(s *Set, v uint8)
+}
+
+var knownFeatures = []feature{
+	{
+		name:    "field_presence",
+		values:  []string{"FIELD_PRESENCE_UNKNOWN", "EXPLICIT", "IMPLICIT", "LEGACY_REQUIRED"},
+		targets: targetFile | targetField,
+		set:     func(s *Set, v uint8) { s.FieldPresence = FieldPresence(v) },
+	},
+	{
+		name:    "enum_type",
+		values:  []string{"ENUM_TYPE_UNKNOWN", "OPEN", "CLOSED"},
+		targets: targetFile | targetEnum,
+		set:     func(s *Set, v uint8) { s.EnumType = EnumType(v) },
+	},
+	{
+		name:    "repeated_field_encoding",
+		values:  []string{"REPEATED_FIELD_ENCODING_UNKNOWN", "PACKED", "EXPANDED"},
+		targets: targetFile | targetField,
+		set:     func(s *Set, v uint8) { s.RepeatedFieldEncoding = RepeatedFieldEncoding(v) },
+	},
+	{
+		name:    "utf8_validation",
+		values:  []string{"UTF8_VALIDATION_UNKNOWN", "", "VERIFY", "NONE"},
+		targets: targetFile | targetField,
+		set:     func(s *Set, v uint8) { s.UTF8Validation = UTF8Validation(v) },
+	},
+	{
+		name:    "message_encoding",
+		values:  []string{"MESSAGE_ENCODING_UNKNOWN", "LENGTH_PREFIXED", "DELIMITED"},
+		targets: targetFile | targetField,
+		set:     func(s *Set, v uint8) { s.MessageEncoding = MessageEncoding(v) },
+	},
+	{
+		name:    "json_format",
+		values:  []string{"JSON_FORMAT_UNKNOWN", "ALLOW", "LEGACY_BEST_EFFORT"},
+		targets: targetFile | targetMessage | targetEnum,
+		set:     func(s *Set, v uint8) { s.JSONFormat = JSONFormat(v) },
+	},
+}
+
+func lookupFeature(name string) *feature {
+	for i := range knownFeatures {
+		if knownFeatures[i].name == name {
+			return &knownFeatures[i]
+		}
+	}
+	return nil
+}
+
+func (f *feature) value(name string) (uint8, bool) {
+	// the first value is the unknown one and cannot be set
+	for i, v := range f.values[1:] {
+		if v != "" && v == name {
+			return uint8(i + 1), true
+		}
+	}
+	return 0, false
+}
+
+func (s FieldPresence) String() string         { return knownFeatures[0].values[s] }
+func (s EnumType) String() string              { return knownFeatures[1].values[s] }
+func (s RepeatedFieldEncoding) String() string { return knownFeatures[2].values[s] }
+func (s UTF8Validation) String() string        { return knownFeatures[3].values[s] }
+func (s MessageEncoding) String() string       { return knownFeatures[4].values[s] }
+func (s JSONFormat) String() string            { return knownFeatures[5].values[s] }
@@ -0,0 +1,248 @@
+package features
+
+import (
+	"fmt"
+
+	"github.com/Clement-Jean/protein/ast"
+	"github.com/Clement-Jean/protein/lexer"
+)
+
+// Resolution holds the effective features of the elements of a file.
+type Resolution struct {
+	Edition Edition
+
+	file Set
+	sets map[int]Set // by ast node
+}
+
+func (r *Resolution) File() Set                  { return r.file }
+func (r *Resolution) Message(m *ast.Message) Set { return r.lookup(m.Node) }
+func (r *Resolution) Field(f *ast.Field) Set     { return r.lookup(f.Node) }
+func (r *Resolution) Oneof(o *ast.Oneof) Set     { return r.lookup(o.Node) }
+func (r *Resolution) Enum(e *ast.Enum) Set       { return r.lookup(e.Node) }
+
+func (r *Resolution) lookup(node int) Set {
+	if s, ok := r.sets[node]; ok {
+		return s
+	}
+	return r.file
+}
+
+type resolver struct {
+	res  *Resolution
+	errs []error
+}
+
+// Resolve computes the features of every file, message, field, oneof
+// and enum of file. The extensions inherit the features of the scope
+// of their extend block. The defaults of the file edition (or syntax) are
+// overridden by the features options and inherited by nested elements.
+func Resolve(file *ast.File) (*Resolution, []error) {
+	r := &resolver{res: &Resolution{sets: make(map[int]Set)}}
+	r.res.Edition = r.edition(file)
+
+	r.res.file = r.apply(Defaults(r.res.Edition), file.Options, targetFile)
+	for _, msg := range file.Messages {
+		r.message(r.res.file, msg)
+	}
+	for _, enum := range file.Enums {
+		r.enum(r.res.file, enum)
+	}
+	for _, ext := range file.Extends {
+		r.extend(r.res.file, ext)
+	}
+	return r.res, r.errs
+}
+
+func (r *resolver) edition(file *ast.File) Edition {
+	switch {
+	case file.Edition != nil:
+		s, _ := file.Edition.Value.String()
+		edition := ParseEdition(s)
+		if edition == EditionUnknown {
+			r.errs = append(r.errs, &Error{
+				Msg:    fmt.Sprintf("unsupported edition %q", s),
+				TokIdx: file.Edition.Value.Idx,
+			})
+			// keep going with the closest edition
+			edition = Edition2023
+		}
+		return edition
+	case file.Syntax != nil:
+		if s, _ := file.Syntax.Value.String(); s == "proto3" {
+			return EditionProto3
+		}
+	}
+	return EditionProto2
+}
+
+func (r *resolver) message(parent Set, msg *ast.Message) {
+	set := r.apply(parent, msg.Options, targetMessage)
+	r.res.sets[msg.Node] = set
+
+	for _, oneof := range msg.Oneofs {
+		r.res.sets[oneof.Node] = r.apply(set, oneof.Options, targetOneof)
+	}
+	for _, field := range msg.Fields {
+		fieldParent := set
+		if field.Oneof != nil {
+			fieldParent = r.res.sets[field.Oneof.Node]
+		}
+		r.field(fieldParent, field, false)
+	}
+	for _, nested := range msg.Messages {
+		r.message(set, nested)
+	}
+	for _, enum := range msg.Enums {
+		r.enum(set, enum)
+	}
+	for _, ext := range msg.Extends {
+		r.extend(set, ext)
+	}
+}
+
+func (r *resolver) extend(parent Set, ext *ast.Extend) {
+	for _, field := range ext.Fields {
+		r.field(parent, field, true)
+	}
+}
+
+func (r *resolver) enum(parent Set, enum *ast.Enum) {
+	r.res.sets[enum.Node] = r.apply(parent, enum.Options, targetEnum)
+}
+
+func (r *resolver) field(parent Set, field *ast.Field, extension bool) {
+	set := parent
+	repeated := field.Label == ast.LabelRepeated || field.Map != nil
+
+	if !r.res.Edition.IsEditions() {
+		// the legacy constructs are translated to features
+		switch field.Label {
+		case ast.LabelRequired:
+			set.FieldPresence = FieldPresenceLegacyRequired
+		case ast.LabelOptional:
+			set.FieldPresence = FieldPresenceExplicit
+		}
+	} else if field.Label == ast.LabelRequired || field.Label == ast.LabelOptional {
+		presence := FieldPresenceLegacyRequired
+		if field.Label == ast.LabelOptional {
+			presence = FieldPresenceExplicit
+		}
+		r.errs = append(r.errs, &Error{
+			Msg: fmt.Sprintf(
+				"label %s is not supported in editions, use features.field_presence = %s",
+				field.LabelToken.Text, presence,
+			),
+			TokIdx: field.LabelToken.Idx,
+		})
+	}
+
+	for _, opt := range field.Options {
+		if len(opt.Name) != 1 || opt.Name[0].Extension || opt.Name[0].Name.String() != "packed" {
+			continue
+		}
+
+		if r.res.Edition.IsEditions() {
+			r.errs = append(r.errs, &Error{
+				Msg:    "packed is not supported in editions, use features.repeated_field_encoding",
+				TokIdx: opt.Name[0].Name.Parts[0].Idx,
+			})
+		} else if v, err := opt.Value.Bool(); err == nil {
+			set.RepeatedFieldEncoding = RepeatedFieldEncodingExpanded
+			if v {
+				set.RepeatedFieldEncoding = RepeatedFieldEncodingPacked
+			}
+		}
+	}
+
+	for _, opt := range field.Options {
+		f, ok := featureName(opt)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case f == "field_presence" && repeated:
+			r.errs = append(r.errs, &Error{
+				Msg:    "repeated fields cannot specify field presence",
+				TokIdx: opt.Value.Idx,
+			})
+		case f == "field_presence" && field.Oneof != nil:
+			r.errs = append(r.errs, &Error{
+				Msg:    "oneof fields cannot specify field presence",
+				TokIdx: opt.Value.Idx,
+			})
+		case f == "field_presence" && extension:
+			r.errs = append(r.errs, &Error{
+				Msg:    "extensions cannot specify field presence",
+				TokIdx: opt.Value.Idx,
+			})
+		case f == "repeated_field_encoding" && !repeated:
+			r.errs = append(r.errs, &Error{
+				Msg:    "only repeated fields can specify repeated field encoding",
+				TokIdx: opt.Value.Idx,
+			})
+		}
+	}
+
+	r.res.sets[field.Node] = r.apply(set, field.Options, targetField)
+}
+
+// featureName returns the name of the feature set by an option
+// written as features.name.
+func featureName(opt *ast.Option) (string, bool) {
+	if len(opt.Name) != 2 || opt.Name[0].Extension || opt.Name[1].Extension {
+		return "", false
+	}
+	if opt.Name[0].Name.String() != "features" {
+		return "", false
+	}
+	return opt.Name[1].Name.String(), true
+}
+
+func (r *resolver) apply(parent Set, opts []*ast.Option, t target) Set {
+	set := parent
+
+	for _, opt := range opts {
+		name, ok := featureName(opt)
+		if !ok {
+			continue
+		}
+
+		tokIdx := opt.Name[1].Name.Parts[0].Idx
+		if !r.res.Edition.IsEditions() {
+			r.errs = append(r.errs, &Error{
+				Msg:    "features are only available in editions",
+				TokIdx: tokIdx,
+			})
+			continue
+		}
+
+		f := lookupFeature(name)
+		if f == nil {
+			r.errs = append(r.errs, &Error{
+				Msg:    fmt.Sprintf("unknown feature %q", name),
+				TokIdx: tokIdx,
+			})
+			continue
+		}
+		if f.targets&t == 0 {
+			r.errs = append(r.errs, &Error{
+				Msg:    fmt.Sprintf("feature %s cannot be set on a %s", name, targetNames[t]),
+				TokIdx: tokIdx,
+			})
+			continue
+		}
+
+		v, ok := f.value(opt.Value.Text)
+		if opt.Value.Kind != lexer.TokenKindIdentifier || !ok {
+			r.errs = append(r.errs, &Error{
+				Msg:    fmt.Sprintf("invalid value %s for feature %s", opt.Value.Text, name),
+				TokIdx: opt.Value.Idx,
+			})
+			continue
+		}
+		f.set(&set, v)
+	}
+	return set
+}
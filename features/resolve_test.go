@@ -0,0 +1,250 @@
+package features_test
+
+import (
+	"testing"
+
+	"github.com/Clement-Jean/protein/features"
+	"github.com/Clement-Jean/protein/internal/asttest"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDefaults(t *testing.T) {
+	tests := []struct {
+		input    string
+		edition  features.Edition
+		expected features.Set
+	}{
+		{`message M {}`, features.EditionProto2, features.Defaults(features.EditionProto2)},
+		{`syntax = "proto2";`, features.EditionProto2, features.Defaults(features.EditionProto2)},
+		{`syntax = "proto3";`, features.EditionProto3, features.Defaults(features.EditionProto3)},
+		{`edition = "2023";`, features.Edition2023, features.Defaults(features.Edition2023)},
+		{`edition = "2024";`, features.Edition2024, features.Defaults(features.Edition2024)},
+	}
+
+	for _, test := range tests {
+		res, errs := features.Resolve(asttest.NewFile(t, test.input))
+		if len(errs) != 0 {
+			t.Fatalf("%s: %v", test.input, errs)
+		}
+		if res.Edition != test.edition {
+			t.Errorf("%s: expected edition %s, got %s", test.input, test.edition, res.Edition)
+		}
+		if diff := cmp.Diff(test.expected, res.File()); diff != "" {
+			t.Errorf("%s mismatch (-want +got):\n%s", test.input, diff)
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	file := asttest.NewFile(t, `edition = "2023";
+
+option features.field_presence = IMPLICIT;
+option features.enum_type = CLOSED;
+
+message M {
+  option features.json_format = LEGACY_BEST_EFFORT;
+
+  int32 a = 1;
+  int32 b = 2 [features.field_presence = EXPLICIT];
+  repeated int32 c = 3 [features.repeated_field_encoding = EXPANDED];
+  oneof o {
+    string d = 4;
+  }
+
+  message N {
+    string e = 1 [features.utf8_validation = NONE];
+  }
+  enum E {
+    option features.enum_type = OPEN;
+    E_UNSPECIFIED = 0;
+  }
+
+  extensions 100 to 200;
+  extend M {
+    repeated int32 g = 101 [features.repeated_field_encoding = EXPANDED];
+  }
+}
+
+enum F {
+  F_UNSPECIFIED = 0;
+}
+
+extend M {
+  string f = 100 [features.utf8_validation = NONE];
+}
+`)
+
+	res, errs := features.Resolve(file)
+	if len(errs) != 0 {
+		t.Fatal(errs)
+	}
+
+	fileSet := features.Defaults(features.Edition2023)
+	fileSet.FieldPresence = features.FieldPresenceImplicit
+	fileSet.EnumType = features.EnumTypeClosed
+
+	msg := file.Messages[0]
+	msgSet := fileSet
+	msgSet.JSONFormat = features.JSONFormatLegacyBestEffort
+
+	b := msgSet
+	b.FieldPresence = features.FieldPresenceExplicit
+	c := msgSet
+	c.RepeatedFieldEncoding = features.RepeatedFieldEncodingExpanded
+	e := msgSet
+	e.UTF8Validation = features.UTF8ValidationNone
+	enum := msgSet
+	enum.EnumType = features.EnumTypeOpen
+	f := fileSet
+	f.UTF8Validation = features.UTF8ValidationNone
+
+	tests := []struct {
+		name     string
+		got      features.Set
+		expected features.Set
+	}{
+		{"file", res.File(), fileSet},
+		{"M", res.Message(msg), msgSet},
+		{"M.a", res.Field(msg.Fields[0]), msgSet},
+		{"M.b", res.Field(msg.Fields[1]), b},
+		{"M.c", res.Field(msg.Fields[2]), c},
+		{"M.o", res.Oneof(msg.Oneofs[0]), msgSet},
+		{"M.d", res.Field(msg.Fields[3]), msgSet},
+		{"M.N", res.Message(msg.Messages[0]), msgSet},
+		{"M.N.e", res.Field(msg.Messages[0].Fields[0]), e},
+		{"M.E", res.Enum(msg.Enums[0]), enum},
+		{"F", res.Enum(file.Enums[0]), fileSet},
+		{"f", res.Field(file.Extends[0].Fields[0]), f},
+		{"M.g", res.Field(msg.Extends[0].Fields[0]), c},
+	}
+
+	for _, test := range tests {
+		if diff := cmp.Diff(test.expected, test.got); diff != "" {
+			t.Errorf("%s mismatch (-want +got):\n%s", test.name, diff)
+		}
+	}
+}
+
+func TestResolveLegacy(t *testing.T) {
+	file := asttest.NewFile(t, `syntax = "proto2";
+message M {
+  required int32 a = 1;
+  repeated int32 b = 2 [packed = true];
+}
+enum E { A = 1; }
+`)
+
+	res, errs := features.Resolve(file)
+	if len(errs) != 0 {
+		t.Fatal(errs)
+	}
+
+	msg := file.Messages[0]
+	if got := res.Field(msg.Fields[0]).FieldPresence; got != features.FieldPresenceLegacyRequired {
+		t.Errorf("expected %s, got %s", features.FieldPresenceLegacyRequired, got)
+	}
+	if got := res.Field(msg.Fields[1]).RepeatedFieldEncoding; got != features.RepeatedFieldEncodingPacked {
+		t.Errorf("expected %s, got %s", features.RepeatedFieldEncodingPacked, got)
+	}
+	if got := res.Enum(file.Enums[0]).EnumType; got != features.EnumTypeClosed {
+		t.Errorf("expected %s, got %s", features.EnumTypeClosed, got)
+	}
+}
+
+func TestResolveErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name:     "unsupported_edition",
+			input:    `edition = "2077";`,
+			expected: []string{`unsupported edition "2077"`},
+		},
+		{
+			name:     "features_in_syntax",
+			input:    "syntax = \"proto3\";\noption features.field_presence = EXPLICIT;",
+			expected: []string{"features are only available in editions"},
+		},
+		{
+			name: "labels",
+			input: `edition = "2023";
+message M {
+  required int32 a = 1;
+  optional int32 b = 2;
+}`,
+			expected: []string{
+				"label required is not supported in editions, use features.field_presence = LEGACY_REQUIRED",
+				"label optional is not supported in editions, use features.field_presence = EXPLICIT",
+			},
+		},
+		{
+			name: "packed",
+			input: `edition = "2023";
+message M { repeated int32 a = 1 [packed = true]; }`,
+			expected: []string{"packed is not supported in editions, use features.repeated_field_encoding"},
+		},
+		{
+			name: "invalid_feature",
+			input: `edition = "2023";
+option features.unknown = TRUE;
+option features.field_presence = OPEN;
+message M { option features.field_presence = IMPLICIT; }`,
+			expected: []string{
+				`unknown feature "unknown"`,
+				"invalid value OPEN for feature field_presence",
+				"feature field_presence cannot be set on a message",
+			},
+		},
+		{
+			name: "extensions",
+			input: `edition = "2023";
+extend M {
+  optional int32 a = 100;
+  int32 b = 101 [features.field_presence = IMPLICIT];
+}
+message M {
+  extensions 100 to 200;
+  extend M {
+    repeated int32 c = 102 [features.repeated_field_encoding = EXPANDED];
+    int32 d = 103 [features.repeated_field_encoding = PACKED];
+  }
+}`,
+			expected: []string{
+				"only repeated fields can specify repeated field encoding",
+				"label optional is not supported in editions, use features.field_presence = EXPLICIT",
+				"extensions cannot specify field presence",
+			},
+		},
+		{
+			name: "invalid_field_feature",
+			input: `edition = "2023";
+message M {
+  repeated int32 a = 1 [features.field_presence = EXPLICIT];
+  int32 b = 2 [features.repeated_field_encoding = PACKED];
+  oneof o { int32 c = 3 [features.field_presence = IMPLICIT]; }
+}`,
+			expected: []string{
+				"repeated fields cannot specify field presence",
+				"only repeated fields can specify repeated field encoding",
+				"oneof fields cannot specify field presence",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, errs := features.Resolve(asttest.NewFile(t, test.input))
+
+			got := make([]string, len(errs))
+			for i, err := range errs {
+				got[i] = err.Error()
+			}
+			if diff := cmp.Diff(test.expected, got); diff != "" {
+				t.Errorf("%s mismatch (-want +got):\n%s", t.Name(), diff)
+			}
+		})
+	}
+}
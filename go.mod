@@ -2,7 +2,4 @@ module github.com/Clement-Jean/protein
 
 go 1.23
 
-require (
-	github.com/google/go-cmp v0.6.0
-	golang.org/x/exp v0.0.0-20240506185415-9bf2ced13842
-)
+require github.com/google/go-cmp v0.6.0
@@ -0,0 +1,42 @@
+package gogen
+
+import (
+	"fmt"
+
+	"github.com/Clement-Jean/protein/ast"
+)
+
+// enum generates the type of enum, declared in scope. The values are
+// prefixed by the Go name of the parent message, or by the name of
+// the enum at the top level.
+func (g *generator) enum(scope, parent string, enum *ast.Enum) {
+	name := g.names[join(scope, enum.Name.Text)]
+	prefix := parent
+	if prefix == "" {
+		prefix = name
+	}
+
+	g.printf("\ntype %s int32\n\nconst (\n", name)
+	for _, value := range enum.Values {
+		number, err := value.Number.Int()
+		if err != nil {
+			g.errs = append(g.errs, fmt.Errorf("invalid number %s for %q", value.Number.Text, value.Name.Text))
+			continue
+		}
+		g.printf("%s_%s %s = %d\n", prefix, value.Name.Text, name, number)
+	}
+	g.printf(")\n")
+
+	// the aliases are named after the first value with their number
+	g.printf("\nfunc (x %s) String() string {\nswitch x {\n", name)
+	seen := make(map[int64]bool)
+	for _, value := range enum.Values {
+		number, err := value.Number.Int()
+		if err != nil || seen[number] {
+			continue
+		}
+		seen[number] = true
+		g.printf("case %s_%s:\nreturn %q\n", prefix, value.Name.Text, value.Name.Text)
+	}
+	g.printf("}\nreturn strconv.Itoa(int(x))\n}\n")
+}
@@ -0,0 +1,171 @@
+// Package gogen generates Go code from proto files: a struct for each
+// message, an integer type with a String method for each enum and the
+// methods encoding the messages in the binary wire format. The
+// generated code only depends on the wire package.
+package gogen
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"strings"
+
+	"github.com/Clement-Jean/protein/ast"
+	"github.com/Clement-Jean/protein/features"
+	"github.com/Clement-Jean/protein/symbols"
+)
+
+type generator struct {
+	table *symbols.Table
+	names map[string]string // Go names of the messages and enums by fully-qualified name
+	types map[string]bool   // the Go type names in use
+	buf   bytes.Buffer
+	errs  []error
+}
+
+// Generate returns the Go code of the messages and enums declared in
+// files, as a single file of the package pkg. The types used by the
+// fields must be declared in files, they are resolved with table.
+// Extensions are not generated, the unknown fields (e.g. extensions)
+// are kept in the XXX_unrecognized field of the messages and encoded
+// after the known ones.
+func Generate(pkg string, table *symbols.Table, files ...*ast.File) ([]byte, error) {
+	g := &generator{
+		table: table,
+		names: make(map[string]string),
+		types: make(map[string]bool),
+	}
+
+	for _, file := range files {
+		scope := packageName(file)
+		for _, enum := range file.Enums {
+			g.name(scope, scope, enum.Name.Text)
+		}
+		for _, msg := range file.Messages {
+			g.messageNames(scope, scope, msg)
+		}
+	}
+	if len(g.errs) != 0 {
+		return nil, errors.Join(g.errs...)
+	}
+
+	for _, file := range files {
+		res, errs := features.Resolve(file)
+		if len(errs) != 0 {
+			return nil, errors.Join(errs...)
+		}
+
+		scope := packageName(file)
+		for _, enum := range file.Enums {
+			g.enum(scope, "", enum)
+		}
+		for _, msg := range file.Messages {
+			g.message(scope, res, msg)
+		}
+	}
+	if len(g.errs) != 0 {
+		return nil, errors.Join(g.errs...)
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by protein. DO NOT EDIT.\n\npackage %s\n\n", pkg)
+	body := g.buf.String()
+	out.WriteString("import (\n")
+	for _, imp := range []string{"math", "strconv"} {
+		if strings.Contains(body, imp+".") {
+			fmt.Fprintf(&out, "\t%q\n", imp)
+		}
+	}
+	if strings.Contains(body, "wire.") {
+		out.WriteString("\n\t\"github.com/Clement-Jean/protein/wire\"\n")
+	}
+	out.WriteString(")\n")
+	out.WriteString(body)
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("invalid generated code: %w", err)
+	}
+	return src, nil
+}
+
+func packageName(file *ast.File) string {
+	if file.Package == nil {
+		return ""
+	}
+	return file.Package.Name.String()
+}
+
+func join(scope, name string) string {
+	if scope == "" {
+		return name
+	}
+	return scope + "." + name
+}
+
+// name records the Go name of the type name declared in scope, it is
+// the name relative to pkg (e.g. Outer_Inner for pkg.Outer.Inner).
+func (g *generator) name(pkg, scope, name string) string {
+	full := join(scope, name)
+	goName := camelCase(strings.TrimPrefix(full, pkg+"."))
+	if pkg == "" {
+		goName = camelCase(full)
+	}
+
+	if g.types[goName] {
+		g.errs = append(g.errs, fmt.Errorf("%q is generated as %s, which is already used", full, goName))
+	}
+	g.types[goName] = true
+	g.names[full] = goName
+	return goName
+}
+
+func (g *generator) messageNames(pkg, scope string, msg *ast.Message) {
+	g.name(pkg, scope, msg.Name.Text)
+	name := join(scope, msg.Name.Text)
+	for _, enum := range msg.Enums {
+		g.name(pkg, name, enum.Name.Text)
+	}
+	for _, nested := range msg.Messages {
+		g.messageNames(pkg, name, nested)
+	}
+}
+
+func (g *generator) printf(format string, args ...any) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// camelCase converts a proto name to a Go name like protoc-gen-go:
+// the underscores followed by a lowercase letter are removed and the
+// letter is capitalized, the dots become underscores.
+func camelCase(s string) string {
+	var b []byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '.' && i+1 < len(s) && isLower(s[i+1]):
+			// the next letter is capitalized
+		case c == '.':
+			b = append(b, '_')
+		case c == '_' && (i == 0 || s[i-1] == '.'):
+			b = append(b, 'X')
+		case c == '_' && i+1 < len(s) && isLower(s[i+1]):
+			// the next letter is capitalized
+		case isDigit(c):
+			b = append(b, c)
+		default:
+			if isLower(c) {
+				c -= 'a' - 'A'
+			}
+			b = append(b, c)
+			for ; i+1 < len(s) && isLower(s[i+1]); i++ {
+				b = append(b, s[i+1])
+			}
+		}
+	}
+	return string(b)
+}
+
+func isLower(c byte) bool { return c >= 'a' && c <= 'z' }
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
@@ -0,0 +1,164 @@
+package gogen_test
+
+import (
+	"bytes"
+	"flag"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/Clement-Jean/protein/ast"
+	"github.com/Clement-Jean/protein/gogen"
+	"github.com/Clement-Jean/protein/loader"
+	"github.com/Clement-Jean/protein/source"
+	"github.com/Clement-Jean/protein/symbols"
+	"github.com/Clement-Jean/protein/wellknown"
+)
+
+var update = flag.Bool("update", false, "update the generated packages")
+
+func TestGenerate(t *testing.T) {
+	wkt, err := fs.Glob(wellknown.FS, "google/protobuf/*.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	corpus, err := os.ReadFile("../corpus/a_bit_of_everything.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the imports missing from the corpus are stubbed in everythingpb
+	everything := source.NewOverlay(os.DirFS("internal/everythingpb"))
+	everything.Set("a_bit_of_everything.proto", corpus)
+
+	tests := []struct {
+		pkg   string
+		fsys  fs.FS
+		files []string
+	}{
+		{"wktpb", wellknown.FS, wkt},
+		{"testpb", os.DirFS("internal/testpb"), []string{"proto3.proto", "proto2.proto"}},
+		{"everythingpb", everything, []string{
+			"a_bit_of_everything.proto",
+			"examples/internal/proto/pathenum/path_enum.proto",
+			"examples/internal/proto/sub/message.proto",
+			"examples/internal/proto/sub2/message.proto",
+			"google/rpc/status.proto",
+			"google/protobuf/any.proto",
+			"google/protobuf/duration.proto",
+			"google/protobuf/empty.proto",
+			"google/protobuf/field_mask.proto",
+			"google/protobuf/timestamp.proto",
+			"google/protobuf/wrappers.proto",
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.pkg, func(t *testing.T) {
+			l := loader.New(test.fsys)
+			defer l.Close()
+
+			var files []*ast.File
+			for _, path := range test.files {
+				f, err := l.Load(path)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(f.Errs) != 0 {
+					t.Fatal(f.Errs)
+				}
+				files = append(files, f.AST)
+			}
+
+			table, errs := symbols.New(files...)
+			if len(errs) != 0 {
+				t.Fatal(errs)
+			}
+
+			src, err := gogen.Generate(test.pkg, table, files...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			golden := filepath.Join("internal", test.pkg, test.pkg+".pb.go")
+			if *update {
+				if err := os.WriteFile(golden, src, 0o644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			expected, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(expected, src) {
+				t.Errorf("%s is out of date, run go test -update", golden)
+			}
+		})
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		expected string
+	}{
+		{
+			name: "type not generated",
+			files: map[string]string{
+				"a.proto": `syntax = "proto3"; package a; import "b.proto"; message A { b.B b = 1; }`,
+				"b.proto": `syntax = "proto3"; package b; message B {}`,
+			},
+			expected: `type "b.B" used in "a.A" is not generated`,
+		},
+		{
+			name: "name collision",
+			files: map[string]string{
+				"a.proto": `syntax = "proto3"; message A { message B {} } message A_B {}`,
+			},
+			expected: `"A_B" is generated as A_B, which is already used`,
+		},
+		{
+			name: "delimited",
+			files: map[string]string{
+				"a.proto": `edition = "2023"; message A { A a = 1 [features.message_encoding = DELIMITED]; }`,
+			},
+			expected: `"A.a" uses the delimited encoding, which is not supported`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fsys := make(fstest.MapFS)
+			for name, content := range test.files {
+				fsys[name] = &fstest.MapFile{Data: []byte(content)}
+			}
+
+			l := loader.New(fsys)
+			defer l.Close()
+
+			f, err := l.Load("a.proto")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var files []*ast.File
+			for _, loaded := range l.Files() {
+				files = append(files, loaded.AST)
+			}
+			table, errs := symbols.New(files...)
+			if len(errs) != 0 {
+				t.Fatal(errs)
+			}
+
+			_, err = gogen.Generate("pb", table, f.AST)
+			if err == nil || err.Error() != test.expected {
+				t.Errorf("expected %q, got %v", test.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,3166 @@
+// Code generated by protein. DO NOT EDIT.
+
+package everythingpb
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/Clement-Jean/protein/wire"
+)
+
+type NumericEnum int32
+
+const (
+	NumericEnum_ZERO NumericEnum = 0
+	NumericEnum_ONE  NumericEnum = 1
+)
+
+func (x NumericEnum) String() string {
+	switch x {
+	case NumericEnum_ZERO:
+		return "ZERO"
+	case NumericEnum_ONE:
+		return "ONE"
+	}
+	return strconv.Itoa(int(x))
+}
+
+type ErrorResponse struct {
+	CorrelationId string
+	Error         *ErrorObject
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *ErrorResponse) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *ErrorResponse) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.CorrelationId != "" {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		b = wire.AppendString(b, m.CorrelationId)
+	}
+	if m.Error != nil {
+		b = wire.AppendTag(b, 2, wire.BytesType)
+		b = wire.AppendMessage(b, m.Error)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *ErrorResponse) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.CorrelationId = string(v)
+		case num == 2 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			if m.Error == nil {
+				m.Error = new(ErrorObject)
+			}
+			if err := m.Error.Unmarshal(v); err != nil {
+				return err
+			}
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type ErrorObject struct {
+	Code    int32
+	Message string
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *ErrorObject) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *ErrorObject) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Code != 0 {
+		b = wire.AppendTag(b, 1, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(m.Code))
+	}
+	if m.Message != "" {
+		b = wire.AppendTag(b, 2, wire.BytesType)
+		b = wire.AppendString(b, m.Message)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *ErrorObject) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Code = int32(v)
+		case num == 2 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Message = string(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type ABitOfEverything struct {
+	SingleNested                               *ABitOfEverything_Nested
+	Uuid                                       string
+	Nested                                     []*ABitOfEverything_Nested
+	FloatValue                                 float32
+	DoubleValue                                float64
+	Int64Value                                 int64
+	Uint64Value                                uint64
+	Int32Value                                 int32
+	Fixed64Value                               uint64
+	Fixed32Value                               uint32
+	BoolValue                                  bool
+	StringValue                                string
+	BytesValue                                 []byte
+	Uint32Value                                uint32
+	EnumValue                                  NumericEnum
+	PathEnumValue                              PathEnum
+	NestedPathEnumValue                        MessagePathEnum_NestedPathEnum
+	Sfixed32Value                              int32
+	Sfixed64Value                              int64
+	Sint32Value                                int32
+	Sint64Value                                int64
+	RepeatedStringValue                        []string
+	OneofValue                                 isABitOfEverything_OneofValue
+	MapValue                                   map[string]NumericEnum
+	MappedStringValue                          map[string]string
+	MappedNestedValue                          map[string]*ABitOfEverything_Nested
+	NonConventionalNameValue                   string
+	TimestampValue                             *Timestamp
+	RepeatedEnumValue                          []NumericEnum
+	RepeatedEnumAnnotation                     []NumericEnum
+	EnumValueAnnotation                        NumericEnum
+	RepeatedStringAnnotation                   []string
+	RepeatedNestedAnnotation                   []*ABitOfEverything_Nested
+	NestedAnnotation                           *ABitOfEverything_Nested
+	Int64OverrideType                          int64
+	RequiredStringViaFieldBehaviorAnnotation   string
+	OutputOnlyStringViaFieldBehaviorAnnotation string
+	OptionalStringValue                        *string
+
+	XXX_unrecognized []byte
+}
+
+type isABitOfEverything_OneofValue interface {
+	isABitOfEverything_OneofValue()
+}
+
+type ABitOfEverything_OneofEmpty struct {
+	OneofEmpty *Empty
+}
+
+type ABitOfEverything_OneofString struct {
+	OneofString string
+}
+
+func (*ABitOfEverything_OneofEmpty) isABitOfEverything_OneofValue()  {}
+func (*ABitOfEverything_OneofString) isABitOfEverything_OneofValue() {}
+
+// Marshal returns the wire format encoding of m.
+func (m *ABitOfEverything) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *ABitOfEverything) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Uuid != "" {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		b = wire.AppendString(b, m.Uuid)
+	}
+	for _, x := range m.Nested {
+		b = wire.AppendTag(b, 2, wire.BytesType)
+		b = wire.AppendMessage(b, x)
+	}
+	if m.FloatValue != 0 {
+		b = wire.AppendTag(b, 3, wire.Fixed32Type)
+		b = wire.AppendFixed32(b, math.Float32bits(m.FloatValue))
+	}
+	if m.DoubleValue != 0 {
+		b = wire.AppendTag(b, 4, wire.Fixed64Type)
+		b = wire.AppendFixed64(b, math.Float64bits(m.DoubleValue))
+	}
+	if m.Int64Value != 0 {
+		b = wire.AppendTag(b, 5, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(m.Int64Value))
+	}
+	if m.Uint64Value != 0 {
+		b = wire.AppendTag(b, 6, wire.VarintType)
+		b = wire.AppendVarint(b, m.Uint64Value)
+	}
+	if m.Int32Value != 0 {
+		b = wire.AppendTag(b, 7, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(m.Int32Value))
+	}
+	if m.Fixed64Value != 0 {
+		b = wire.AppendTag(b, 8, wire.Fixed64Type)
+		b = wire.AppendFixed64(b, m.Fixed64Value)
+	}
+	if m.Fixed32Value != 0 {
+		b = wire.AppendTag(b, 9, wire.Fixed32Type)
+		b = wire.AppendFixed32(b, m.Fixed32Value)
+	}
+	if m.BoolValue {
+		b = wire.AppendTag(b, 10, wire.VarintType)
+		b = wire.AppendVarint(b, wire.EncodeBool(m.BoolValue))
+	}
+	if m.StringValue != "" {
+		b = wire.AppendTag(b, 11, wire.BytesType)
+		b = wire.AppendString(b, m.StringValue)
+	}
+	if m.Uint32Value != 0 {
+		b = wire.AppendTag(b, 13, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(m.Uint32Value))
+	}
+	if m.EnumValue != 0 {
+		b = wire.AppendTag(b, 14, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(m.EnumValue))
+	}
+	if m.Sfixed32Value != 0 {
+		b = wire.AppendTag(b, 15, wire.Fixed32Type)
+		b = wire.AppendFixed32(b, uint32(m.Sfixed32Value))
+	}
+	if m.Sfixed64Value != 0 {
+		b = wire.AppendTag(b, 16, wire.Fixed64Type)
+		b = wire.AppendFixed64(b, uint64(m.Sfixed64Value))
+	}
+	if m.Sint32Value != 0 {
+		b = wire.AppendTag(b, 17, wire.VarintType)
+		b = wire.AppendVarint(b, wire.EncodeZigZag(int64(m.Sint32Value)))
+	}
+	if m.Sint64Value != 0 {
+		b = wire.AppendTag(b, 18, wire.VarintType)
+		b = wire.AppendVarint(b, wire.EncodeZigZag(m.Sint64Value))
+	}
+	for _, x := range m.RepeatedStringValue {
+		b = wire.AppendTag(b, 19, wire.BytesType)
+		b = wire.AppendString(b, x)
+	}
+	switch x := m.OneofValue.(type) {
+	case *ABitOfEverything_OneofEmpty:
+		b = wire.AppendTag(b, 20, wire.BytesType)
+		b = wire.AppendMessage(b, x.OneofEmpty)
+	case *ABitOfEverything_OneofString:
+		b = wire.AppendTag(b, 21, wire.BytesType)
+		b = wire.AppendString(b, x.OneofString)
+	}
+	for k, v := range m.MapValue {
+		b = wire.AppendTag(b, 22, wire.BytesType)
+		start := len(b)
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		b = wire.AppendString(b, k)
+		b = wire.AppendTag(b, 2, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(v))
+		b = wire.InsertLength(b, start)
+	}
+	for k, v := range m.MappedStringValue {
+		b = wire.AppendTag(b, 23, wire.BytesType)
+		start := len(b)
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		b = wire.AppendString(b, k)
+		b = wire.AppendTag(b, 2, wire.BytesType)
+		b = wire.AppendString(b, v)
+		b = wire.InsertLength(b, start)
+	}
+	for k, v := range m.MappedNestedValue {
+		b = wire.AppendTag(b, 24, wire.BytesType)
+		start := len(b)
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		b = wire.AppendString(b, k)
+		b = wire.AppendTag(b, 2, wire.BytesType)
+		b = wire.AppendMessage(b, v)
+		b = wire.InsertLength(b, start)
+	}
+	if m.SingleNested != nil {
+		b = wire.AppendTag(b, 25, wire.BytesType)
+		b = wire.AppendMessage(b, m.SingleNested)
+	}
+	if m.NonConventionalNameValue != "" {
+		b = wire.AppendTag(b, 26, wire.BytesType)
+		b = wire.AppendString(b, m.NonConventionalNameValue)
+	}
+	if m.TimestampValue != nil {
+		b = wire.AppendTag(b, 27, wire.BytesType)
+		b = wire.AppendMessage(b, m.TimestampValue)
+	}
+	if len(m.RepeatedEnumValue) > 0 {
+		b = wire.AppendTag(b, 28, wire.BytesType)
+		start := len(b)
+		for _, x := range m.RepeatedEnumValue {
+			b = wire.AppendVarint(b, uint64(x))
+		}
+		b = wire.InsertLength(b, start)
+	}
+	if len(m.BytesValue) > 0 {
+		b = wire.AppendTag(b, 29, wire.BytesType)
+		b = wire.AppendBytes(b, m.BytesValue)
+	}
+	if m.PathEnumValue != 0 {
+		b = wire.AppendTag(b, 30, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(m.PathEnumValue))
+	}
+	if m.NestedPathEnumValue != 0 {
+		b = wire.AppendTag(b, 31, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(m.NestedPathEnumValue))
+	}
+	if len(m.RepeatedEnumAnnotation) > 0 {
+		b = wire.AppendTag(b, 32, wire.BytesType)
+		start := len(b)
+		for _, x := range m.RepeatedEnumAnnotation {
+			b = wire.AppendVarint(b, uint64(x))
+		}
+		b = wire.InsertLength(b, start)
+	}
+	if m.EnumValueAnnotation != 0 {
+		b = wire.AppendTag(b, 33, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(m.EnumValueAnnotation))
+	}
+	for _, x := range m.RepeatedStringAnnotation {
+		b = wire.AppendTag(b, 34, wire.BytesType)
+		b = wire.AppendString(b, x)
+	}
+	for _, x := range m.RepeatedNestedAnnotation {
+		b = wire.AppendTag(b, 35, wire.BytesType)
+		b = wire.AppendMessage(b, x)
+	}
+	if m.NestedAnnotation != nil {
+		b = wire.AppendTag(b, 36, wire.BytesType)
+		b = wire.AppendMessage(b, m.NestedAnnotation)
+	}
+	if m.Int64OverrideType != 0 {
+		b = wire.AppendTag(b, 37, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(m.Int64OverrideType))
+	}
+	if m.RequiredStringViaFieldBehaviorAnnotation != "" {
+		b = wire.AppendTag(b, 38, wire.BytesType)
+		b = wire.AppendString(b, m.RequiredStringViaFieldBehaviorAnnotation)
+	}
+	if m.OutputOnlyStringViaFieldBehaviorAnnotation != "" {
+		b = wire.AppendTag(b, 39, wire.BytesType)
+		b = wire.AppendString(b, m.OutputOnlyStringViaFieldBehaviorAnnotation)
+	}
+	if m.OptionalStringValue != nil {
+		b = wire.AppendTag(b, 40, wire.BytesType)
+		b = wire.AppendString(b, *m.OptionalStringValue)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *ABitOfEverything) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 25 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			if m.SingleNested == nil {
+				m.SingleNested = new(ABitOfEverything_Nested)
+			}
+			if err := m.SingleNested.Unmarshal(v); err != nil {
+				return err
+			}
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Uuid = string(v)
+		case num == 2 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			x := new(ABitOfEverything_Nested)
+			if err := x.Unmarshal(v); err != nil {
+				return err
+			}
+			m.Nested = append(m.Nested, x)
+		case num == 3 && typ == wire.Fixed32Type:
+			var v uint32
+			v, n = wire.ConsumeFixed32(b)
+			if n < 0 {
+				break
+			}
+			m.FloatValue = math.Float32frombits(v)
+		case num == 4 && typ == wire.Fixed64Type:
+			var v uint64
+			v, n = wire.ConsumeFixed64(b)
+			if n < 0 {
+				break
+			}
+			m.DoubleValue = math.Float64frombits(v)
+		case num == 5 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Int64Value = int64(v)
+		case num == 6 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Uint64Value = v
+		case num == 7 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Int32Value = int32(v)
+		case num == 8 && typ == wire.Fixed64Type:
+			var v uint64
+			v, n = wire.ConsumeFixed64(b)
+			if n < 0 {
+				break
+			}
+			m.Fixed64Value = v
+		case num == 9 && typ == wire.Fixed32Type:
+			var v uint32
+			v, n = wire.ConsumeFixed32(b)
+			if n < 0 {
+				break
+			}
+			m.Fixed32Value = v
+		case num == 10 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.BoolValue = wire.DecodeBool(v)
+		case num == 11 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.StringValue = string(v)
+		case num == 29 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.BytesValue = append([]byte{}, v...)
+		case num == 13 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Uint32Value = uint32(v)
+		case num == 14 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.EnumValue = NumericEnum(v)
+		case num == 30 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.PathEnumValue = PathEnum(v)
+		case num == 31 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.NestedPathEnumValue = MessagePathEnum_NestedPathEnum(v)
+		case num == 15 && typ == wire.Fixed32Type:
+			var v uint32
+			v, n = wire.ConsumeFixed32(b)
+			if n < 0 {
+				break
+			}
+			m.Sfixed32Value = int32(v)
+		case num == 16 && typ == wire.Fixed64Type:
+			var v uint64
+			v, n = wire.ConsumeFixed64(b)
+			if n < 0 {
+				break
+			}
+			m.Sfixed64Value = int64(v)
+		case num == 17 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Sint32Value = int32(wire.DecodeZigZag(uint64(uint32(v))))
+		case num == 18 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Sint64Value = wire.DecodeZigZag(v)
+		case num == 19 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.RepeatedStringValue = append(m.RepeatedStringValue, string(v))
+		case num == 20 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			x := new(Empty)
+			if err := x.Unmarshal(v); err != nil {
+				return err
+			}
+			m.OneofValue = &ABitOfEverything_OneofEmpty{OneofEmpty: x}
+		case num == 21 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.OneofValue = &ABitOfEverything_OneofString{OneofString: string(v)}
+		case num == 22 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			var key string
+			var val NumericEnum
+			for len(v) > 0 {
+				num, typ, k := wire.ConsumeTag(v)
+				if k < 0 {
+					return wire.ParseError(k)
+				}
+				v = v[k:]
+
+				switch {
+				case num == 1 && typ == wire.BytesType:
+					var x []byte
+					x, k = wire.ConsumeBytes(v)
+					if k < 0 {
+						break
+					}
+					key = string(x)
+				case num == 2 && typ == wire.VarintType:
+					var x uint64
+					x, k = wire.ConsumeVarint(v)
+					if k < 0 {
+						break
+					}
+					val = NumericEnum(x)
+				default:
+					k = wire.ConsumeFieldValue(num, typ, v)
+				}
+				if k < 0 {
+					return wire.ParseError(k)
+				}
+				v = v[k:]
+			}
+			if m.MapValue == nil {
+				m.MapValue = make(map[string]NumericEnum)
+			}
+			m.MapValue[key] = val
+		case num == 23 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			var key string
+			var val string
+			for len(v) > 0 {
+				num, typ, k := wire.ConsumeTag(v)
+				if k < 0 {
+					return wire.ParseError(k)
+				}
+				v = v[k:]
+
+				switch {
+				case num == 1 && typ == wire.BytesType:
+					var x []byte
+					x, k = wire.ConsumeBytes(v)
+					if k < 0 {
+						break
+					}
+					key = string(x)
+				case num == 2 && typ == wire.BytesType:
+					var x []byte
+					x, k = wire.ConsumeBytes(v)
+					if k < 0 {
+						break
+					}
+					val = string(x)
+				default:
+					k = wire.ConsumeFieldValue(num, typ, v)
+				}
+				if k < 0 {
+					return wire.ParseError(k)
+				}
+				v = v[k:]
+			}
+			if m.MappedStringValue == nil {
+				m.MappedStringValue = make(map[string]string)
+			}
+			m.MappedStringValue[key] = val
+		case num == 24 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			var key string
+			val := new(ABitOfEverything_Nested)
+			for len(v) > 0 {
+				num, typ, k := wire.ConsumeTag(v)
+				if k < 0 {
+					return wire.ParseError(k)
+				}
+				v = v[k:]
+
+				switch {
+				case num == 1 && typ == wire.BytesType:
+					var x []byte
+					x, k = wire.ConsumeBytes(v)
+					if k < 0 {
+						break
+					}
+					key = string(x)
+				case num == 2 && typ == wire.BytesType:
+					var x []byte
+					x, k = wire.ConsumeBytes(v)
+					if k < 0 {
+						break
+					}
+					y := new(ABitOfEverything_Nested)
+					if err := y.Unmarshal(x); err != nil {
+						return err
+					}
+					val = y
+				default:
+					k = wire.ConsumeFieldValue(num, typ, v)
+				}
+				if k < 0 {
+					return wire.ParseError(k)
+				}
+				v = v[k:]
+			}
+			if m.MappedNestedValue == nil {
+				m.MappedNestedValue = make(map[string]*ABitOfEverything_Nested)
+			}
+			m.MappedNestedValue[key] = val
+		case num == 26 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.NonConventionalNameValue = string(v)
+		case num == 27 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			if m.TimestampValue == nil {
+				m.TimestampValue = new(Timestamp)
+			}
+			if err := m.TimestampValue.Unmarshal(v); err != nil {
+				return err
+			}
+		case num == 28 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			for len(v) > 0 {
+				var k int
+				var x uint64
+				x, k = wire.ConsumeVarint(v)
+				if k < 0 {
+					return wire.ParseError(k)
+				}
+				m.RepeatedEnumValue = append(m.RepeatedEnumValue, NumericEnum(x))
+				v = v[k:]
+			}
+		case num == 28 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.RepeatedEnumValue = append(m.RepeatedEnumValue, NumericEnum(v))
+		case num == 32 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			for len(v) > 0 {
+				var k int
+				var x uint64
+				x, k = wire.ConsumeVarint(v)
+				if k < 0 {
+					return wire.ParseError(k)
+				}
+				m.RepeatedEnumAnnotation = append(m.RepeatedEnumAnnotation, NumericEnum(x))
+				v = v[k:]
+			}
+		case num == 32 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.RepeatedEnumAnnotation = append(m.RepeatedEnumAnnotation, NumericEnum(v))
+		case num == 33 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.EnumValueAnnotation = NumericEnum(v)
+		case num == 34 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.RepeatedStringAnnotation = append(m.RepeatedStringAnnotation, string(v))
+		case num == 35 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			x := new(ABitOfEverything_Nested)
+			if err := x.Unmarshal(v); err != nil {
+				return err
+			}
+			m.RepeatedNestedAnnotation = append(m.RepeatedNestedAnnotation, x)
+		case num == 36 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			if m.NestedAnnotation == nil {
+				m.NestedAnnotation = new(ABitOfEverything_Nested)
+			}
+			if err := m.NestedAnnotation.Unmarshal(v); err != nil {
+				return err
+			}
+		case num == 37 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Int64OverrideType = int64(v)
+		case num == 38 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.RequiredStringViaFieldBehaviorAnnotation = string(v)
+		case num == 39 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.OutputOnlyStringViaFieldBehaviorAnnotation = string(v)
+		case num == 40 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.OptionalStringValue = new(string)
+			*m.OptionalStringValue = string(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type ABitOfEverything_Nested struct {
+	Name   string
+	Amount uint32
+	Ok     ABitOfEverything_Nested_DeepEnum
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *ABitOfEverything_Nested) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *ABitOfEverything_Nested) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Name != "" {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		b = wire.AppendString(b, m.Name)
+	}
+	if m.Amount != 0 {
+		b = wire.AppendTag(b, 2, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(m.Amount))
+	}
+	if m.Ok != 0 {
+		b = wire.AppendTag(b, 3, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(m.Ok))
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *ABitOfEverything_Nested) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Name = string(v)
+		case num == 2 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Amount = uint32(v)
+		case num == 3 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Ok = ABitOfEverything_Nested_DeepEnum(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type ABitOfEverything_Nested_DeepEnum int32
+
+const (
+	ABitOfEverything_Nested_FALSE ABitOfEverything_Nested_DeepEnum = 0
+	ABitOfEverything_Nested_TRUE  ABitOfEverything_Nested_DeepEnum = 1
+)
+
+func (x ABitOfEverything_Nested_DeepEnum) String() string {
+	switch x {
+	case ABitOfEverything_Nested_FALSE:
+		return "FALSE"
+	case ABitOfEverything_Nested_TRUE:
+		return "TRUE"
+	}
+	return strconv.Itoa(int(x))
+}
+
+type ABitOfEverythingRepeated struct {
+	PathRepeatedFloatValue    []float32
+	PathRepeatedDoubleValue   []float64
+	PathRepeatedInt64Value    []int64
+	PathRepeatedUint64Value   []uint64
+	PathRepeatedInt32Value    []int32
+	PathRepeatedFixed64Value  []uint64
+	PathRepeatedFixed32Value  []uint32
+	PathRepeatedBoolValue     []bool
+	PathRepeatedStringValue   []string
+	PathRepeatedBytesValue    [][]byte
+	PathRepeatedUint32Value   []uint32
+	PathRepeatedEnumValue     []NumericEnum
+	PathRepeatedSfixed32Value []int32
+	PathRepeatedSfixed64Value []int64
+	PathRepeatedSint32Value   []int32
+	PathRepeatedSint64Value   []int64
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *ABitOfEverythingRepeated) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *ABitOfEverythingRepeated) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if len(m.PathRepeatedFloatValue) > 0 {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		start := len(b)
+		for _, x := range m.PathRepeatedFloatValue {
+			b = wire.AppendFixed32(b, math.Float32bits(x))
+		}
+		b = wire.InsertLength(b, start)
+	}
+	if len(m.PathRepeatedDoubleValue) > 0 {
+		b = wire.AppendTag(b, 2, wire.BytesType)
+		start := len(b)
+		for _, x := range m.PathRepeatedDoubleValue {
+			b = wire.AppendFixed64(b, math.Float64bits(x))
+		}
+		b = wire.InsertLength(b, start)
+	}
+	if len(m.PathRepeatedInt64Value) > 0 {
+		b = wire.AppendTag(b, 3, wire.BytesType)
+		start := len(b)
+		for _, x := range m.PathRepeatedInt64Value {
+			b = wire.AppendVarint(b, uint64(x))
+		}
+		b = wire.InsertLength(b, start)
+	}
+	if len(m.PathRepeatedUint64Value) > 0 {
+		b = wire.AppendTag(b, 4, wire.BytesType)
+		start := len(b)
+		for _, x := range m.PathRepeatedUint64Value {
+			b = wire.AppendVarint(b, x)
+		}
+		b = wire.InsertLength(b, start)
+	}
+	if len(m.PathRepeatedInt32Value) > 0 {
+		b = wire.AppendTag(b, 5, wire.BytesType)
+		start := len(b)
+		for _, x := range m.PathRepeatedInt32Value {
+			b = wire.AppendVarint(b, uint64(x))
+		}
+		b = wire.InsertLength(b, start)
+	}
+	if len(m.PathRepeatedFixed64Value) > 0 {
+		b = wire.AppendTag(b, 6, wire.BytesType)
+		start := len(b)
+		for _, x := range m.PathRepeatedFixed64Value {
+			b = wire.AppendFixed64(b, x)
+		}
+		b = wire.InsertLength(b, start)
+	}
+	if len(m.PathRepeatedFixed32Value) > 0 {
+		b = wire.AppendTag(b, 7, wire.BytesType)
+		start := len(b)
+		for _, x := range m.PathRepeatedFixed32Value {
+			b = wire.AppendFixed32(b, x)
+		}
+		b = wire.InsertLength(b, start)
+	}
+	if len(m.PathRepeatedBoolValue) > 0 {
+		b = wire.AppendTag(b, 8, wire.BytesType)
+		start := len(b)
+		for _, x := range m.PathRepeatedBoolValue {
+			b = wire.AppendVarint(b, wire.EncodeBool(x))
+		}
+		b = wire.InsertLength(b, start)
+	}
+	for _, x := range m.PathRepeatedStringValue {
+		b = wire.AppendTag(b, 9, wire.BytesType)
+		b = wire.AppendString(b, x)
+	}
+	for _, x := range m.PathRepeatedBytesValue {
+		b = wire.AppendTag(b, 10, wire.BytesType)
+		b = wire.AppendBytes(b, x)
+	}
+	if len(m.PathRepeatedUint32Value) > 0 {
+		b = wire.AppendTag(b, 11, wire.BytesType)
+		start := len(b)
+		for _, x := range m.PathRepeatedUint32Value {
+			b = wire.AppendVarint(b, uint64(x))
+		}
+		b = wire.InsertLength(b, start)
+	}
+	if len(m.PathRepeatedEnumValue) > 0 {
+		b = wire.AppendTag(b, 12, wire.BytesType)
+		start := len(b)
+		for _, x := range m.PathRepeatedEnumValue {
+			b = wire.AppendVarint(b, uint64(x))
+		}
+		b = wire.InsertLength(b, start)
+	}
+	if len(m.PathRepeatedSfixed32Value) > 0 {
+		b = wire.AppendTag(b, 13, wire.BytesType)
+		start := len(b)
+		for _, x := range m.PathRepeatedSfixed32Value {
+			b = wire.AppendFixed32(b, uint32(x))
+		}
+		b = wire.InsertLength(b, start)
+	}
+	if len(m.PathRepeatedSfixed64Value) > 0 {
+		b = wire.AppendTag(b, 14, wire.BytesType)
+		start := len(b)
+		for _, x := range m.PathRepeatedSfixed64Value {
+			b = wire.AppendFixed64(b, uint64(x))
+		}
+		b = wire.InsertLength(b, start)
+	}
+	if len(m.PathRepeatedSint32Value) > 0 {
+		b = wire.AppendTag(b, 15, wire.BytesType)
+		start := len(b)
+		for _, x := range m.PathRepeatedSint32Value {
+			b = wire.AppendVarint(b, wire.EncodeZigZag(int64(x)))
+		}
+		b = wire.InsertLength(b, start)
+	}
+	if len(m.PathRepeatedSint64Value) > 0 {
+		b = wire.AppendTag(b, 16, wire.BytesType)
+		start := len(b)
+		for _, x := range m.PathRepeatedSint64Value {
+			b = wire.AppendVarint(b, wire.EncodeZigZag(x))
+		}
+		b = wire.InsertLength(b, start)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *ABitOfEverythingRepeated) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			for len(v) > 0 {
+				var k int
+				var x uint32
+				x, k = wire.ConsumeFixed32(v)
+				if k < 0 {
+					return wire.ParseError(k)
+				}
+				m.PathRepeatedFloatValue = append(m.PathRepeatedFloatValue, math.Float32frombits(x))
+				v = v[k:]
+			}
+		case num == 1 && typ == wire.Fixed32Type:
+			var v uint32
+			v, n = wire.ConsumeFixed32(b)
+			if n < 0 {
+				break
+			}
+			m.PathRepeatedFloatValue = append(m.PathRepeatedFloatValue, math.Float32frombits(v))
+		case num == 2 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			for len(v) > 0 {
+				var k int
+				var x uint64
+				x, k = wire.ConsumeFixed64(v)
+				if k < 0 {
+					return wire.ParseError(k)
+				}
+				m.PathRepeatedDoubleValue = append(m.PathRepeatedDoubleValue, math.Float64frombits(x))
+				v = v[k:]
+			}
+		case num == 2 && typ == wire.Fixed64Type:
+			var v uint64
+			v, n = wire.ConsumeFixed64(b)
+			if n < 0 {
+				break
+			}
+			m.PathRepeatedDoubleValue = append(m.PathRepeatedDoubleValue, math.Float64frombits(v))
+		case num == 3 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			for len(v) > 0 {
+				var k int
+				var x uint64
+				x, k = wire.ConsumeVarint(v)
+				if k < 0 {
+					return wire.ParseError(k)
+				}
+				m.PathRepeatedInt64Value = append(m.PathRepeatedInt64Value, int64(x))
+				v = v[k:]
+			}
+		case num == 3 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.PathRepeatedInt64Value = append(m.PathRepeatedInt64Value, int64(v))
+		case num == 4 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			for len(v) > 0 {
+				var k int
+				var x uint64
+				x, k = wire.ConsumeVarint(v)
+				if k < 0 {
+					return wire.ParseError(k)
+				}
+				m.PathRepeatedUint64Value = append(m.PathRepeatedUint64Value, x)
+				v = v[k:]
+			}
+		case num == 4 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.PathRepeatedUint64Value = append(m.PathRepeatedUint64Value, v)
+		case num == 5 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			for len(v) > 0 {
+				var k int
+				var x uint64
+				x, k = wire.ConsumeVarint(v)
+				if k < 0 {
+					return wire.ParseError(k)
+				}
+				m.PathRepeatedInt32Value = append(m.PathRepeatedInt32Value, int32(x))
+				v = v[k:]
+			}
+		case num == 5 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.PathRepeatedInt32Value = append(m.PathRepeatedInt32Value, int32(v))
+		case num == 6 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			for len(v) > 0 {
+				var k int
+				var x uint64
+				x, k = wire.ConsumeFixed64(v)
+				if k < 0 {
+					return wire.ParseError(k)
+				}
+				m.PathRepeatedFixed64Value = append(m.PathRepeatedFixed64Value, x)
+				v = v[k:]
+			}
+		case num == 6 && typ == wire.Fixed64Type:
+			var v uint64
+			v, n = wire.ConsumeFixed64(b)
+			if n < 0 {
+				break
+			}
+			m.PathRepeatedFixed64Value = append(m.PathRepeatedFixed64Value, v)
+		case num == 7 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			for len(v) > 0 {
+				var k int
+				var x uint32
+				x, k = wire.ConsumeFixed32(v)
+				if k < 0 {
+					return wire.ParseError(k)
+				}
+				m.PathRepeatedFixed32Value = append(m.PathRepeatedFixed32Value, x)
+				v = v[k:]
+			}
+		case num == 7 && typ == wire.Fixed32Type:
+			var v uint32
+			v, n = wire.ConsumeFixed32(b)
+			if n < 0 {
+				break
+			}
+			m.PathRepeatedFixed32Value = append(m.PathRepeatedFixed32Value, v)
+		case num == 8 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			for len(v) > 0 {
+				var k int
+				var x uint64
+				x, k = wire.ConsumeVarint(v)
+				if k < 0 {
+					return wire.ParseError(k)
+				}
+				m.PathRepeatedBoolValue = append(m.PathRepeatedBoolValue, wire.DecodeBool(x))
+				v = v[k:]
+			}
+		case num == 8 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.PathRepeatedBoolValue = append(m.PathRepeatedBoolValue, wire.DecodeBool(v))
+		case num == 9 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.PathRepeatedStringValue = append(m.PathRepeatedStringValue, string(v))
+		case num == 10 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.PathRepeatedBytesValue = append(m.PathRepeatedBytesValue, append([]byte{}, v...))
+		case num == 11 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			for len(v) > 0 {
+				var k int
+				var x uint64
+				x, k = wire.ConsumeVarint(v)
+				if k < 0 {
+					return wire.ParseError(k)
+				}
+				m.PathRepeatedUint32Value = append(m.PathRepeatedUint32Value, uint32(x))
+				v = v[k:]
+			}
+		case num == 11 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.PathRepeatedUint32Value = append(m.PathRepeatedUint32Value, uint32(v))
+		case num == 12 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			for len(v) > 0 {
+				var k int
+				var x uint64
+				x, k = wire.ConsumeVarint(v)
+				if k < 0 {
+					return wire.ParseError(k)
+				}
+				m.PathRepeatedEnumValue = append(m.PathRepeatedEnumValue, NumericEnum(x))
+				v = v[k:]
+			}
+		case num == 12 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.PathRepeatedEnumValue = append(m.PathRepeatedEnumValue, NumericEnum(v))
+		case num == 13 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			for len(v) > 0 {
+				var k int
+				var x uint32
+				x, k = wire.ConsumeFixed32(v)
+				if k < 0 {
+					return wire.ParseError(k)
+				}
+				m.PathRepeatedSfixed32Value = append(m.PathRepeatedSfixed32Value, int32(x))
+				v = v[k:]
+			}
+		case num == 13 && typ == wire.Fixed32Type:
+			var v uint32
+			v, n = wire.ConsumeFixed32(b)
+			if n < 0 {
+				break
+			}
+			m.PathRepeatedSfixed32Value = append(m.PathRepeatedSfixed32Value, int32(v))
+		case num == 14 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			for len(v) > 0 {
+				var k int
+				var x uint64
+				x, k = wire.ConsumeFixed64(v)
+				if k < 0 {
+					return wire.ParseError(k)
+				}
+				m.PathRepeatedSfixed64Value = append(m.PathRepeatedSfixed64Value, int64(x))
+				v = v[k:]
+			}
+		case num == 14 && typ == wire.Fixed64Type:
+			var v uint64
+			v, n = wire.ConsumeFixed64(b)
+			if n < 0 {
+				break
+			}
+			m.PathRepeatedSfixed64Value = append(m.PathRepeatedSfixed64Value, int64(v))
+		case num == 15 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			for len(v) > 0 {
+				var k int
+				var x uint64
+				x, k = wire.ConsumeVarint(v)
+				if k < 0 {
+					return wire.ParseError(k)
+				}
+				m.PathRepeatedSint32Value = append(m.PathRepeatedSint32Value, int32(wire.DecodeZigZag(uint64(uint32(x)))))
+				v = v[k:]
+			}
+		case num == 15 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.PathRepeatedSint32Value = append(m.PathRepeatedSint32Value, int32(wire.DecodeZigZag(uint64(uint32(v)))))
+		case num == 16 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			for len(v) > 0 {
+				var k int
+				var x uint64
+				x, k = wire.ConsumeVarint(v)
+				if k < 0 {
+					return wire.ParseError(k)
+				}
+				m.PathRepeatedSint64Value = append(m.PathRepeatedSint64Value, wire.DecodeZigZag(x))
+				v = v[k:]
+			}
+		case num == 16 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.PathRepeatedSint64Value = append(m.PathRepeatedSint64Value, wire.DecodeZigZag(v))
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type CheckStatusResponse struct {
+	Status *Status
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *CheckStatusResponse) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *CheckStatusResponse) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Status != nil {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		b = wire.AppendMessage(b, m.Status)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *CheckStatusResponse) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			if m.Status == nil {
+				m.Status = new(Status)
+			}
+			if err := m.Status.Unmarshal(v); err != nil {
+				return err
+			}
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type Body struct {
+	Name string
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *Body) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *Body) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Name != "" {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		b = wire.AppendString(b, m.Name)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *Body) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Name = string(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type MessageWithBody struct {
+	Id   string
+	Data *Body
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *MessageWithBody) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *MessageWithBody) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Id != "" {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		b = wire.AppendString(b, m.Id)
+	}
+	if m.Data != nil {
+		b = wire.AppendTag(b, 2, wire.BytesType)
+		b = wire.AppendMessage(b, m.Data)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *MessageWithBody) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Id = string(v)
+		case num == 2 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			if m.Data == nil {
+				m.Data = new(Body)
+			}
+			if err := m.Data.Unmarshal(v); err != nil {
+				return err
+			}
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type UpdateV2Request struct {
+	Abe        *ABitOfEverything
+	UpdateMask *FieldMask
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *UpdateV2Request) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *UpdateV2Request) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Abe != nil {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		b = wire.AppendMessage(b, m.Abe)
+	}
+	if m.UpdateMask != nil {
+		b = wire.AppendTag(b, 2, wire.BytesType)
+		b = wire.AppendMessage(b, m.UpdateMask)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *UpdateV2Request) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			if m.Abe == nil {
+				m.Abe = new(ABitOfEverything)
+			}
+			if err := m.Abe.Unmarshal(v); err != nil {
+				return err
+			}
+		case num == 2 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			if m.UpdateMask == nil {
+				m.UpdateMask = new(FieldMask)
+			}
+			if err := m.UpdateMask.Unmarshal(v); err != nil {
+				return err
+			}
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type Book struct {
+	Name       string
+	Id         string
+	CreateTime *Timestamp
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *Book) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *Book) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Name != "" {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		b = wire.AppendString(b, m.Name)
+	}
+	if m.Id != "" {
+		b = wire.AppendTag(b, 2, wire.BytesType)
+		b = wire.AppendString(b, m.Id)
+	}
+	if m.CreateTime != nil {
+		b = wire.AppendTag(b, 3, wire.BytesType)
+		b = wire.AppendMessage(b, m.CreateTime)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *Book) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Name = string(v)
+		case num == 2 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Id = string(v)
+		case num == 3 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			if m.CreateTime == nil {
+				m.CreateTime = new(Timestamp)
+			}
+			if err := m.CreateTime.Unmarshal(v); err != nil {
+				return err
+			}
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type CreateBookRequest struct {
+	Parent string
+	Book   *Book
+	BookId string
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *CreateBookRequest) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *CreateBookRequest) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Parent != "" {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		b = wire.AppendString(b, m.Parent)
+	}
+	if m.Book != nil {
+		b = wire.AppendTag(b, 2, wire.BytesType)
+		b = wire.AppendMessage(b, m.Book)
+	}
+	if m.BookId != "" {
+		b = wire.AppendTag(b, 3, wire.BytesType)
+		b = wire.AppendString(b, m.BookId)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *CreateBookRequest) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Parent = string(v)
+		case num == 2 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			if m.Book == nil {
+				m.Book = new(Book)
+			}
+			if err := m.Book.Unmarshal(v); err != nil {
+				return err
+			}
+		case num == 3 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.BookId = string(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type UpdateBookRequest struct {
+	Book         *Book
+	UpdateMask   *FieldMask
+	AllowMissing bool
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *UpdateBookRequest) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *UpdateBookRequest) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Book != nil {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		b = wire.AppendMessage(b, m.Book)
+	}
+	if m.UpdateMask != nil {
+		b = wire.AppendTag(b, 2, wire.BytesType)
+		b = wire.AppendMessage(b, m.UpdateMask)
+	}
+	if m.AllowMissing {
+		b = wire.AppendTag(b, 3, wire.VarintType)
+		b = wire.AppendVarint(b, wire.EncodeBool(m.AllowMissing))
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *UpdateBookRequest) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			if m.Book == nil {
+				m.Book = new(Book)
+			}
+			if err := m.Book.Unmarshal(v); err != nil {
+				return err
+			}
+		case num == 2 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			if m.UpdateMask == nil {
+				m.UpdateMask = new(FieldMask)
+			}
+			if err := m.UpdateMask.Unmarshal(v); err != nil {
+				return err
+			}
+		case num == 3 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.AllowMissing = wire.DecodeBool(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type PathEnum int32
+
+const (
+	PathEnum_ABC PathEnum = 0
+	PathEnum_DEF PathEnum = 1
+)
+
+func (x PathEnum) String() string {
+	switch x {
+	case PathEnum_ABC:
+		return "ABC"
+	case PathEnum_DEF:
+		return "DEF"
+	}
+	return strconv.Itoa(int(x))
+}
+
+type MessagePathEnum struct {
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *MessagePathEnum) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *MessagePathEnum) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *MessagePathEnum) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		n = wire.ConsumeFieldValue(num, typ, b)
+		if n >= 0 {
+			m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type MessagePathEnum_NestedPathEnum int32
+
+const (
+	MessagePathEnum_GHI MessagePathEnum_NestedPathEnum = 0
+	MessagePathEnum_JKL MessagePathEnum_NestedPathEnum = 1
+)
+
+func (x MessagePathEnum_NestedPathEnum) String() string {
+	switch x {
+	case MessagePathEnum_GHI:
+		return "GHI"
+	case MessagePathEnum_JKL:
+		return "JKL"
+	}
+	return strconv.Itoa(int(x))
+}
+
+type MessageWithPathEnum struct {
+	Value PathEnum
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *MessageWithPathEnum) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *MessageWithPathEnum) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Value != 0 {
+		b = wire.AppendTag(b, 1, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(m.Value))
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *MessageWithPathEnum) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Value = PathEnum(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type MessageWithNestedPathEnum struct {
+	Value MessagePathEnum_NestedPathEnum
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *MessageWithNestedPathEnum) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *MessageWithNestedPathEnum) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Value != 0 {
+		b = wire.AppendTag(b, 1, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(m.Value))
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *MessageWithNestedPathEnum) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Value = MessagePathEnum_NestedPathEnum(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type StringMessage struct {
+	Value *string
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *StringMessage) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *StringMessage) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Value != nil {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		b = wire.AppendString(b, *m.Value)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *StringMessage) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Value = new(string)
+			*m.Value = string(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type IdMessage struct {
+	Uuid string
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *IdMessage) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *IdMessage) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Uuid != "" {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		b = wire.AppendString(b, m.Uuid)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *IdMessage) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Uuid = string(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type Status struct {
+	Code    int32
+	Message string
+	Details []*Any
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *Status) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *Status) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Code != 0 {
+		b = wire.AppendTag(b, 1, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(m.Code))
+	}
+	if m.Message != "" {
+		b = wire.AppendTag(b, 2, wire.BytesType)
+		b = wire.AppendString(b, m.Message)
+	}
+	for _, x := range m.Details {
+		b = wire.AppendTag(b, 3, wire.BytesType)
+		b = wire.AppendMessage(b, x)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *Status) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Code = int32(v)
+		case num == 2 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Message = string(v)
+		case num == 3 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			x := new(Any)
+			if err := x.Unmarshal(v); err != nil {
+				return err
+			}
+			m.Details = append(m.Details, x)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type Any struct {
+	TypeUrl string
+	Value   []byte
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *Any) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *Any) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.TypeUrl != "" {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		b = wire.AppendString(b, m.TypeUrl)
+	}
+	if len(m.Value) > 0 {
+		b = wire.AppendTag(b, 2, wire.BytesType)
+		b = wire.AppendBytes(b, m.Value)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *Any) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.TypeUrl = string(v)
+		case num == 2 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Value = append([]byte{}, v...)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type Duration struct {
+	Seconds int64
+	Nanos   int32
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *Duration) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *Duration) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Seconds != 0 {
+		b = wire.AppendTag(b, 1, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(m.Seconds))
+	}
+	if m.Nanos != 0 {
+		b = wire.AppendTag(b, 2, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(m.Nanos))
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *Duration) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Seconds = int64(v)
+		case num == 2 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Nanos = int32(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type Empty struct {
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *Empty) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *Empty) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *Empty) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		n = wire.ConsumeFieldValue(num, typ, b)
+		if n >= 0 {
+			m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type FieldMask struct {
+	Paths []string
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *FieldMask) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *FieldMask) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	for _, x := range m.Paths {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		b = wire.AppendString(b, x)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *FieldMask) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Paths = append(m.Paths, string(v))
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type Timestamp struct {
+	Seconds int64
+	Nanos   int32
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *Timestamp) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *Timestamp) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Seconds != 0 {
+		b = wire.AppendTag(b, 1, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(m.Seconds))
+	}
+	if m.Nanos != 0 {
+		b = wire.AppendTag(b, 2, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(m.Nanos))
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *Timestamp) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Seconds = int64(v)
+		case num == 2 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Nanos = int32(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type DoubleValue struct {
+	Value float64
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *DoubleValue) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *DoubleValue) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Value != 0 {
+		b = wire.AppendTag(b, 1, wire.Fixed64Type)
+		b = wire.AppendFixed64(b, math.Float64bits(m.Value))
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *DoubleValue) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.Fixed64Type:
+			var v uint64
+			v, n = wire.ConsumeFixed64(b)
+			if n < 0 {
+				break
+			}
+			m.Value = math.Float64frombits(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type FloatValue struct {
+	Value float32
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *FloatValue) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *FloatValue) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Value != 0 {
+		b = wire.AppendTag(b, 1, wire.Fixed32Type)
+		b = wire.AppendFixed32(b, math.Float32bits(m.Value))
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *FloatValue) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.Fixed32Type:
+			var v uint32
+			v, n = wire.ConsumeFixed32(b)
+			if n < 0 {
+				break
+			}
+			m.Value = math.Float32frombits(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type Int64Value struct {
+	Value int64
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *Int64Value) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *Int64Value) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Value != 0 {
+		b = wire.AppendTag(b, 1, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(m.Value))
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *Int64Value) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Value = int64(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type UInt64Value struct {
+	Value uint64
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *UInt64Value) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *UInt64Value) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Value != 0 {
+		b = wire.AppendTag(b, 1, wire.VarintType)
+		b = wire.AppendVarint(b, m.Value)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *UInt64Value) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Value = v
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type Int32Value struct {
+	Value int32
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *Int32Value) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *Int32Value) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Value != 0 {
+		b = wire.AppendTag(b, 1, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(m.Value))
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *Int32Value) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Value = int32(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type UInt32Value struct {
+	Value uint32
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *UInt32Value) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *UInt32Value) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Value != 0 {
+		b = wire.AppendTag(b, 1, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(m.Value))
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *UInt32Value) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Value = uint32(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type BoolValue struct {
+	Value bool
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *BoolValue) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *BoolValue) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Value {
+		b = wire.AppendTag(b, 1, wire.VarintType)
+		b = wire.AppendVarint(b, wire.EncodeBool(m.Value))
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *BoolValue) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Value = wire.DecodeBool(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type StringValue struct {
+	Value string
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *StringValue) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *StringValue) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Value != "" {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		b = wire.AppendString(b, m.Value)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *StringValue) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Value = string(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type BytesValue struct {
+	Value []byte
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *BytesValue) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *BytesValue) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if len(m.Value) > 0 {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		b = wire.AppendBytes(b, m.Value)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *BytesValue) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Value = append([]byte{}, v...)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package everythingpb_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Clement-Jean/protein/gogen/internal/everythingpb"
+	"github.com/Clement-Jean/protein/gogen/internal/pbtest"
+)
+
+func TestRoundTrip(t *testing.T) {
+	nested := &everythingpb.ABitOfEverything_Nested{Name: "n", Amount: 10, Ok: everythingpb.ABitOfEverything_Nested_TRUE}
+	abe := &everythingpb.ABitOfEverything{
+		SingleNested:                             nested,
+		Uuid:                                     "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		Nested:                                   []*everythingpb.ABitOfEverything_Nested{nested, {}},
+		FloatValue:                               1.5,
+		DoubleValue:                              math.MaxFloat64,
+		Int64Value:                               math.MinInt64,
+		Uint64Value:                              math.MaxUint64,
+		Int32Value:                               -1,
+		Fixed64Value:                             1,
+		Fixed32Value:                             2,
+		BoolValue:                                true,
+		StringValue:                              "s",
+		BytesValue:                               []byte{0, 1},
+		Uint32Value:                              math.MaxUint32,
+		EnumValue:                                everythingpb.NumericEnum_ONE,
+		PathEnumValue:                            everythingpb.PathEnum_DEF,
+		NestedPathEnumValue:                      everythingpb.MessagePathEnum_JKL,
+		Sfixed32Value:                            -3,
+		Sfixed64Value:                            -4,
+		Sint32Value:                              math.MinInt32,
+		Sint64Value:                              -5,
+		RepeatedStringValue:                      []string{"a", ""},
+		OneofValue:                               &everythingpb.ABitOfEverything_OneofString{OneofString: "o"},
+		MapValue:                                 map[string]everythingpb.NumericEnum{"one": everythingpb.NumericEnum_ONE, "": 0},
+		MappedStringValue:                        map[string]string{"a": "b"},
+		MappedNestedValue:                        map[string]*everythingpb.ABitOfEverything_Nested{"n": nested, "empty": {}},
+		NonConventionalNameValue:                 "x",
+		TimestampValue:                           &everythingpb.Timestamp{Seconds: 1700000000, Nanos: 1},
+		RepeatedEnumValue:                        []everythingpb.NumericEnum{everythingpb.NumericEnum_ONE, everythingpb.NumericEnum_ZERO},
+		RepeatedEnumAnnotation:                   []everythingpb.NumericEnum{everythingpb.NumericEnum_ONE},
+		EnumValueAnnotation:                      everythingpb.NumericEnum_ONE,
+		RepeatedStringAnnotation:                 []string{"r"},
+		RepeatedNestedAnnotation:                 []*everythingpb.ABitOfEverything_Nested{nested},
+		NestedAnnotation:                         nested,
+		Int64OverrideType:                        6,
+		RequiredStringViaFieldBehaviorAnnotation: "required",
+		OutputOnlyStringViaFieldBehaviorAnnotation: "output",
+		OptionalStringValue:                        pbtest.Ptr(""),
+	}
+
+	t.Run("a bit of everything", func(t *testing.T) {
+		pbtest.RoundTrip(t, abe)
+		pbtest.RoundTrip(t, &everythingpb.ABitOfEverything{})
+		pbtest.RoundTrip(t, &everythingpb.ABitOfEverything{OneofValue: &everythingpb.ABitOfEverything_OneofEmpty{OneofEmpty: &everythingpb.Empty{}}})
+	})
+	t.Run("repeated", func(t *testing.T) {
+		pbtest.RoundTrip(t, &everythingpb.ABitOfEverythingRepeated{
+			PathRepeatedFloatValue:    []float32{1.5, -1},
+			PathRepeatedDoubleValue:   []float64{math.Inf(1)},
+			PathRepeatedInt64Value:    []int64{math.MinInt64, 0},
+			PathRepeatedUint64Value:   []uint64{math.MaxUint64},
+			PathRepeatedInt32Value:    []int32{-1, 1},
+			PathRepeatedFixed64Value:  []uint64{2},
+			PathRepeatedFixed32Value:  []uint32{3},
+			PathRepeatedBoolValue:     []bool{true, false},
+			PathRepeatedStringValue:   []string{"a", ""},
+			PathRepeatedBytesValue:    [][]byte{{1}, {}},
+			PathRepeatedUint32Value:   []uint32{math.MaxUint32},
+			PathRepeatedEnumValue:     []everythingpb.NumericEnum{everythingpb.NumericEnum_ONE, 42},
+			PathRepeatedSfixed32Value: []int32{-4},
+			PathRepeatedSfixed64Value: []int64{-5},
+			PathRepeatedSint32Value:   []int32{math.MinInt32},
+			PathRepeatedSint64Value:   []int64{-6},
+		})
+	})
+	t.Run("requests and responses", func(t *testing.T) {
+		book := &everythingpb.Book{Name: "publishers/1/books/2", Id: "2", CreateTime: &everythingpb.Timestamp{Seconds: 1}}
+		mask := &everythingpb.FieldMask{Paths: []string{"name", "create_time"}}
+
+		pbtest.RoundTrip(t, &everythingpb.ErrorResponse{CorrelationId: "c", Error: &everythingpb.ErrorObject{Code: 404, Message: "not found"}})
+		pbtest.RoundTrip(t, &everythingpb.CheckStatusResponse{Status: &everythingpb.Status{
+			Code:    5,
+			Message: "m",
+			Details: []*everythingpb.Any{{TypeUrl: "type.googleapis.com/grpc.gateway.examples.internal.proto.examplepb.Book", Value: book.Marshal()}},
+		}})
+		pbtest.RoundTrip(t, &everythingpb.MessageWithBody{Id: "1", Data: &everythingpb.Body{Name: "b"}})
+		pbtest.RoundTrip(t, &everythingpb.UpdateV2Request{Abe: abe, UpdateMask: mask})
+		pbtest.RoundTrip(t, &everythingpb.CreateBookRequest{Parent: "publishers/1", Book: book, BookId: "2"})
+		pbtest.RoundTrip(t, &everythingpb.UpdateBookRequest{Book: book, UpdateMask: mask, AllowMissing: true})
+	})
+	t.Run("imports", func(t *testing.T) {
+		pbtest.RoundTrip(t, &everythingpb.MessageWithPathEnum{Value: everythingpb.PathEnum_DEF})
+		pbtest.RoundTrip(t, &everythingpb.MessageWithNestedPathEnum{Value: everythingpb.MessagePathEnum_JKL})
+		pbtest.RoundTrip(t, &everythingpb.StringMessage{Value: pbtest.Ptr("")})
+		pbtest.RoundTrip(t, &everythingpb.IdMessage{Uuid: "u"})
+	})
+}
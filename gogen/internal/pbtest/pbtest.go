@@ -0,0 +1,34 @@
+// Package pbtest contains the helpers shared by the tests of the
+// packages generated by gogen.
+package pbtest
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// Message is a generated message.
+type Message interface {
+	Marshal() []byte
+	Unmarshal(b []byte) error
+}
+
+// Ptr returns a pointer to v, for the fields with presence.
+func Ptr[T any](v T) *T { return &v }
+
+// RoundTrip checks that m is unchanged once encoded and decoded.
+func RoundTrip[T any, M interface {
+	*T
+	Message
+}](t *testing.T, m M) {
+	t.Helper()
+
+	got := M(new(T))
+	if err := got.Unmarshal(m.Marshal()); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(m, got); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+}
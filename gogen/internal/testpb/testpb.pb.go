@@ -0,0 +1,1363 @@
+// Code generated by protein. DO NOT EDIT.
+
+package testpb
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/Clement-Jean/protein/wire"
+)
+
+type Color int32
+
+const (
+	Color_COLOR_UNSPECIFIED Color = 0
+	Color_COLOR_RED         Color = 1
+	Color_COLOR_GREEN       Color = 2
+)
+
+func (x Color) String() string {
+	switch x {
+	case Color_COLOR_UNSPECIFIED:
+		return "COLOR_UNSPECIFIED"
+	case Color_COLOR_RED:
+		return "COLOR_RED"
+	case Color_COLOR_GREEN:
+		return "COLOR_GREEN"
+	}
+	return strconv.Itoa(int(x))
+}
+
+type Scalars struct {
+	Int32          int32
+	Int64          int64
+	Uint32         uint32
+	Uint64         uint64
+	Sint32         int32
+	Sint64         int64
+	Fixed32        uint32
+	Fixed64        uint64
+	Sfixed32       int32
+	Sfixed64       int64
+	Float          float32
+	Double         float64
+	Bool           bool
+	String         string
+	Bytes          []byte
+	Color          Color
+	OptionalInt32  *int32
+	OptionalString *string
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *Scalars) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *Scalars) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Int32 != 0 {
+		b = wire.AppendTag(b, 1, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(m.Int32))
+	}
+	if m.Int64 != 0 {
+		b = wire.AppendTag(b, 2, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(m.Int64))
+	}
+	if m.Uint32 != 0 {
+		b = wire.AppendTag(b, 3, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(m.Uint32))
+	}
+	if m.Uint64 != 0 {
+		b = wire.AppendTag(b, 4, wire.VarintType)
+		b = wire.AppendVarint(b, m.Uint64)
+	}
+	if m.Sint32 != 0 {
+		b = wire.AppendTag(b, 5, wire.VarintType)
+		b = wire.AppendVarint(b, wire.EncodeZigZag(int64(m.Sint32)))
+	}
+	if m.Sint64 != 0 {
+		b = wire.AppendTag(b, 6, wire.VarintType)
+		b = wire.AppendVarint(b, wire.EncodeZigZag(m.Sint64))
+	}
+	if m.Fixed32 != 0 {
+		b = wire.AppendTag(b, 7, wire.Fixed32Type)
+		b = wire.AppendFixed32(b, m.Fixed32)
+	}
+	if m.Fixed64 != 0 {
+		b = wire.AppendTag(b, 8, wire.Fixed64Type)
+		b = wire.AppendFixed64(b, m.Fixed64)
+	}
+	if m.Sfixed32 != 0 {
+		b = wire.AppendTag(b, 9, wire.Fixed32Type)
+		b = wire.AppendFixed32(b, uint32(m.Sfixed32))
+	}
+	if m.Sfixed64 != 0 {
+		b = wire.AppendTag(b, 10, wire.Fixed64Type)
+		b = wire.AppendFixed64(b, uint64(m.Sfixed64))
+	}
+	if m.Float != 0 {
+		b = wire.AppendTag(b, 11, wire.Fixed32Type)
+		b = wire.AppendFixed32(b, math.Float32bits(m.Float))
+	}
+	if m.Double != 0 {
+		b = wire.AppendTag(b, 12, wire.Fixed64Type)
+		b = wire.AppendFixed64(b, math.Float64bits(m.Double))
+	}
+	if m.Bool {
+		b = wire.AppendTag(b, 13, wire.VarintType)
+		b = wire.AppendVarint(b, wire.EncodeBool(m.Bool))
+	}
+	if m.String != "" {
+		b = wire.AppendTag(b, 14, wire.BytesType)
+		b = wire.AppendString(b, m.String)
+	}
+	if len(m.Bytes) > 0 {
+		b = wire.AppendTag(b, 15, wire.BytesType)
+		b = wire.AppendBytes(b, m.Bytes)
+	}
+	if m.Color != 0 {
+		b = wire.AppendTag(b, 16, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(m.Color))
+	}
+	if m.OptionalInt32 != nil {
+		b = wire.AppendTag(b, 17, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(*m.OptionalInt32))
+	}
+	if m.OptionalString != nil {
+		b = wire.AppendTag(b, 18, wire.BytesType)
+		b = wire.AppendString(b, *m.OptionalString)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *Scalars) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Int32 = int32(v)
+		case num == 2 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Int64 = int64(v)
+		case num == 3 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Uint32 = uint32(v)
+		case num == 4 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Uint64 = v
+		case num == 5 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Sint32 = int32(wire.DecodeZigZag(uint64(uint32(v))))
+		case num == 6 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Sint64 = wire.DecodeZigZag(v)
+		case num == 7 && typ == wire.Fixed32Type:
+			var v uint32
+			v, n = wire.ConsumeFixed32(b)
+			if n < 0 {
+				break
+			}
+			m.Fixed32 = v
+		case num == 8 && typ == wire.Fixed64Type:
+			var v uint64
+			v, n = wire.ConsumeFixed64(b)
+			if n < 0 {
+				break
+			}
+			m.Fixed64 = v
+		case num == 9 && typ == wire.Fixed32Type:
+			var v uint32
+			v, n = wire.ConsumeFixed32(b)
+			if n < 0 {
+				break
+			}
+			m.Sfixed32 = int32(v)
+		case num == 10 && typ == wire.Fixed64Type:
+			var v uint64
+			v, n = wire.ConsumeFixed64(b)
+			if n < 0 {
+				break
+			}
+			m.Sfixed64 = int64(v)
+		case num == 11 && typ == wire.Fixed32Type:
+			var v uint32
+			v, n = wire.ConsumeFixed32(b)
+			if n < 0 {
+				break
+			}
+			m.Float = math.Float32frombits(v)
+		case num == 12 && typ == wire.Fixed64Type:
+			var v uint64
+			v, n = wire.ConsumeFixed64(b)
+			if n < 0 {
+				break
+			}
+			m.Double = math.Float64frombits(v)
+		case num == 13 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Bool = wire.DecodeBool(v)
+		case num == 14 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.String = string(v)
+		case num == 15 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Bytes = append([]byte{}, v...)
+		case num == 16 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Color = Color(v)
+		case num == 17 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.OptionalInt32 = new(int32)
+			*m.OptionalInt32 = int32(v)
+		case num == 18 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.OptionalString = new(string)
+			*m.OptionalString = string(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type Repeated struct {
+	Int32   []int32
+	Sint64  []int64
+	Fixed32 []uint32
+	Double  []float64
+	Bool    []bool
+	String  []string
+	Bytes   [][]byte
+	Color   []Color
+	Scalars []*Scalars
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *Repeated) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *Repeated) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if len(m.Int32) > 0 {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		start := len(b)
+		for _, x := range m.Int32 {
+			b = wire.AppendVarint(b, uint64(x))
+		}
+		b = wire.InsertLength(b, start)
+	}
+	if len(m.Sint64) > 0 {
+		b = wire.AppendTag(b, 2, wire.BytesType)
+		start := len(b)
+		for _, x := range m.Sint64 {
+			b = wire.AppendVarint(b, wire.EncodeZigZag(x))
+		}
+		b = wire.InsertLength(b, start)
+	}
+	if len(m.Fixed32) > 0 {
+		b = wire.AppendTag(b, 3, wire.BytesType)
+		start := len(b)
+		for _, x := range m.Fixed32 {
+			b = wire.AppendFixed32(b, x)
+		}
+		b = wire.InsertLength(b, start)
+	}
+	if len(m.Double) > 0 {
+		b = wire.AppendTag(b, 4, wire.BytesType)
+		start := len(b)
+		for _, x := range m.Double {
+			b = wire.AppendFixed64(b, math.Float64bits(x))
+		}
+		b = wire.InsertLength(b, start)
+	}
+	if len(m.Bool) > 0 {
+		b = wire.AppendTag(b, 5, wire.BytesType)
+		start := len(b)
+		for _, x := range m.Bool {
+			b = wire.AppendVarint(b, wire.EncodeBool(x))
+		}
+		b = wire.InsertLength(b, start)
+	}
+	for _, x := range m.String {
+		b = wire.AppendTag(b, 6, wire.BytesType)
+		b = wire.AppendString(b, x)
+	}
+	for _, x := range m.Bytes {
+		b = wire.AppendTag(b, 7, wire.BytesType)
+		b = wire.AppendBytes(b, x)
+	}
+	if len(m.Color) > 0 {
+		b = wire.AppendTag(b, 8, wire.BytesType)
+		start := len(b)
+		for _, x := range m.Color {
+			b = wire.AppendVarint(b, uint64(x))
+		}
+		b = wire.InsertLength(b, start)
+	}
+	for _, x := range m.Scalars {
+		b = wire.AppendTag(b, 9, wire.BytesType)
+		b = wire.AppendMessage(b, x)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *Repeated) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			for len(v) > 0 {
+				var k int
+				var x uint64
+				x, k = wire.ConsumeVarint(v)
+				if k < 0 {
+					return wire.ParseError(k)
+				}
+				m.Int32 = append(m.Int32, int32(x))
+				v = v[k:]
+			}
+		case num == 1 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Int32 = append(m.Int32, int32(v))
+		case num == 2 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			for len(v) > 0 {
+				var k int
+				var x uint64
+				x, k = wire.ConsumeVarint(v)
+				if k < 0 {
+					return wire.ParseError(k)
+				}
+				m.Sint64 = append(m.Sint64, wire.DecodeZigZag(x))
+				v = v[k:]
+			}
+		case num == 2 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Sint64 = append(m.Sint64, wire.DecodeZigZag(v))
+		case num == 3 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			for len(v) > 0 {
+				var k int
+				var x uint32
+				x, k = wire.ConsumeFixed32(v)
+				if k < 0 {
+					return wire.ParseError(k)
+				}
+				m.Fixed32 = append(m.Fixed32, x)
+				v = v[k:]
+			}
+		case num == 3 && typ == wire.Fixed32Type:
+			var v uint32
+			v, n = wire.ConsumeFixed32(b)
+			if n < 0 {
+				break
+			}
+			m.Fixed32 = append(m.Fixed32, v)
+		case num == 4 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			for len(v) > 0 {
+				var k int
+				var x uint64
+				x, k = wire.ConsumeFixed64(v)
+				if k < 0 {
+					return wire.ParseError(k)
+				}
+				m.Double = append(m.Double, math.Float64frombits(x))
+				v = v[k:]
+			}
+		case num == 4 && typ == wire.Fixed64Type:
+			var v uint64
+			v, n = wire.ConsumeFixed64(b)
+			if n < 0 {
+				break
+			}
+			m.Double = append(m.Double, math.Float64frombits(v))
+		case num == 5 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			for len(v) > 0 {
+				var k int
+				var x uint64
+				x, k = wire.ConsumeVarint(v)
+				if k < 0 {
+					return wire.ParseError(k)
+				}
+				m.Bool = append(m.Bool, wire.DecodeBool(x))
+				v = v[k:]
+			}
+		case num == 5 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Bool = append(m.Bool, wire.DecodeBool(v))
+		case num == 6 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.String = append(m.String, string(v))
+		case num == 7 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Bytes = append(m.Bytes, append([]byte{}, v...))
+		case num == 8 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			for len(v) > 0 {
+				var k int
+				var x uint64
+				x, k = wire.ConsumeVarint(v)
+				if k < 0 {
+					return wire.ParseError(k)
+				}
+				m.Color = append(m.Color, Color(x))
+				v = v[k:]
+			}
+		case num == 8 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Color = append(m.Color, Color(v))
+		case num == 9 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			x := new(Scalars)
+			if err := x.Unmarshal(v); err != nil {
+				return err
+			}
+			m.Scalars = append(m.Scalars, x)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type Maps struct {
+	StringString  map[string]string
+	Int32Message  map[int32]*Scalars
+	Sint64Enum    map[int64]Color
+	BoolBytes     map[bool][]byte
+	Fixed64Double map[uint64]float64
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *Maps) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *Maps) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	for k, v := range m.StringString {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		start := len(b)
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		b = wire.AppendString(b, k)
+		b = wire.AppendTag(b, 2, wire.BytesType)
+		b = wire.AppendString(b, v)
+		b = wire.InsertLength(b, start)
+	}
+	for k, v := range m.Int32Message {
+		b = wire.AppendTag(b, 2, wire.BytesType)
+		start := len(b)
+		b = wire.AppendTag(b, 1, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(k))
+		b = wire.AppendTag(b, 2, wire.BytesType)
+		b = wire.AppendMessage(b, v)
+		b = wire.InsertLength(b, start)
+	}
+	for k, v := range m.Sint64Enum {
+		b = wire.AppendTag(b, 3, wire.BytesType)
+		start := len(b)
+		b = wire.AppendTag(b, 1, wire.VarintType)
+		b = wire.AppendVarint(b, wire.EncodeZigZag(k))
+		b = wire.AppendTag(b, 2, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(v))
+		b = wire.InsertLength(b, start)
+	}
+	for k, v := range m.BoolBytes {
+		b = wire.AppendTag(b, 4, wire.BytesType)
+		start := len(b)
+		b = wire.AppendTag(b, 1, wire.VarintType)
+		b = wire.AppendVarint(b, wire.EncodeBool(k))
+		b = wire.AppendTag(b, 2, wire.BytesType)
+		b = wire.AppendBytes(b, v)
+		b = wire.InsertLength(b, start)
+	}
+	for k, v := range m.Fixed64Double {
+		b = wire.AppendTag(b, 5, wire.BytesType)
+		start := len(b)
+		b = wire.AppendTag(b, 1, wire.Fixed64Type)
+		b = wire.AppendFixed64(b, k)
+		b = wire.AppendTag(b, 2, wire.Fixed64Type)
+		b = wire.AppendFixed64(b, math.Float64bits(v))
+		b = wire.InsertLength(b, start)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *Maps) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			var key string
+			var val string
+			for len(v) > 0 {
+				num, typ, k := wire.ConsumeTag(v)
+				if k < 0 {
+					return wire.ParseError(k)
+				}
+				v = v[k:]
+
+				switch {
+				case num == 1 && typ == wire.BytesType:
+					var x []byte
+					x, k = wire.ConsumeBytes(v)
+					if k < 0 {
+						break
+					}
+					key = string(x)
+				case num == 2 && typ == wire.BytesType:
+					var x []byte
+					x, k = wire.ConsumeBytes(v)
+					if k < 0 {
+						break
+					}
+					val = string(x)
+				default:
+					k = wire.ConsumeFieldValue(num, typ, v)
+				}
+				if k < 0 {
+					return wire.ParseError(k)
+				}
+				v = v[k:]
+			}
+			if m.StringString == nil {
+				m.StringString = make(map[string]string)
+			}
+			m.StringString[key] = val
+		case num == 2 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			var key int32
+			val := new(Scalars)
+			for len(v) > 0 {
+				num, typ, k := wire.ConsumeTag(v)
+				if k < 0 {
+					return wire.ParseError(k)
+				}
+				v = v[k:]
+
+				switch {
+				case num == 1 && typ == wire.VarintType:
+					var x uint64
+					x, k = wire.ConsumeVarint(v)
+					if k < 0 {
+						break
+					}
+					key = int32(x)
+				case num == 2 && typ == wire.BytesType:
+					var x []byte
+					x, k = wire.ConsumeBytes(v)
+					if k < 0 {
+						break
+					}
+					y := new(Scalars)
+					if err := y.Unmarshal(x); err != nil {
+						return err
+					}
+					val = y
+				default:
+					k = wire.ConsumeFieldValue(num, typ, v)
+				}
+				if k < 0 {
+					return wire.ParseError(k)
+				}
+				v = v[k:]
+			}
+			if m.Int32Message == nil {
+				m.Int32Message = make(map[int32]*Scalars)
+			}
+			m.Int32Message[key] = val
+		case num == 3 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			var key int64
+			var val Color
+			for len(v) > 0 {
+				num, typ, k := wire.ConsumeTag(v)
+				if k < 0 {
+					return wire.ParseError(k)
+				}
+				v = v[k:]
+
+				switch {
+				case num == 1 && typ == wire.VarintType:
+					var x uint64
+					x, k = wire.ConsumeVarint(v)
+					if k < 0 {
+						break
+					}
+					key = wire.DecodeZigZag(x)
+				case num == 2 && typ == wire.VarintType:
+					var x uint64
+					x, k = wire.ConsumeVarint(v)
+					if k < 0 {
+						break
+					}
+					val = Color(x)
+				default:
+					k = wire.ConsumeFieldValue(num, typ, v)
+				}
+				if k < 0 {
+					return wire.ParseError(k)
+				}
+				v = v[k:]
+			}
+			if m.Sint64Enum == nil {
+				m.Sint64Enum = make(map[int64]Color)
+			}
+			m.Sint64Enum[key] = val
+		case num == 4 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			var key bool
+			var val []byte
+			for len(v) > 0 {
+				num, typ, k := wire.ConsumeTag(v)
+				if k < 0 {
+					return wire.ParseError(k)
+				}
+				v = v[k:]
+
+				switch {
+				case num == 1 && typ == wire.VarintType:
+					var x uint64
+					x, k = wire.ConsumeVarint(v)
+					if k < 0 {
+						break
+					}
+					key = wire.DecodeBool(x)
+				case num == 2 && typ == wire.BytesType:
+					var x []byte
+					x, k = wire.ConsumeBytes(v)
+					if k < 0 {
+						break
+					}
+					val = append([]byte{}, x...)
+				default:
+					k = wire.ConsumeFieldValue(num, typ, v)
+				}
+				if k < 0 {
+					return wire.ParseError(k)
+				}
+				v = v[k:]
+			}
+			if m.BoolBytes == nil {
+				m.BoolBytes = make(map[bool][]byte)
+			}
+			m.BoolBytes[key] = val
+		case num == 5 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			var key uint64
+			var val float64
+			for len(v) > 0 {
+				num, typ, k := wire.ConsumeTag(v)
+				if k < 0 {
+					return wire.ParseError(k)
+				}
+				v = v[k:]
+
+				switch {
+				case num == 1 && typ == wire.Fixed64Type:
+					var x uint64
+					x, k = wire.ConsumeFixed64(v)
+					if k < 0 {
+						break
+					}
+					key = x
+				case num == 2 && typ == wire.Fixed64Type:
+					var x uint64
+					x, k = wire.ConsumeFixed64(v)
+					if k < 0 {
+						break
+					}
+					val = math.Float64frombits(x)
+				default:
+					k = wire.ConsumeFieldValue(num, typ, v)
+				}
+				if k < 0 {
+					return wire.ParseError(k)
+				}
+				v = v[k:]
+			}
+			if m.Fixed64Double == nil {
+				m.Fixed64Double = make(map[uint64]float64)
+			}
+			m.Fixed64Double[key] = val
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type Oneofs struct {
+	Choice isOneofs_Choice
+	After  string
+
+	XXX_unrecognized []byte
+}
+
+type isOneofs_Choice interface {
+	isOneofs_Choice()
+}
+
+type Oneofs_Number struct {
+	Number int32
+}
+
+type Oneofs_Text struct {
+	Text string
+}
+
+type Oneofs_Scalars struct {
+	Scalars *Scalars
+}
+
+type Oneofs_Color struct {
+	Color Color
+}
+
+func (*Oneofs_Number) isOneofs_Choice()  {}
+func (*Oneofs_Text) isOneofs_Choice()    {}
+func (*Oneofs_Scalars) isOneofs_Choice() {}
+func (*Oneofs_Color) isOneofs_Choice()   {}
+
+// Marshal returns the wire format encoding of m.
+func (m *Oneofs) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *Oneofs) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	switch x := m.Choice.(type) {
+	case *Oneofs_Number:
+		b = wire.AppendTag(b, 1, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(x.Number))
+	case *Oneofs_Text:
+		b = wire.AppendTag(b, 2, wire.BytesType)
+		b = wire.AppendString(b, x.Text)
+	case *Oneofs_Scalars:
+		b = wire.AppendTag(b, 3, wire.BytesType)
+		b = wire.AppendMessage(b, x.Scalars)
+	case *Oneofs_Color:
+		b = wire.AppendTag(b, 4, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(x.Color))
+	}
+	if m.After != "" {
+		b = wire.AppendTag(b, 5, wire.BytesType)
+		b = wire.AppendString(b, m.After)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *Oneofs) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Choice = &Oneofs_Number{Number: int32(v)}
+		case num == 2 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Choice = &Oneofs_Text{Text: string(v)}
+		case num == 3 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			x := new(Scalars)
+			if err := x.Unmarshal(v); err != nil {
+				return err
+			}
+			m.Choice = &Oneofs_Scalars{Scalars: x}
+		case num == 4 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Choice = &Oneofs_Color{Color: Color(v)}
+		case num == 5 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.After = string(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type Outer struct {
+	Inner  *Outer_Inner
+	Inners []*Outer_Inner
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *Outer) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *Outer) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Inner != nil {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		b = wire.AppendMessage(b, m.Inner)
+	}
+	for _, x := range m.Inners {
+		b = wire.AppendTag(b, 2, wire.BytesType)
+		b = wire.AppendMessage(b, x)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *Outer) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			if m.Inner == nil {
+				m.Inner = new(Outer_Inner)
+			}
+			if err := m.Inner.Unmarshal(v); err != nil {
+				return err
+			}
+		case num == 2 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			x := new(Outer_Inner)
+			if err := x.Unmarshal(v); err != nil {
+				return err
+			}
+			m.Inners = append(m.Inners, x)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type Outer_Inner struct {
+	Kind  Outer_Inner_Kind
+	Child *Outer_Inner
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *Outer_Inner) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *Outer_Inner) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Kind != 0 {
+		b = wire.AppendTag(b, 1, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(m.Kind))
+	}
+	if m.Child != nil {
+		b = wire.AppendTag(b, 2, wire.BytesType)
+		b = wire.AppendMessage(b, m.Child)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *Outer_Inner) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Kind = Outer_Inner_Kind(v)
+		case num == 2 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			if m.Child == nil {
+				m.Child = new(Outer_Inner)
+			}
+			if err := m.Child.Unmarshal(v); err != nil {
+				return err
+			}
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type Outer_Inner_Kind int32
+
+const (
+	Outer_Inner_KIND_UNSPECIFIED Outer_Inner_Kind = 0
+	Outer_Inner_KIND_LEAF        Outer_Inner_Kind = 1
+)
+
+func (x Outer_Inner_Kind) String() string {
+	switch x {
+	case Outer_Inner_KIND_UNSPECIFIED:
+		return "KIND_UNSPECIFIED"
+	case Outer_Inner_KIND_LEAF:
+		return "KIND_LEAF"
+	}
+	return strconv.Itoa(int(x))
+}
+
+type Legacy struct {
+	Int32    *int32
+	String   *string
+	Bytes    []byte
+	Level    *Legacy_Level
+	Required *bool
+	Expanded []int32
+	Packed   []int32
+	Outer    *Outer
+	Color    *Color
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *Legacy) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *Legacy) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Int32 != nil {
+		b = wire.AppendTag(b, 1, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(*m.Int32))
+	}
+	if m.String != nil {
+		b = wire.AppendTag(b, 2, wire.BytesType)
+		b = wire.AppendString(b, *m.String)
+	}
+	if m.Bytes != nil {
+		b = wire.AppendTag(b, 3, wire.BytesType)
+		b = wire.AppendBytes(b, m.Bytes)
+	}
+	if m.Level != nil {
+		b = wire.AppendTag(b, 4, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(*m.Level))
+	}
+	if m.Required != nil {
+		b = wire.AppendTag(b, 5, wire.VarintType)
+		b = wire.AppendVarint(b, wire.EncodeBool(*m.Required))
+	}
+	for _, x := range m.Expanded {
+		b = wire.AppendTag(b, 6, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(x))
+	}
+	if len(m.Packed) > 0 {
+		b = wire.AppendTag(b, 7, wire.BytesType)
+		start := len(b)
+		for _, x := range m.Packed {
+			b = wire.AppendVarint(b, uint64(x))
+		}
+		b = wire.InsertLength(b, start)
+	}
+	if m.Outer != nil {
+		b = wire.AppendTag(b, 8, wire.BytesType)
+		b = wire.AppendMessage(b, m.Outer)
+	}
+	if m.Color != nil {
+		b = wire.AppendTag(b, 9, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(*m.Color))
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *Legacy) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Int32 = new(int32)
+			*m.Int32 = int32(v)
+		case num == 2 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.String = new(string)
+			*m.String = string(v)
+		case num == 3 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Bytes = append([]byte{}, v...)
+		case num == 4 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Level = new(Legacy_Level)
+			*m.Level = Legacy_Level(v)
+		case num == 5 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Required = new(bool)
+			*m.Required = wire.DecodeBool(v)
+		case num == 6 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			for len(v) > 0 {
+				var k int
+				var x uint64
+				x, k = wire.ConsumeVarint(v)
+				if k < 0 {
+					return wire.ParseError(k)
+				}
+				m.Expanded = append(m.Expanded, int32(x))
+				v = v[k:]
+			}
+		case num == 6 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Expanded = append(m.Expanded, int32(v))
+		case num == 7 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			for len(v) > 0 {
+				var k int
+				var x uint64
+				x, k = wire.ConsumeVarint(v)
+				if k < 0 {
+					return wire.ParseError(k)
+				}
+				m.Packed = append(m.Packed, int32(x))
+				v = v[k:]
+			}
+		case num == 7 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Packed = append(m.Packed, int32(v))
+		case num == 8 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			if m.Outer == nil {
+				m.Outer = new(Outer)
+			}
+			if err := m.Outer.Unmarshal(v); err != nil {
+				return err
+			}
+		case num == 9 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Color = new(Color)
+			*m.Color = Color(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type Legacy_Level int32
+
+const (
+	Legacy_LEVEL_LOW  Legacy_Level = -1
+	Legacy_LEVEL_HIGH Legacy_Level = 1
+	Legacy_LEVEL_MAX  Legacy_Level = 1
+)
+
+func (x Legacy_Level) String() string {
+	switch x {
+	case Legacy_LEVEL_LOW:
+		return "LEVEL_LOW"
+	case Legacy_LEVEL_HIGH:
+		return "LEVEL_HIGH"
+	}
+	return strconv.Itoa(int(x))
+}
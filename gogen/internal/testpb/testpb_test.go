@@ -0,0 +1,208 @@
+package testpb_test
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/Clement-Jean/protein/gogen/internal/pbtest"
+	"github.com/Clement-Jean/protein/gogen/internal/testpb"
+	"github.com/Clement-Jean/protein/wire"
+)
+
+func TestRoundTrip(t *testing.T) {
+	scalars := &testpb.Scalars{
+		Int32:          math.MinInt32,
+		Int64:          math.MinInt64,
+		Uint32:         math.MaxUint32,
+		Uint64:         math.MaxUint64,
+		Sint32:         -2,
+		Sint64:         math.MinInt64,
+		Fixed32:        7,
+		Fixed64:        8,
+		Sfixed32:       -9,
+		Sfixed64:       -10,
+		Float:          1.5,
+		Double:         math.Inf(-1),
+		Bool:           true,
+		String:         "héllo",
+		Bytes:          []byte{0, 1, 2},
+		Color:          testpb.Color_COLOR_GREEN,
+		OptionalInt32:  pbtest.Ptr[int32](0),
+		OptionalString: pbtest.Ptr(""),
+	}
+
+	t.Run("scalars", func(t *testing.T) {
+		pbtest.RoundTrip(t, scalars)
+		pbtest.RoundTrip(t, &testpb.Scalars{})
+	})
+	t.Run("repeated", func(t *testing.T) {
+		pbtest.RoundTrip(t, &testpb.Repeated{
+			Int32:   []int32{1, -1, 0},
+			Sint64:  []int64{-1, 1},
+			Fixed32: []uint32{1, 2},
+			Double:  []float64{0.5, -0.5},
+			Bool:    []bool{true, false},
+			String:  []string{"a", ""},
+			Bytes:   [][]byte{{1}, {}},
+			Color:   []testpb.Color{testpb.Color_COLOR_RED, 42},
+			Scalars: []*testpb.Scalars{scalars, {}},
+		})
+	})
+	t.Run("maps", func(t *testing.T) {
+		pbtest.RoundTrip(t, &testpb.Maps{
+			StringString:  map[string]string{"a": "b", "": ""},
+			Int32Message:  map[int32]*testpb.Scalars{-1: scalars, 0: {}},
+			Sint64Enum:    map[int64]testpb.Color{-5: testpb.Color_COLOR_RED},
+			BoolBytes:     map[bool][]byte{true: {1}, false: {}},
+			Fixed64Double: map[uint64]float64{1: 1.5},
+		})
+	})
+	t.Run("oneofs", func(t *testing.T) {
+		pbtest.RoundTrip(t, &testpb.Oneofs{Choice: &testpb.Oneofs_Number{Number: 0}})
+		pbtest.RoundTrip(t, &testpb.Oneofs{Choice: &testpb.Oneofs_Text{Text: "a"}, After: "b"})
+		pbtest.RoundTrip(t, &testpb.Oneofs{Choice: &testpb.Oneofs_Scalars{Scalars: scalars}})
+		pbtest.RoundTrip(t, &testpb.Oneofs{Choice: &testpb.Oneofs_Color{Color: testpb.Color_COLOR_RED}})
+	})
+	t.Run("nested", func(t *testing.T) {
+		inner := &testpb.Outer_Inner{
+			Kind:  testpb.Outer_Inner_KIND_LEAF,
+			Child: &testpb.Outer_Inner{Child: &testpb.Outer_Inner{}},
+		}
+		pbtest.RoundTrip(t, &testpb.Outer{Inner: inner, Inners: []*testpb.Outer_Inner{inner, {}}})
+	})
+	t.Run("proto2", func(t *testing.T) {
+		pbtest.RoundTrip(t, &testpb.Legacy{
+			Int32:    pbtest.Ptr[int32](0),
+			String:   pbtest.Ptr(""),
+			Bytes:    []byte{},
+			Level:    pbtest.Ptr(testpb.Legacy_LEVEL_LOW),
+			Required: pbtest.Ptr(false),
+			Expanded: []int32{1, 2},
+			Packed:   []int32{3, 4},
+			Outer:    &testpb.Outer{},
+			Color:    pbtest.Ptr(testpb.Color_COLOR_UNSPECIFIED),
+		})
+		pbtest.RoundTrip(t, &testpb.Legacy{})
+	})
+}
+
+func TestEncoding(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  pbtest.Message
+		expected []byte
+	}{
+		{"negative int32", &testpb.Scalars{Int32: -1}, []byte{0x08, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01}},
+		{"sint32", &testpb.Scalars{Sint32: -1}, []byte{0x28, 0x01}},
+		{"string", &testpb.Scalars{String: "hi"}, []byte{0x72, 0x02, 'h', 'i'}},
+		{"proto3 packed", &testpb.Repeated{Int32: []int32{1, 150}}, []byte{0x0a, 0x03, 0x01, 0x96, 0x01}},
+		{"proto2 expanded", &testpb.Legacy{Expanded: []int32{1, 2}}, []byte{0x30, 0x01, 0x30, 0x02}},
+		{"proto2 packed", &testpb.Legacy{Packed: []int32{1, 2}}, []byte{0x3a, 0x02, 0x01, 0x02}},
+		{"proto2 presence", &testpb.Legacy{Int32: pbtest.Ptr[int32](0)}, []byte{0x08, 0x00}},
+		{"oneof", &testpb.Oneofs{Choice: &testpb.Oneofs_Number{Number: 0}}, []byte{0x08, 0x00}},
+		{"nested", &testpb.Outer{Inner: &testpb.Outer_Inner{Kind: 1}}, []byte{0x0a, 0x02, 0x08, 0x01}},
+		{"map", &testpb.Maps{Sint64Enum: map[int64]testpb.Color{-1: 2}}, []byte{0x1a, 0x04, 0x08, 0x01, 0x10, 0x02}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if diff := cmp.Diff(test.expected, test.message.Marshal()); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDecoding(t *testing.T) {
+	t.Run("packed and expanded", func(t *testing.T) {
+		// the encodings are swapped, decoders accept both
+		var got testpb.Legacy
+		if err := got.Unmarshal([]byte{0x32, 0x02, 0x01, 0x02, 0x38, 0x03}); err != nil {
+			t.Fatal(err)
+		}
+		expected := &testpb.Legacy{Expanded: []int32{1, 2}, Packed: []int32{3}}
+		if diff := cmp.Diff(expected, &got); diff != "" {
+			t.Errorf("(-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("unknown fields", func(t *testing.T) {
+		var unknown []byte
+		unknown = wire.AppendTag(unknown, 100, wire.BytesType)
+		unknown = wire.AppendString(unknown, "kept")
+		unknown = wire.AppendTag(unknown, 1, wire.Fixed32Type) // wrong wire type
+		unknown = wire.AppendFixed32(unknown, 1)
+
+		known := wire.AppendTag(nil, 2, wire.VarintType)
+		known = wire.AppendVarint(known, 3)
+
+		var got testpb.Scalars
+		if err := got.Unmarshal(append(slices.Clone(unknown), known...)); err != nil {
+			t.Fatal(err)
+		}
+		expected := &testpb.Scalars{Int64: 3, XXX_unrecognized: unknown}
+		if diff := cmp.Diff(expected, &got); diff != "" {
+			t.Errorf("(-want +got):\n%s", diff)
+		}
+
+		// they are encoded after the known fields
+		if diff := cmp.Diff(append(known, unknown...), got.Marshal()); diff != "" {
+			t.Errorf("(-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("merge", func(t *testing.T) {
+		a := (&testpb.Outer{Inner: &testpb.Outer_Inner{Kind: 1}}).Marshal()
+		b := (&testpb.Outer{Inner: &testpb.Outer_Inner{Child: &testpb.Outer_Inner{}}}).Marshal()
+
+		var got testpb.Outer
+		if err := got.Unmarshal(append(a, b...)); err != nil {
+			t.Fatal(err)
+		}
+		expected := &testpb.Outer{Inner: &testpb.Outer_Inner{Kind: 1, Child: &testpb.Outer_Inner{}}}
+		if diff := cmp.Diff(expected, &got); diff != "" {
+			t.Errorf("(-want +got):\n%s", diff)
+		}
+	})
+
+	errs := []struct {
+		name     string
+		input    []byte
+		expected error
+	}{
+		{"truncated tag", []byte{0x80}, wire.ErrTruncated},
+		{"truncated value", []byte{0x72, 0x05, 'a'}, wire.ErrTruncated},
+		{"truncated packed", []byte{0x0a, 0x01, 0x80}, wire.ErrTruncated},
+		{"end group", []byte{0x0c}, wire.ErrEndGroup},
+	}
+	for _, test := range errs {
+		t.Run(test.name, func(t *testing.T) {
+			var m testpb.Repeated
+			if err := m.Unmarshal(test.input); err != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, err)
+			}
+		})
+	}
+}
+
+func TestEnumString(t *testing.T) {
+	tests := []struct {
+		value    interface{ String() string }
+		expected string
+	}{
+		{testpb.Color_COLOR_RED, "COLOR_RED"},
+		{testpb.Color(42), "42"},
+		{testpb.Outer_Inner_KIND_LEAF, "KIND_LEAF"},
+		{testpb.Legacy_LEVEL_LOW, "LEVEL_LOW"},
+		{testpb.Legacy_LEVEL_MAX, "LEVEL_HIGH"}, // alias
+	}
+
+	for _, test := range tests {
+		if got := test.value.String(); got != test.expected {
+			t.Errorf("expected %q, got %q", test.expected, got)
+		}
+	}
+}
@@ -0,0 +1,6458 @@
+// Code generated by protein. DO NOT EDIT.
+
+package wktpb
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/Clement-Jean/protein/wire"
+)
+
+type Any struct {
+	TypeUrl string
+	Value   []byte
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *Any) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *Any) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.TypeUrl != "" {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		b = wire.AppendString(b, m.TypeUrl)
+	}
+	if len(m.Value) > 0 {
+		b = wire.AppendTag(b, 2, wire.BytesType)
+		b = wire.AppendBytes(b, m.Value)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *Any) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.TypeUrl = string(v)
+		case num == 2 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Value = append([]byte{}, v...)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type Api struct {
+	Name          string
+	Methods       []*Method
+	Options       []*Option
+	Version       string
+	SourceContext *SourceContext
+	Mixins        []*Mixin
+	Syntax        Syntax
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *Api) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *Api) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Name != "" {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		b = wire.AppendString(b, m.Name)
+	}
+	for _, x := range m.Methods {
+		b = wire.AppendTag(b, 2, wire.BytesType)
+		b = wire.AppendMessage(b, x)
+	}
+	for _, x := range m.Options {
+		b = wire.AppendTag(b, 3, wire.BytesType)
+		b = wire.AppendMessage(b, x)
+	}
+	if m.Version != "" {
+		b = wire.AppendTag(b, 4, wire.BytesType)
+		b = wire.AppendString(b, m.Version)
+	}
+	if m.SourceContext != nil {
+		b = wire.AppendTag(b, 5, wire.BytesType)
+		b = wire.AppendMessage(b, m.SourceContext)
+	}
+	for _, x := range m.Mixins {
+		b = wire.AppendTag(b, 6, wire.BytesType)
+		b = wire.AppendMessage(b, x)
+	}
+	if m.Syntax != 0 {
+		b = wire.AppendTag(b, 7, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(m.Syntax))
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *Api) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Name = string(v)
+		case num == 2 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			x := new(Method)
+			if err := x.Unmarshal(v); err != nil {
+				return err
+			}
+			m.Methods = append(m.Methods, x)
+		case num == 3 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			x := new(Option)
+			if err := x.Unmarshal(v); err != nil {
+				return err
+			}
+			m.Options = append(m.Options, x)
+		case num == 4 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Version = string(v)
+		case num == 5 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			if m.SourceContext == nil {
+				m.SourceContext = new(SourceContext)
+			}
+			if err := m.SourceContext.Unmarshal(v); err != nil {
+				return err
+			}
+		case num == 6 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			x := new(Mixin)
+			if err := x.Unmarshal(v); err != nil {
+				return err
+			}
+			m.Mixins = append(m.Mixins, x)
+		case num == 7 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Syntax = Syntax(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type Method struct {
+	Name              string
+	RequestTypeUrl    string
+	RequestStreaming  bool
+	ResponseTypeUrl   string
+	ResponseStreaming bool
+	Options           []*Option
+	Syntax            Syntax
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *Method) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *Method) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Name != "" {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		b = wire.AppendString(b, m.Name)
+	}
+	if m.RequestTypeUrl != "" {
+		b = wire.AppendTag(b, 2, wire.BytesType)
+		b = wire.AppendString(b, m.RequestTypeUrl)
+	}
+	if m.RequestStreaming {
+		b = wire.AppendTag(b, 3, wire.VarintType)
+		b = wire.AppendVarint(b, wire.EncodeBool(m.RequestStreaming))
+	}
+	if m.ResponseTypeUrl != "" {
+		b = wire.AppendTag(b, 4, wire.BytesType)
+		b = wire.AppendString(b, m.ResponseTypeUrl)
+	}
+	if m.ResponseStreaming {
+		b = wire.AppendTag(b, 5, wire.VarintType)
+		b = wire.AppendVarint(b, wire.EncodeBool(m.ResponseStreaming))
+	}
+	for _, x := range m.Options {
+		b = wire.AppendTag(b, 6, wire.BytesType)
+		b = wire.AppendMessage(b, x)
+	}
+	if m.Syntax != 0 {
+		b = wire.AppendTag(b, 7, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(m.Syntax))
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *Method) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Name = string(v)
+		case num == 2 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.RequestTypeUrl = string(v)
+		case num == 3 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.RequestStreaming = wire.DecodeBool(v)
+		case num == 4 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.ResponseTypeUrl = string(v)
+		case num == 5 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.ResponseStreaming = wire.DecodeBool(v)
+		case num == 6 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			x := new(Option)
+			if err := x.Unmarshal(v); err != nil {
+				return err
+			}
+			m.Options = append(m.Options, x)
+		case num == 7 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Syntax = Syntax(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type Mixin struct {
+	Name string
+	Root string
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *Mixin) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *Mixin) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Name != "" {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		b = wire.AppendString(b, m.Name)
+	}
+	if m.Root != "" {
+		b = wire.AppendTag(b, 2, wire.BytesType)
+		b = wire.AppendString(b, m.Root)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *Mixin) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Name = string(v)
+		case num == 2 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Root = string(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type Edition int32
+
+const (
+	Edition_EDITION_UNKNOWN         Edition = 0
+	Edition_EDITION_PROTO2          Edition = 998
+	Edition_EDITION_PROTO3          Edition = 999
+	Edition_EDITION_2023            Edition = 1000
+	Edition_EDITION_1_TEST_ONLY     Edition = 1
+	Edition_EDITION_2_TEST_ONLY     Edition = 2
+	Edition_EDITION_99997_TEST_ONLY Edition = 99997
+	Edition_EDITION_99998_TEST_ONLY Edition = 99998
+	Edition_EDITION_99999_TEST_ONLY Edition = 99999
+)
+
+func (x Edition) String() string {
+	switch x {
+	case Edition_EDITION_UNKNOWN:
+		return "EDITION_UNKNOWN"
+	case Edition_EDITION_PROTO2:
+		return "EDITION_PROTO2"
+	case Edition_EDITION_PROTO3:
+		return "EDITION_PROTO3"
+	case Edition_EDITION_2023:
+		return "EDITION_2023"
+	case Edition_EDITION_1_TEST_ONLY:
+		return "EDITION_1_TEST_ONLY"
+	case Edition_EDITION_2_TEST_ONLY:
+		return "EDITION_2_TEST_ONLY"
+	case Edition_EDITION_99997_TEST_ONLY:
+		return "EDITION_99997_TEST_ONLY"
+	case Edition_EDITION_99998_TEST_ONLY:
+		return "EDITION_99998_TEST_ONLY"
+	case Edition_EDITION_99999_TEST_ONLY:
+		return "EDITION_99999_TEST_ONLY"
+	}
+	return strconv.Itoa(int(x))
+}
+
+type FileDescriptorSet struct {
+	File []*FileDescriptorProto
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *FileDescriptorSet) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *FileDescriptorSet) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	for _, x := range m.File {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		b = wire.AppendMessage(b, x)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *FileDescriptorSet) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			x := new(FileDescriptorProto)
+			if err := x.Unmarshal(v); err != nil {
+				return err
+			}
+			m.File = append(m.File, x)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type FileDescriptorProto struct {
+	Name             *string
+	Package          *string
+	Dependency       []string
+	PublicDependency []int32
+	WeakDependency   []int32
+	MessageType      []*DescriptorProto
+	EnumType         []*EnumDescriptorProto
+	Service          []*ServiceDescriptorProto
+	Extension        []*FieldDescriptorProto
+	Options          *FileOptions
+	SourceCodeInfo   *SourceCodeInfo
+	Syntax           *string
+	Edition          *Edition
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *FileDescriptorProto) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *FileDescriptorProto) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Name != nil {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		b = wire.AppendString(b, *m.Name)
+	}
+	if m.Package != nil {
+		b = wire.AppendTag(b, 2, wire.BytesType)
+		b = wire.AppendString(b, *m.Package)
+	}
+	for _, x := range m.Dependency {
+		b = wire.AppendTag(b, 3, wire.BytesType)
+		b = wire.AppendString(b, x)
+	}
+	for _, x := range m.MessageType {
+		b = wire.AppendTag(b, 4, wire.BytesType)
+		b = wire.AppendMessage(b, x)
+	}
+	for _, x := range m.EnumType {
+		b = wire.AppendTag(b, 5, wire.BytesType)
+		b = wire.AppendMessage(b, x)
+	}
+	for _, x := range m.Service {
+		b = wire.AppendTag(b, 6, wire.BytesType)
+		b = wire.AppendMessage(b, x)
+	}
+	for _, x := range m.Extension {
+		b = wire.AppendTag(b, 7, wire.BytesType)
+		b = wire.AppendMessage(b, x)
+	}
+	if m.Options != nil {
+		b = wire.AppendTag(b, 8, wire.BytesType)
+		b = wire.AppendMessage(b, m.Options)
+	}
+	if m.SourceCodeInfo != nil {
+		b = wire.AppendTag(b, 9, wire.BytesType)
+		b = wire.AppendMessage(b, m.SourceCodeInfo)
+	}
+	for _, x := range m.PublicDependency {
+		b = wire.AppendTag(b, 10, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(x))
+	}
+	for _, x := range m.WeakDependency {
+		b = wire.AppendTag(b, 11, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(x))
+	}
+	if m.Syntax != nil {
+		b = wire.AppendTag(b, 12, wire.BytesType)
+		b = wire.AppendString(b, *m.Syntax)
+	}
+	if m.Edition != nil {
+		b = wire.AppendTag(b, 14, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(*m.Edition))
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *FileDescriptorProto) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Name = new(string)
+			*m.Name = string(v)
+		case num == 2 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Package = new(string)
+			*m.Package = string(v)
+		case num == 3 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Dependency = append(m.Dependency, string(v))
+		case num == 10 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			for len(v) > 0 {
+				var k int
+				var x uint64
+				x, k = wire.ConsumeVarint(v)
+				if k < 0 {
+					return wire.ParseError(k)
+				}
+				m.PublicDependency = append(m.PublicDependency, int32(x))
+				v = v[k:]
+			}
+		case num == 10 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.PublicDependency = append(m.PublicDependency, int32(v))
+		case num == 11 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			for len(v) > 0 {
+				var k int
+				var x uint64
+				x, k = wire.ConsumeVarint(v)
+				if k < 0 {
+					return wire.ParseError(k)
+				}
+				m.WeakDependency = append(m.WeakDependency, int32(x))
+				v = v[k:]
+			}
+		case num == 11 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.WeakDependency = append(m.WeakDependency, int32(v))
+		case num == 4 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			x := new(DescriptorProto)
+			if err := x.Unmarshal(v); err != nil {
+				return err
+			}
+			m.MessageType = append(m.MessageType, x)
+		case num == 5 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			x := new(EnumDescriptorProto)
+			if err := x.Unmarshal(v); err != nil {
+				return err
+			}
+			m.EnumType = append(m.EnumType, x)
+		case num == 6 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			x := new(ServiceDescriptorProto)
+			if err := x.Unmarshal(v); err != nil {
+				return err
+			}
+			m.Service = append(m.Service, x)
+		case num == 7 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			x := new(FieldDescriptorProto)
+			if err := x.Unmarshal(v); err != nil {
+				return err
+			}
+			m.Extension = append(m.Extension, x)
+		case num == 8 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			if m.Options == nil {
+				m.Options = new(FileOptions)
+			}
+			if err := m.Options.Unmarshal(v); err != nil {
+				return err
+			}
+		case num == 9 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			if m.SourceCodeInfo == nil {
+				m.SourceCodeInfo = new(SourceCodeInfo)
+			}
+			if err := m.SourceCodeInfo.Unmarshal(v); err != nil {
+				return err
+			}
+		case num == 12 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Syntax = new(string)
+			*m.Syntax = string(v)
+		case num == 14 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Edition = new(Edition)
+			*m.Edition = Edition(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type DescriptorProto struct {
+	Name           *string
+	Field          []*FieldDescriptorProto
+	Extension      []*FieldDescriptorProto
+	NestedType     []*DescriptorProto
+	EnumType       []*EnumDescriptorProto
+	ExtensionRange []*DescriptorProto_ExtensionRange
+	OneofDecl      []*OneofDescriptorProto
+	Options        *MessageOptions
+	ReservedRange  []*DescriptorProto_ReservedRange
+	ReservedName   []string
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *DescriptorProto) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *DescriptorProto) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Name != nil {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		b = wire.AppendString(b, *m.Name)
+	}
+	for _, x := range m.Field {
+		b = wire.AppendTag(b, 2, wire.BytesType)
+		b = wire.AppendMessage(b, x)
+	}
+	for _, x := range m.NestedType {
+		b = wire.AppendTag(b, 3, wire.BytesType)
+		b = wire.AppendMessage(b, x)
+	}
+	for _, x := range m.EnumType {
+		b = wire.AppendTag(b, 4, wire.BytesType)
+		b = wire.AppendMessage(b, x)
+	}
+	for _, x := range m.ExtensionRange {
+		b = wire.AppendTag(b, 5, wire.BytesType)
+		b = wire.AppendMessage(b, x)
+	}
+	for _, x := range m.Extension {
+		b = wire.AppendTag(b, 6, wire.BytesType)
+		b = wire.AppendMessage(b, x)
+	}
+	if m.Options != nil {
+		b = wire.AppendTag(b, 7, wire.BytesType)
+		b = wire.AppendMessage(b, m.Options)
+	}
+	for _, x := range m.OneofDecl {
+		b = wire.AppendTag(b, 8, wire.BytesType)
+		b = wire.AppendMessage(b, x)
+	}
+	for _, x := range m.ReservedRange {
+		b = wire.AppendTag(b, 9, wire.BytesType)
+		b = wire.AppendMessage(b, x)
+	}
+	for _, x := range m.ReservedName {
+		b = wire.AppendTag(b, 10, wire.BytesType)
+		b = wire.AppendString(b, x)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *DescriptorProto) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Name = new(string)
+			*m.Name = string(v)
+		case num == 2 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			x := new(FieldDescriptorProto)
+			if err := x.Unmarshal(v); err != nil {
+				return err
+			}
+			m.Field = append(m.Field, x)
+		case num == 6 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			x := new(FieldDescriptorProto)
+			if err := x.Unmarshal(v); err != nil {
+				return err
+			}
+			m.Extension = append(m.Extension, x)
+		case num == 3 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			x := new(DescriptorProto)
+			if err := x.Unmarshal(v); err != nil {
+				return err
+			}
+			m.NestedType = append(m.NestedType, x)
+		case num == 4 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			x := new(EnumDescriptorProto)
+			if err := x.Unmarshal(v); err != nil {
+				return err
+			}
+			m.EnumType = append(m.EnumType, x)
+		case num == 5 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			x := new(DescriptorProto_ExtensionRange)
+			if err := x.Unmarshal(v); err != nil {
+				return err
+			}
+			m.ExtensionRange = append(m.ExtensionRange, x)
+		case num == 8 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			x := new(OneofDescriptorProto)
+			if err := x.Unmarshal(v); err != nil {
+				return err
+			}
+			m.OneofDecl = append(m.OneofDecl, x)
+		case num == 7 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			if m.Options == nil {
+				m.Options = new(MessageOptions)
+			}
+			if err := m.Options.Unmarshal(v); err != nil {
+				return err
+			}
+		case num == 9 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			x := new(DescriptorProto_ReservedRange)
+			if err := x.Unmarshal(v); err != nil {
+				return err
+			}
+			m.ReservedRange = append(m.ReservedRange, x)
+		case num == 10 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.ReservedName = append(m.ReservedName, string(v))
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type DescriptorProto_ExtensionRange struct {
+	Start   *int32
+	End     *int32
+	Options *ExtensionRangeOptions
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *DescriptorProto_ExtensionRange) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *DescriptorProto_ExtensionRange) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Start != nil {
+		b = wire.AppendTag(b, 1, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(*m.Start))
+	}
+	if m.End != nil {
+		b = wire.AppendTag(b, 2, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(*m.End))
+	}
+	if m.Options != nil {
+		b = wire.AppendTag(b, 3, wire.BytesType)
+		b = wire.AppendMessage(b, m.Options)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *DescriptorProto_ExtensionRange) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Start = new(int32)
+			*m.Start = int32(v)
+		case num == 2 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.End = new(int32)
+			*m.End = int32(v)
+		case num == 3 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			if m.Options == nil {
+				m.Options = new(ExtensionRangeOptions)
+			}
+			if err := m.Options.Unmarshal(v); err != nil {
+				return err
+			}
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type DescriptorProto_ReservedRange struct {
+	Start *int32
+	End   *int32
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *DescriptorProto_ReservedRange) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *DescriptorProto_ReservedRange) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Start != nil {
+		b = wire.AppendTag(b, 1, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(*m.Start))
+	}
+	if m.End != nil {
+		b = wire.AppendTag(b, 2, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(*m.End))
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *DescriptorProto_ReservedRange) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Start = new(int32)
+			*m.Start = int32(v)
+		case num == 2 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.End = new(int32)
+			*m.End = int32(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type ExtensionRangeOptions struct {
+	UninterpretedOption []*UninterpretedOption
+	Declaration         []*ExtensionRangeOptions_Declaration
+	Features            *FeatureSet
+	Verification        *ExtensionRangeOptions_VerificationState
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *ExtensionRangeOptions) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *ExtensionRangeOptions) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	for _, x := range m.Declaration {
+		b = wire.AppendTag(b, 2, wire.BytesType)
+		b = wire.AppendMessage(b, x)
+	}
+	if m.Verification != nil {
+		b = wire.AppendTag(b, 3, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(*m.Verification))
+	}
+	if m.Features != nil {
+		b = wire.AppendTag(b, 50, wire.BytesType)
+		b = wire.AppendMessage(b, m.Features)
+	}
+	for _, x := range m.UninterpretedOption {
+		b = wire.AppendTag(b, 999, wire.BytesType)
+		b = wire.AppendMessage(b, x)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *ExtensionRangeOptions) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 999 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			x := new(UninterpretedOption)
+			if err := x.Unmarshal(v); err != nil {
+				return err
+			}
+			m.UninterpretedOption = append(m.UninterpretedOption, x)
+		case num == 2 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			x := new(ExtensionRangeOptions_Declaration)
+			if err := x.Unmarshal(v); err != nil {
+				return err
+			}
+			m.Declaration = append(m.Declaration, x)
+		case num == 50 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			if m.Features == nil {
+				m.Features = new(FeatureSet)
+			}
+			if err := m.Features.Unmarshal(v); err != nil {
+				return err
+			}
+		case num == 3 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Verification = new(ExtensionRangeOptions_VerificationState)
+			*m.Verification = ExtensionRangeOptions_VerificationState(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type ExtensionRangeOptions_VerificationState int32
+
+const (
+	ExtensionRangeOptions_DECLARATION ExtensionRangeOptions_VerificationState = 0
+	ExtensionRangeOptions_UNVERIFIED  ExtensionRangeOptions_VerificationState = 1
+)
+
+func (x ExtensionRangeOptions_VerificationState) String() string {
+	switch x {
+	case ExtensionRangeOptions_DECLARATION:
+		return "DECLARATION"
+	case ExtensionRangeOptions_UNVERIFIED:
+		return "UNVERIFIED"
+	}
+	return strconv.Itoa(int(x))
+}
+
+type ExtensionRangeOptions_Declaration struct {
+	Number   *int32
+	FullName *string
+	Type     *string
+	Reserved *bool
+	Repeated *bool
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *ExtensionRangeOptions_Declaration) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *ExtensionRangeOptions_Declaration) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Number != nil {
+		b = wire.AppendTag(b, 1, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(*m.Number))
+	}
+	if m.FullName != nil {
+		b = wire.AppendTag(b, 2, wire.BytesType)
+		b = wire.AppendString(b, *m.FullName)
+	}
+	if m.Type != nil {
+		b = wire.AppendTag(b, 3, wire.BytesType)
+		b = wire.AppendString(b, *m.Type)
+	}
+	if m.Reserved != nil {
+		b = wire.AppendTag(b, 5, wire.VarintType)
+		b = wire.AppendVarint(b, wire.EncodeBool(*m.Reserved))
+	}
+	if m.Repeated != nil {
+		b = wire.AppendTag(b, 6, wire.VarintType)
+		b = wire.AppendVarint(b, wire.EncodeBool(*m.Repeated))
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *ExtensionRangeOptions_Declaration) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Number = new(int32)
+			*m.Number = int32(v)
+		case num == 2 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.FullName = new(string)
+			*m.FullName = string(v)
+		case num == 3 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Type = new(string)
+			*m.Type = string(v)
+		case num == 5 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Reserved = new(bool)
+			*m.Reserved = wire.DecodeBool(v)
+		case num == 6 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Repeated = new(bool)
+			*m.Repeated = wire.DecodeBool(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type FieldDescriptorProto struct {
+	Name           *string
+	Number         *int32
+	Label          *FieldDescriptorProto_Label
+	Type           *FieldDescriptorProto_Type
+	TypeName       *string
+	Extendee       *string
+	DefaultValue   *string
+	OneofIndex     *int32
+	JsonName       *string
+	Options        *FieldOptions
+	Proto3Optional *bool
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *FieldDescriptorProto) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *FieldDescriptorProto) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Name != nil {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		b = wire.AppendString(b, *m.Name)
+	}
+	if m.Extendee != nil {
+		b = wire.AppendTag(b, 2, wire.BytesType)
+		b = wire.AppendString(b, *m.Extendee)
+	}
+	if m.Number != nil {
+		b = wire.AppendTag(b, 3, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(*m.Number))
+	}
+	if m.Label != nil {
+		b = wire.AppendTag(b, 4, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(*m.Label))
+	}
+	if m.Type != nil {
+		b = wire.AppendTag(b, 5, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(*m.Type))
+	}
+	if m.TypeName != nil {
+		b = wire.AppendTag(b, 6, wire.BytesType)
+		b = wire.AppendString(b, *m.TypeName)
+	}
+	if m.DefaultValue != nil {
+		b = wire.AppendTag(b, 7, wire.BytesType)
+		b = wire.AppendString(b, *m.DefaultValue)
+	}
+	if m.Options != nil {
+		b = wire.AppendTag(b, 8, wire.BytesType)
+		b = wire.AppendMessage(b, m.Options)
+	}
+	if m.OneofIndex != nil {
+		b = wire.AppendTag(b, 9, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(*m.OneofIndex))
+	}
+	if m.JsonName != nil {
+		b = wire.AppendTag(b, 10, wire.BytesType)
+		b = wire.AppendString(b, *m.JsonName)
+	}
+	if m.Proto3Optional != nil {
+		b = wire.AppendTag(b, 17, wire.VarintType)
+		b = wire.AppendVarint(b, wire.EncodeBool(*m.Proto3Optional))
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *FieldDescriptorProto) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Name = new(string)
+			*m.Name = string(v)
+		case num == 3 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Number = new(int32)
+			*m.Number = int32(v)
+		case num == 4 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Label = new(FieldDescriptorProto_Label)
+			*m.Label = FieldDescriptorProto_Label(v)
+		case num == 5 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Type = new(FieldDescriptorProto_Type)
+			*m.Type = FieldDescriptorProto_Type(v)
+		case num == 6 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.TypeName = new(string)
+			*m.TypeName = string(v)
+		case num == 2 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Extendee = new(string)
+			*m.Extendee = string(v)
+		case num == 7 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.DefaultValue = new(string)
+			*m.DefaultValue = string(v)
+		case num == 9 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.OneofIndex = new(int32)
+			*m.OneofIndex = int32(v)
+		case num == 10 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.JsonName = new(string)
+			*m.JsonName = string(v)
+		case num == 8 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			if m.Options == nil {
+				m.Options = new(FieldOptions)
+			}
+			if err := m.Options.Unmarshal(v); err != nil {
+				return err
+			}
+		case num == 17 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Proto3Optional = new(bool)
+			*m.Proto3Optional = wire.DecodeBool(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type FieldDescriptorProto_Type int32
+
+const (
+	FieldDescriptorProto_TYPE_DOUBLE   FieldDescriptorProto_Type = 1
+	FieldDescriptorProto_TYPE_FLOAT    FieldDescriptorProto_Type = 2
+	FieldDescriptorProto_TYPE_INT64    FieldDescriptorProto_Type = 3
+	FieldDescriptorProto_TYPE_UINT64   FieldDescriptorProto_Type = 4
+	FieldDescriptorProto_TYPE_INT32    FieldDescriptorProto_Type = 5
+	FieldDescriptorProto_TYPE_FIXED64  FieldDescriptorProto_Type = 6
+	FieldDescriptorProto_TYPE_FIXED32  FieldDescriptorProto_Type = 7
+	FieldDescriptorProto_TYPE_BOOL     FieldDescriptorProto_Type = 8
+	FieldDescriptorProto_TYPE_STRING   FieldDescriptorProto_Type = 9
+	FieldDescriptorProto_TYPE_GROUP    FieldDescriptorProto_Type = 10
+	FieldDescriptorProto_TYPE_MESSAGE  FieldDescriptorProto_Type = 11
+	FieldDescriptorProto_TYPE_BYTES    FieldDescriptorProto_Type = 12
+	FieldDescriptorProto_TYPE_UINT32   FieldDescriptorProto_Type = 13
+	FieldDescriptorProto_TYPE_ENUM     FieldDescriptorProto_Type = 14
+	FieldDescriptorProto_TYPE_SFIXED32 FieldDescriptorProto_Type = 15
+	FieldDescriptorProto_TYPE_SFIXED64 FieldDescriptorProto_Type = 16
+	FieldDescriptorProto_TYPE_SINT32   FieldDescriptorProto_Type = 17
+	FieldDescriptorProto_TYPE_SINT64   FieldDescriptorProto_Type = 18
+)
+
+func (x FieldDescriptorProto_Type) String() string {
+	switch x {
+	case FieldDescriptorProto_TYPE_DOUBLE:
+		return "TYPE_DOUBLE"
+	case FieldDescriptorProto_TYPE_FLOAT:
+		return "TYPE_FLOAT"
+	case FieldDescriptorProto_TYPE_INT64:
+		return "TYPE_INT64"
+	case FieldDescriptorProto_TYPE_UINT64:
+		return "TYPE_UINT64"
+	case FieldDescriptorProto_TYPE_INT32:
+		return "TYPE_INT32"
+	case FieldDescriptorProto_TYPE_FIXED64:
+		return "TYPE_FIXED64"
+	case FieldDescriptorProto_TYPE_FIXED32:
+		return "TYPE_FIXED32"
+	case FieldDescriptorProto_TYPE_BOOL:
+		return "TYPE_BOOL"
+	case FieldDescriptorProto_TYPE_STRING:
+		return "TYPE_STRING"
+	case FieldDescriptorProto_TYPE_GROUP:
+		return "TYPE_GROUP"
+	case FieldDescriptorProto_TYPE_MESSAGE:
+		return "TYPE_MESSAGE"
+	case FieldDescriptorProto_TYPE_BYTES:
+		return "TYPE_BYTES"
+	case FieldDescriptorProto_TYPE_UINT32:
+		return "TYPE_UINT32"
+	case FieldDescriptorProto_TYPE_ENUM:
+		return "TYPE_ENUM"
+	case FieldDescriptorProto_TYPE_SFIXED32:
+		return "TYPE_SFIXED32"
+	case FieldDescriptorProto_TYPE_SFIXED64:
+		return "TYPE_SFIXED64"
+	case FieldDescriptorProto_TYPE_SINT32:
+		return "TYPE_SINT32"
+	case FieldDescriptorProto_TYPE_SINT64:
+		return "TYPE_SINT64"
+	}
+	return strconv.Itoa(int(x))
+}
+
+type FieldDescriptorProto_Label int32
+
+const (
+	FieldDescriptorProto_LABEL_OPTIONAL FieldDescriptorProto_Label = 1
+	FieldDescriptorProto_LABEL_REPEATED FieldDescriptorProto_Label = 3
+	FieldDescriptorProto_LABEL_REQUIRED FieldDescriptorProto_Label = 2
+)
+
+func (x FieldDescriptorProto_Label) String() string {
+	switch x {
+	case FieldDescriptorProto_LABEL_OPTIONAL:
+		return "LABEL_OPTIONAL"
+	case FieldDescriptorProto_LABEL_REPEATED:
+		return "LABEL_REPEATED"
+	case FieldDescriptorProto_LABEL_REQUIRED:
+		return "LABEL_REQUIRED"
+	}
+	return strconv.Itoa(int(x))
+}
+
+type OneofDescriptorProto struct {
+	Name    *string
+	Options *OneofOptions
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *OneofDescriptorProto) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *OneofDescriptorProto) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Name != nil {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		b = wire.AppendString(b, *m.Name)
+	}
+	if m.Options != nil {
+		b = wire.AppendTag(b, 2, wire.BytesType)
+		b = wire.AppendMessage(b, m.Options)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *OneofDescriptorProto) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Name = new(string)
+			*m.Name = string(v)
+		case num == 2 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			if m.Options == nil {
+				m.Options = new(OneofOptions)
+			}
+			if err := m.Options.Unmarshal(v); err != nil {
+				return err
+			}
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type EnumDescriptorProto struct {
+	Name          *string
+	Value         []*EnumValueDescriptorProto
+	Options       *EnumOptions
+	ReservedRange []*EnumDescriptorProto_EnumReservedRange
+	ReservedName  []string
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *EnumDescriptorProto) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *EnumDescriptorProto) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Name != nil {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		b = wire.AppendString(b, *m.Name)
+	}
+	for _, x := range m.Value {
+		b = wire.AppendTag(b, 2, wire.BytesType)
+		b = wire.AppendMessage(b, x)
+	}
+	if m.Options != nil {
+		b = wire.AppendTag(b, 3, wire.BytesType)
+		b = wire.AppendMessage(b, m.Options)
+	}
+	for _, x := range m.ReservedRange {
+		b = wire.AppendTag(b, 4, wire.BytesType)
+		b = wire.AppendMessage(b, x)
+	}
+	for _, x := range m.ReservedName {
+		b = wire.AppendTag(b, 5, wire.BytesType)
+		b = wire.AppendString(b, x)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *EnumDescriptorProto) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Name = new(string)
+			*m.Name = string(v)
+		case num == 2 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			x := new(EnumValueDescriptorProto)
+			if err := x.Unmarshal(v); err != nil {
+				return err
+			}
+			m.Value = append(m.Value, x)
+		case num == 3 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			if m.Options == nil {
+				m.Options = new(EnumOptions)
+			}
+			if err := m.Options.Unmarshal(v); err != nil {
+				return err
+			}
+		case num == 4 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			x := new(EnumDescriptorProto_EnumReservedRange)
+			if err := x.Unmarshal(v); err != nil {
+				return err
+			}
+			m.ReservedRange = append(m.ReservedRange, x)
+		case num == 5 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.ReservedName = append(m.ReservedName, string(v))
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type EnumDescriptorProto_EnumReservedRange struct {
+	Start *int32
+	End   *int32
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *EnumDescriptorProto_EnumReservedRange) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *EnumDescriptorProto_EnumReservedRange) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Start != nil {
+		b = wire.AppendTag(b, 1, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(*m.Start))
+	}
+	if m.End != nil {
+		b = wire.AppendTag(b, 2, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(*m.End))
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *EnumDescriptorProto_EnumReservedRange) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Start = new(int32)
+			*m.Start = int32(v)
+		case num == 2 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.End = new(int32)
+			*m.End = int32(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type EnumValueDescriptorProto struct {
+	Name    *string
+	Number  *int32
+	Options *EnumValueOptions
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *EnumValueDescriptorProto) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *EnumValueDescriptorProto) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Name != nil {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		b = wire.AppendString(b, *m.Name)
+	}
+	if m.Number != nil {
+		b = wire.AppendTag(b, 2, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(*m.Number))
+	}
+	if m.Options != nil {
+		b = wire.AppendTag(b, 3, wire.BytesType)
+		b = wire.AppendMessage(b, m.Options)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *EnumValueDescriptorProto) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Name = new(string)
+			*m.Name = string(v)
+		case num == 2 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Number = new(int32)
+			*m.Number = int32(v)
+		case num == 3 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			if m.Options == nil {
+				m.Options = new(EnumValueOptions)
+			}
+			if err := m.Options.Unmarshal(v); err != nil {
+				return err
+			}
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type ServiceDescriptorProto struct {
+	Name    *string
+	Method  []*MethodDescriptorProto
+	Options *ServiceOptions
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *ServiceDescriptorProto) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *ServiceDescriptorProto) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Name != nil {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		b = wire.AppendString(b, *m.Name)
+	}
+	for _, x := range m.Method {
+		b = wire.AppendTag(b, 2, wire.BytesType)
+		b = wire.AppendMessage(b, x)
+	}
+	if m.Options != nil {
+		b = wire.AppendTag(b, 3, wire.BytesType)
+		b = wire.AppendMessage(b, m.Options)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *ServiceDescriptorProto) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Name = new(string)
+			*m.Name = string(v)
+		case num == 2 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			x := new(MethodDescriptorProto)
+			if err := x.Unmarshal(v); err != nil {
+				return err
+			}
+			m.Method = append(m.Method, x)
+		case num == 3 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			if m.Options == nil {
+				m.Options = new(ServiceOptions)
+			}
+			if err := m.Options.Unmarshal(v); err != nil {
+				return err
+			}
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type MethodDescriptorProto struct {
+	Name            *string
+	InputType       *string
+	OutputType      *string
+	Options         *MethodOptions
+	ClientStreaming *bool
+	ServerStreaming *bool
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *MethodDescriptorProto) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *MethodDescriptorProto) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Name != nil {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		b = wire.AppendString(b, *m.Name)
+	}
+	if m.InputType != nil {
+		b = wire.AppendTag(b, 2, wire.BytesType)
+		b = wire.AppendString(b, *m.InputType)
+	}
+	if m.OutputType != nil {
+		b = wire.AppendTag(b, 3, wire.BytesType)
+		b = wire.AppendString(b, *m.OutputType)
+	}
+	if m.Options != nil {
+		b = wire.AppendTag(b, 4, wire.BytesType)
+		b = wire.AppendMessage(b, m.Options)
+	}
+	if m.ClientStreaming != nil {
+		b = wire.AppendTag(b, 5, wire.VarintType)
+		b = wire.AppendVarint(b, wire.EncodeBool(*m.ClientStreaming))
+	}
+	if m.ServerStreaming != nil {
+		b = wire.AppendTag(b, 6, wire.VarintType)
+		b = wire.AppendVarint(b, wire.EncodeBool(*m.ServerStreaming))
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *MethodDescriptorProto) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Name = new(string)
+			*m.Name = string(v)
+		case num == 2 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.InputType = new(string)
+			*m.InputType = string(v)
+		case num == 3 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.OutputType = new(string)
+			*m.OutputType = string(v)
+		case num == 4 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			if m.Options == nil {
+				m.Options = new(MethodOptions)
+			}
+			if err := m.Options.Unmarshal(v); err != nil {
+				return err
+			}
+		case num == 5 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.ClientStreaming = new(bool)
+			*m.ClientStreaming = wire.DecodeBool(v)
+		case num == 6 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.ServerStreaming = new(bool)
+			*m.ServerStreaming = wire.DecodeBool(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type FileOptions struct {
+	JavaPackage               *string
+	JavaOuterClassname        *string
+	JavaMultipleFiles         *bool
+	JavaGenerateEqualsAndHash *bool
+	JavaStringCheckUtf8       *bool
+	OptimizeFor               *FileOptions_OptimizeMode
+	GoPackage                 *string
+	CcGenericServices         *bool
+	JavaGenericServices       *bool
+	PyGenericServices         *bool
+	PhpGenericServices        *bool
+	Deprecated                *bool
+	CcEnableArenas            *bool
+	ObjcClassPrefix           *string
+	CsharpNamespace           *string
+	SwiftPrefix               *string
+	PhpClassPrefix            *string
+	PhpNamespace              *string
+	PhpMetadataNamespace      *string
+	RubyPackage               *string
+	Features                  *FeatureSet
+	UninterpretedOption       []*UninterpretedOption
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *FileOptions) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *FileOptions) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.JavaPackage != nil {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		b = wire.AppendString(b, *m.JavaPackage)
+	}
+	if m.JavaOuterClassname != nil {
+		b = wire.AppendTag(b, 8, wire.BytesType)
+		b = wire.AppendString(b, *m.JavaOuterClassname)
+	}
+	if m.OptimizeFor != nil {
+		b = wire.AppendTag(b, 9, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(*m.OptimizeFor))
+	}
+	if m.JavaMultipleFiles != nil {
+		b = wire.AppendTag(b, 10, wire.VarintType)
+		b = wire.AppendVarint(b, wire.EncodeBool(*m.JavaMultipleFiles))
+	}
+	if m.GoPackage != nil {
+		b = wire.AppendTag(b, 11, wire.BytesType)
+		b = wire.AppendString(b, *m.GoPackage)
+	}
+	if m.CcGenericServices != nil {
+		b = wire.AppendTag(b, 16, wire.VarintType)
+		b = wire.AppendVarint(b, wire.EncodeBool(*m.CcGenericServices))
+	}
+	if m.JavaGenericServices != nil {
+		b = wire.AppendTag(b, 17, wire.VarintType)
+		b = wire.AppendVarint(b, wire.EncodeBool(*m.JavaGenericServices))
+	}
+	if m.PyGenericServices != nil {
+		b = wire.AppendTag(b, 18, wire.VarintType)
+		b = wire.AppendVarint(b, wire.EncodeBool(*m.PyGenericServices))
+	}
+	if m.JavaGenerateEqualsAndHash != nil {
+		b = wire.AppendTag(b, 20, wire.VarintType)
+		b = wire.AppendVarint(b, wire.EncodeBool(*m.JavaGenerateEqualsAndHash))
+	}
+	if m.Deprecated != nil {
+		b = wire.AppendTag(b, 23, wire.VarintType)
+		b = wire.AppendVarint(b, wire.EncodeBool(*m.Deprecated))
+	}
+	if m.JavaStringCheckUtf8 != nil {
+		b = wire.AppendTag(b, 27, wire.VarintType)
+		b = wire.AppendVarint(b, wire.EncodeBool(*m.JavaStringCheckUtf8))
+	}
+	if m.CcEnableArenas != nil {
+		b = wire.AppendTag(b, 31, wire.VarintType)
+		b = wire.AppendVarint(b, wire.EncodeBool(*m.CcEnableArenas))
+	}
+	if m.ObjcClassPrefix != nil {
+		b = wire.AppendTag(b, 36, wire.BytesType)
+		b = wire.AppendString(b, *m.ObjcClassPrefix)
+	}
+	if m.CsharpNamespace != nil {
+		b = wire.AppendTag(b, 37, wire.BytesType)
+		b = wire.AppendString(b, *m.CsharpNamespace)
+	}
+	if m.SwiftPrefix != nil {
+		b = wire.AppendTag(b, 39, wire.BytesType)
+		b = wire.AppendString(b, *m.SwiftPrefix)
+	}
+	if m.PhpClassPrefix != nil {
+		b = wire.AppendTag(b, 40, wire.BytesType)
+		b = wire.AppendString(b, *m.PhpClassPrefix)
+	}
+	if m.PhpNamespace != nil {
+		b = wire.AppendTag(b, 41, wire.BytesType)
+		b = wire.AppendString(b, *m.PhpNamespace)
+	}
+	if m.PhpGenericServices != nil {
+		b = wire.AppendTag(b, 42, wire.VarintType)
+		b = wire.AppendVarint(b, wire.EncodeBool(*m.PhpGenericServices))
+	}
+	if m.PhpMetadataNamespace != nil {
+		b = wire.AppendTag(b, 44, wire.BytesType)
+		b = wire.AppendString(b, *m.PhpMetadataNamespace)
+	}
+	if m.RubyPackage != nil {
+		b = wire.AppendTag(b, 45, wire.BytesType)
+		b = wire.AppendString(b, *m.RubyPackage)
+	}
+	if m.Features != nil {
+		b = wire.AppendTag(b, 50, wire.BytesType)
+		b = wire.AppendMessage(b, m.Features)
+	}
+	for _, x := range m.UninterpretedOption {
+		b = wire.AppendTag(b, 999, wire.BytesType)
+		b = wire.AppendMessage(b, x)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *FileOptions) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.JavaPackage = new(string)
+			*m.JavaPackage = string(v)
+		case num == 8 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.JavaOuterClassname = new(string)
+			*m.JavaOuterClassname = string(v)
+		case num == 10 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.JavaMultipleFiles = new(bool)
+			*m.JavaMultipleFiles = wire.DecodeBool(v)
+		case num == 20 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.JavaGenerateEqualsAndHash = new(bool)
+			*m.JavaGenerateEqualsAndHash = wire.DecodeBool(v)
+		case num == 27 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.JavaStringCheckUtf8 = new(bool)
+			*m.JavaStringCheckUtf8 = wire.DecodeBool(v)
+		case num == 9 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.OptimizeFor = new(FileOptions_OptimizeMode)
+			*m.OptimizeFor = FileOptions_OptimizeMode(v)
+		case num == 11 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.GoPackage = new(string)
+			*m.GoPackage = string(v)
+		case num == 16 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.CcGenericServices = new(bool)
+			*m.CcGenericServices = wire.DecodeBool(v)
+		case num == 17 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.JavaGenericServices = new(bool)
+			*m.JavaGenericServices = wire.DecodeBool(v)
+		case num == 18 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.PyGenericServices = new(bool)
+			*m.PyGenericServices = wire.DecodeBool(v)
+		case num == 42 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.PhpGenericServices = new(bool)
+			*m.PhpGenericServices = wire.DecodeBool(v)
+		case num == 23 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Deprecated = new(bool)
+			*m.Deprecated = wire.DecodeBool(v)
+		case num == 31 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.CcEnableArenas = new(bool)
+			*m.CcEnableArenas = wire.DecodeBool(v)
+		case num == 36 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.ObjcClassPrefix = new(string)
+			*m.ObjcClassPrefix = string(v)
+		case num == 37 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.CsharpNamespace = new(string)
+			*m.CsharpNamespace = string(v)
+		case num == 39 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.SwiftPrefix = new(string)
+			*m.SwiftPrefix = string(v)
+		case num == 40 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.PhpClassPrefix = new(string)
+			*m.PhpClassPrefix = string(v)
+		case num == 41 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.PhpNamespace = new(string)
+			*m.PhpNamespace = string(v)
+		case num == 44 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.PhpMetadataNamespace = new(string)
+			*m.PhpMetadataNamespace = string(v)
+		case num == 45 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.RubyPackage = new(string)
+			*m.RubyPackage = string(v)
+		case num == 50 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			if m.Features == nil {
+				m.Features = new(FeatureSet)
+			}
+			if err := m.Features.Unmarshal(v); err != nil {
+				return err
+			}
+		case num == 999 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			x := new(UninterpretedOption)
+			if err := x.Unmarshal(v); err != nil {
+				return err
+			}
+			m.UninterpretedOption = append(m.UninterpretedOption, x)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type FileOptions_OptimizeMode int32
+
+const (
+	FileOptions_SPEED        FileOptions_OptimizeMode = 1
+	FileOptions_CODE_SIZE    FileOptions_OptimizeMode = 2
+	FileOptions_LITE_RUNTIME FileOptions_OptimizeMode = 3
+)
+
+func (x FileOptions_OptimizeMode) String() string {
+	switch x {
+	case FileOptions_SPEED:
+		return "SPEED"
+	case FileOptions_CODE_SIZE:
+		return "CODE_SIZE"
+	case FileOptions_LITE_RUNTIME:
+		return "LITE_RUNTIME"
+	}
+	return strconv.Itoa(int(x))
+}
+
+type MessageOptions struct {
+	MessageSetWireFormat               *bool
+	NoStandardDescriptorAccessor       *bool
+	Deprecated                         *bool
+	MapEntry                           *bool
+	DeprecatedLegacyJsonFieldConflicts *bool
+	Features                           *FeatureSet
+	UninterpretedOption                []*UninterpretedOption
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *MessageOptions) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *MessageOptions) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.MessageSetWireFormat != nil {
+		b = wire.AppendTag(b, 1, wire.VarintType)
+		b = wire.AppendVarint(b, wire.EncodeBool(*m.MessageSetWireFormat))
+	}
+	if m.NoStandardDescriptorAccessor != nil {
+		b = wire.AppendTag(b, 2, wire.VarintType)
+		b = wire.AppendVarint(b, wire.EncodeBool(*m.NoStandardDescriptorAccessor))
+	}
+	if m.Deprecated != nil {
+		b = wire.AppendTag(b, 3, wire.VarintType)
+		b = wire.AppendVarint(b, wire.EncodeBool(*m.Deprecated))
+	}
+	if m.MapEntry != nil {
+		b = wire.AppendTag(b, 7, wire.VarintType)
+		b = wire.AppendVarint(b, wire.EncodeBool(*m.MapEntry))
+	}
+	if m.DeprecatedLegacyJsonFieldConflicts != nil {
+		b = wire.AppendTag(b, 11, wire.VarintType)
+		b = wire.AppendVarint(b, wire.EncodeBool(*m.DeprecatedLegacyJsonFieldConflicts))
+	}
+	if m.Features != nil {
+		b = wire.AppendTag(b, 12, wire.BytesType)
+		b = wire.AppendMessage(b, m.Features)
+	}
+	for _, x := range m.UninterpretedOption {
+		b = wire.AppendTag(b, 999, wire.BytesType)
+		b = wire.AppendMessage(b, x)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *MessageOptions) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.MessageSetWireFormat = new(bool)
+			*m.MessageSetWireFormat = wire.DecodeBool(v)
+		case num == 2 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.NoStandardDescriptorAccessor = new(bool)
+			*m.NoStandardDescriptorAccessor = wire.DecodeBool(v)
+		case num == 3 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Deprecated = new(bool)
+			*m.Deprecated = wire.DecodeBool(v)
+		case num == 7 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.MapEntry = new(bool)
+			*m.MapEntry = wire.DecodeBool(v)
+		case num == 11 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.DeprecatedLegacyJsonFieldConflicts = new(bool)
+			*m.DeprecatedLegacyJsonFieldConflicts = wire.DecodeBool(v)
+		case num == 12 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			if m.Features == nil {
+				m.Features = new(FeatureSet)
+			}
+			if err := m.Features.Unmarshal(v); err != nil {
+				return err
+			}
+		case num == 999 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			x := new(UninterpretedOption)
+			if err := x.Unmarshal(v); err != nil {
+				return err
+			}
+			m.UninterpretedOption = append(m.UninterpretedOption, x)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type FieldOptions struct {
+	Ctype               *FieldOptions_CType
+	Packed              *bool
+	Jstype              *FieldOptions_JSType
+	Lazy                *bool
+	UnverifiedLazy      *bool
+	Deprecated          *bool
+	Weak                *bool
+	DebugRedact         *bool
+	Retention           *FieldOptions_OptionRetention
+	Targets             []FieldOptions_OptionTargetType
+	EditionDefaults     []*FieldOptions_EditionDefault
+	Features            *FeatureSet
+	UninterpretedOption []*UninterpretedOption
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *FieldOptions) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *FieldOptions) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Ctype != nil {
+		b = wire.AppendTag(b, 1, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(*m.Ctype))
+	}
+	if m.Packed != nil {
+		b = wire.AppendTag(b, 2, wire.VarintType)
+		b = wire.AppendVarint(b, wire.EncodeBool(*m.Packed))
+	}
+	if m.Deprecated != nil {
+		b = wire.AppendTag(b, 3, wire.VarintType)
+		b = wire.AppendVarint(b, wire.EncodeBool(*m.Deprecated))
+	}
+	if m.Lazy != nil {
+		b = wire.AppendTag(b, 5, wire.VarintType)
+		b = wire.AppendVarint(b, wire.EncodeBool(*m.Lazy))
+	}
+	if m.Jstype != nil {
+		b = wire.AppendTag(b, 6, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(*m.Jstype))
+	}
+	if m.Weak != nil {
+		b = wire.AppendTag(b, 10, wire.VarintType)
+		b = wire.AppendVarint(b, wire.EncodeBool(*m.Weak))
+	}
+	if m.UnverifiedLazy != nil {
+		b = wire.AppendTag(b, 15, wire.VarintType)
+		b = wire.AppendVarint(b, wire.EncodeBool(*m.UnverifiedLazy))
+	}
+	if m.DebugRedact != nil {
+		b = wire.AppendTag(b, 16, wire.VarintType)
+		b = wire.AppendVarint(b, wire.EncodeBool(*m.DebugRedact))
+	}
+	if m.Retention != nil {
+		b = wire.AppendTag(b, 17, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(*m.Retention))
+	}
+	for _, x := range m.Targets {
+		b = wire.AppendTag(b, 19, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(x))
+	}
+	for _, x := range m.EditionDefaults {
+		b = wire.AppendTag(b, 20, wire.BytesType)
+		b = wire.AppendMessage(b, x)
+	}
+	if m.Features != nil {
+		b = wire.AppendTag(b, 21, wire.BytesType)
+		b = wire.AppendMessage(b, m.Features)
+	}
+	for _, x := range m.UninterpretedOption {
+		b = wire.AppendTag(b, 999, wire.BytesType)
+		b = wire.AppendMessage(b, x)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *FieldOptions) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Ctype = new(FieldOptions_CType)
+			*m.Ctype = FieldOptions_CType(v)
+		case num == 2 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Packed = new(bool)
+			*m.Packed = wire.DecodeBool(v)
+		case num == 6 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Jstype = new(FieldOptions_JSType)
+			*m.Jstype = FieldOptions_JSType(v)
+		case num == 5 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Lazy = new(bool)
+			*m.Lazy = wire.DecodeBool(v)
+		case num == 15 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.UnverifiedLazy = new(bool)
+			*m.UnverifiedLazy = wire.DecodeBool(v)
+		case num == 3 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Deprecated = new(bool)
+			*m.Deprecated = wire.DecodeBool(v)
+		case num == 10 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Weak = new(bool)
+			*m.Weak = wire.DecodeBool(v)
+		case num == 16 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.DebugRedact = new(bool)
+			*m.DebugRedact = wire.DecodeBool(v)
+		case num == 17 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Retention = new(FieldOptions_OptionRetention)
+			*m.Retention = FieldOptions_OptionRetention(v)
+		case num == 19 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			for len(v) > 0 {
+				var k int
+				var x uint64
+				x, k = wire.ConsumeVarint(v)
+				if k < 0 {
+					return wire.ParseError(k)
+				}
+				m.Targets = append(m.Targets, FieldOptions_OptionTargetType(x))
+				v = v[k:]
+			}
+		case num == 19 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Targets = append(m.Targets, FieldOptions_OptionTargetType(v))
+		case num == 20 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			x := new(FieldOptions_EditionDefault)
+			if err := x.Unmarshal(v); err != nil {
+				return err
+			}
+			m.EditionDefaults = append(m.EditionDefaults, x)
+		case num == 21 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			if m.Features == nil {
+				m.Features = new(FeatureSet)
+			}
+			if err := m.Features.Unmarshal(v); err != nil {
+				return err
+			}
+		case num == 999 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			x := new(UninterpretedOption)
+			if err := x.Unmarshal(v); err != nil {
+				return err
+			}
+			m.UninterpretedOption = append(m.UninterpretedOption, x)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type FieldOptions_CType int32
+
+const (
+	FieldOptions_STRING       FieldOptions_CType = 0
+	FieldOptions_CORD         FieldOptions_CType = 1
+	FieldOptions_STRING_PIECE FieldOptions_CType = 2
+)
+
+func (x FieldOptions_CType) String() string {
+	switch x {
+	case FieldOptions_STRING:
+		return "STRING"
+	case FieldOptions_CORD:
+		return "CORD"
+	case FieldOptions_STRING_PIECE:
+		return "STRING_PIECE"
+	}
+	return strconv.Itoa(int(x))
+}
+
+type FieldOptions_JSType int32
+
+const (
+	FieldOptions_JS_NORMAL FieldOptions_JSType = 0
+	FieldOptions_JS_STRING FieldOptions_JSType = 1
+	FieldOptions_JS_NUMBER FieldOptions_JSType = 2
+)
+
+func (x FieldOptions_JSType) String() string {
+	switch x {
+	case FieldOptions_JS_NORMAL:
+		return "JS_NORMAL"
+	case FieldOptions_JS_STRING:
+		return "JS_STRING"
+	case FieldOptions_JS_NUMBER:
+		return "JS_NUMBER"
+	}
+	return strconv.Itoa(int(x))
+}
+
+type FieldOptions_OptionRetention int32
+
+const (
+	FieldOptions_RETENTION_UNKNOWN FieldOptions_OptionRetention = 0
+	FieldOptions_RETENTION_RUNTIME FieldOptions_OptionRetention = 1
+	FieldOptions_RETENTION_SOURCE  FieldOptions_OptionRetention = 2
+)
+
+func (x FieldOptions_OptionRetention) String() string {
+	switch x {
+	case FieldOptions_RETENTION_UNKNOWN:
+		return "RETENTION_UNKNOWN"
+	case FieldOptions_RETENTION_RUNTIME:
+		return "RETENTION_RUNTIME"
+	case FieldOptions_RETENTION_SOURCE:
+		return "RETENTION_SOURCE"
+	}
+	return strconv.Itoa(int(x))
+}
+
+type FieldOptions_OptionTargetType int32
+
+const (
+	FieldOptions_TARGET_TYPE_UNKNOWN         FieldOptions_OptionTargetType = 0
+	FieldOptions_TARGET_TYPE_FILE            FieldOptions_OptionTargetType = 1
+	FieldOptions_TARGET_TYPE_EXTENSION_RANGE FieldOptions_OptionTargetType = 2
+	FieldOptions_TARGET_TYPE_MESSAGE         FieldOptions_OptionTargetType = 3
+	FieldOptions_TARGET_TYPE_FIELD           FieldOptions_OptionTargetType = 4
+	FieldOptions_TARGET_TYPE_ONEOF           FieldOptions_OptionTargetType = 5
+	FieldOptions_TARGET_TYPE_ENUM            FieldOptions_OptionTargetType = 6
+	FieldOptions_TARGET_TYPE_ENUM_ENTRY      FieldOptions_OptionTargetType = 7
+	FieldOptions_TARGET_TYPE_SERVICE         FieldOptions_OptionTargetType = 8
+	FieldOptions_TARGET_TYPE_METHOD          FieldOptions_OptionTargetType = 9
+)
+
+func (x FieldOptions_OptionTargetType) String() string {
+	switch x {
+	case FieldOptions_TARGET_TYPE_UNKNOWN:
+		return "TARGET_TYPE_UNKNOWN"
+	case FieldOptions_TARGET_TYPE_FILE:
+		return "TARGET_TYPE_FILE"
+	case FieldOptions_TARGET_TYPE_EXTENSION_RANGE:
+		return "TARGET_TYPE_EXTENSION_RANGE"
+	case FieldOptions_TARGET_TYPE_MESSAGE:
+		return "TARGET_TYPE_MESSAGE"
+	case FieldOptions_TARGET_TYPE_FIELD:
+		return "TARGET_TYPE_FIELD"
+	case FieldOptions_TARGET_TYPE_ONEOF:
+		return "TARGET_TYPE_ONEOF"
+	case FieldOptions_TARGET_TYPE_ENUM:
+		return "TARGET_TYPE_ENUM"
+	case FieldOptions_TARGET_TYPE_ENUM_ENTRY:
+		return "TARGET_TYPE_ENUM_ENTRY"
+	case FieldOptions_TARGET_TYPE_SERVICE:
+		return "TARGET_TYPE_SERVICE"
+	case FieldOptions_TARGET_TYPE_METHOD:
+		return "TARGET_TYPE_METHOD"
+	}
+	return strconv.Itoa(int(x))
+}
+
+type FieldOptions_EditionDefault struct {
+	Edition *Edition
+	Value   *string
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *FieldOptions_EditionDefault) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *FieldOptions_EditionDefault) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Value != nil {
+		b = wire.AppendTag(b, 2, wire.BytesType)
+		b = wire.AppendString(b, *m.Value)
+	}
+	if m.Edition != nil {
+		b = wire.AppendTag(b, 3, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(*m.Edition))
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *FieldOptions_EditionDefault) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 3 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Edition = new(Edition)
+			*m.Edition = Edition(v)
+		case num == 2 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Value = new(string)
+			*m.Value = string(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type OneofOptions struct {
+	Features            *FeatureSet
+	UninterpretedOption []*UninterpretedOption
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *OneofOptions) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *OneofOptions) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Features != nil {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		b = wire.AppendMessage(b, m.Features)
+	}
+	for _, x := range m.UninterpretedOption {
+		b = wire.AppendTag(b, 999, wire.BytesType)
+		b = wire.AppendMessage(b, x)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *OneofOptions) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			if m.Features == nil {
+				m.Features = new(FeatureSet)
+			}
+			if err := m.Features.Unmarshal(v); err != nil {
+				return err
+			}
+		case num == 999 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			x := new(UninterpretedOption)
+			if err := x.Unmarshal(v); err != nil {
+				return err
+			}
+			m.UninterpretedOption = append(m.UninterpretedOption, x)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type EnumOptions struct {
+	AllowAlias                         *bool
+	Deprecated                         *bool
+	DeprecatedLegacyJsonFieldConflicts *bool
+	Features                           *FeatureSet
+	UninterpretedOption                []*UninterpretedOption
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *EnumOptions) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *EnumOptions) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.AllowAlias != nil {
+		b = wire.AppendTag(b, 2, wire.VarintType)
+		b = wire.AppendVarint(b, wire.EncodeBool(*m.AllowAlias))
+	}
+	if m.Deprecated != nil {
+		b = wire.AppendTag(b, 3, wire.VarintType)
+		b = wire.AppendVarint(b, wire.EncodeBool(*m.Deprecated))
+	}
+	if m.DeprecatedLegacyJsonFieldConflicts != nil {
+		b = wire.AppendTag(b, 6, wire.VarintType)
+		b = wire.AppendVarint(b, wire.EncodeBool(*m.DeprecatedLegacyJsonFieldConflicts))
+	}
+	if m.Features != nil {
+		b = wire.AppendTag(b, 7, wire.BytesType)
+		b = wire.AppendMessage(b, m.Features)
+	}
+	for _, x := range m.UninterpretedOption {
+		b = wire.AppendTag(b, 999, wire.BytesType)
+		b = wire.AppendMessage(b, x)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *EnumOptions) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 2 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.AllowAlias = new(bool)
+			*m.AllowAlias = wire.DecodeBool(v)
+		case num == 3 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Deprecated = new(bool)
+			*m.Deprecated = wire.DecodeBool(v)
+		case num == 6 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.DeprecatedLegacyJsonFieldConflicts = new(bool)
+			*m.DeprecatedLegacyJsonFieldConflicts = wire.DecodeBool(v)
+		case num == 7 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			if m.Features == nil {
+				m.Features = new(FeatureSet)
+			}
+			if err := m.Features.Unmarshal(v); err != nil {
+				return err
+			}
+		case num == 999 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			x := new(UninterpretedOption)
+			if err := x.Unmarshal(v); err != nil {
+				return err
+			}
+			m.UninterpretedOption = append(m.UninterpretedOption, x)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type EnumValueOptions struct {
+	Deprecated          *bool
+	Features            *FeatureSet
+	DebugRedact         *bool
+	UninterpretedOption []*UninterpretedOption
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *EnumValueOptions) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *EnumValueOptions) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Deprecated != nil {
+		b = wire.AppendTag(b, 1, wire.VarintType)
+		b = wire.AppendVarint(b, wire.EncodeBool(*m.Deprecated))
+	}
+	if m.Features != nil {
+		b = wire.AppendTag(b, 2, wire.BytesType)
+		b = wire.AppendMessage(b, m.Features)
+	}
+	if m.DebugRedact != nil {
+		b = wire.AppendTag(b, 3, wire.VarintType)
+		b = wire.AppendVarint(b, wire.EncodeBool(*m.DebugRedact))
+	}
+	for _, x := range m.UninterpretedOption {
+		b = wire.AppendTag(b, 999, wire.BytesType)
+		b = wire.AppendMessage(b, x)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *EnumValueOptions) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Deprecated = new(bool)
+			*m.Deprecated = wire.DecodeBool(v)
+		case num == 2 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			if m.Features == nil {
+				m.Features = new(FeatureSet)
+			}
+			if err := m.Features.Unmarshal(v); err != nil {
+				return err
+			}
+		case num == 3 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.DebugRedact = new(bool)
+			*m.DebugRedact = wire.DecodeBool(v)
+		case num == 999 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			x := new(UninterpretedOption)
+			if err := x.Unmarshal(v); err != nil {
+				return err
+			}
+			m.UninterpretedOption = append(m.UninterpretedOption, x)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type ServiceOptions struct {
+	Features            *FeatureSet
+	Deprecated          *bool
+	UninterpretedOption []*UninterpretedOption
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *ServiceOptions) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *ServiceOptions) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Deprecated != nil {
+		b = wire.AppendTag(b, 33, wire.VarintType)
+		b = wire.AppendVarint(b, wire.EncodeBool(*m.Deprecated))
+	}
+	if m.Features != nil {
+		b = wire.AppendTag(b, 34, wire.BytesType)
+		b = wire.AppendMessage(b, m.Features)
+	}
+	for _, x := range m.UninterpretedOption {
+		b = wire.AppendTag(b, 999, wire.BytesType)
+		b = wire.AppendMessage(b, x)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *ServiceOptions) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 34 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			if m.Features == nil {
+				m.Features = new(FeatureSet)
+			}
+			if err := m.Features.Unmarshal(v); err != nil {
+				return err
+			}
+		case num == 33 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Deprecated = new(bool)
+			*m.Deprecated = wire.DecodeBool(v)
+		case num == 999 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			x := new(UninterpretedOption)
+			if err := x.Unmarshal(v); err != nil {
+				return err
+			}
+			m.UninterpretedOption = append(m.UninterpretedOption, x)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type MethodOptions struct {
+	Deprecated          *bool
+	IdempotencyLevel    *MethodOptions_IdempotencyLevel
+	Features            *FeatureSet
+	UninterpretedOption []*UninterpretedOption
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *MethodOptions) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *MethodOptions) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Deprecated != nil {
+		b = wire.AppendTag(b, 33, wire.VarintType)
+		b = wire.AppendVarint(b, wire.EncodeBool(*m.Deprecated))
+	}
+	if m.IdempotencyLevel != nil {
+		b = wire.AppendTag(b, 34, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(*m.IdempotencyLevel))
+	}
+	if m.Features != nil {
+		b = wire.AppendTag(b, 35, wire.BytesType)
+		b = wire.AppendMessage(b, m.Features)
+	}
+	for _, x := range m.UninterpretedOption {
+		b = wire.AppendTag(b, 999, wire.BytesType)
+		b = wire.AppendMessage(b, x)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *MethodOptions) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 33 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Deprecated = new(bool)
+			*m.Deprecated = wire.DecodeBool(v)
+		case num == 34 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.IdempotencyLevel = new(MethodOptions_IdempotencyLevel)
+			*m.IdempotencyLevel = MethodOptions_IdempotencyLevel(v)
+		case num == 35 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			if m.Features == nil {
+				m.Features = new(FeatureSet)
+			}
+			if err := m.Features.Unmarshal(v); err != nil {
+				return err
+			}
+		case num == 999 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			x := new(UninterpretedOption)
+			if err := x.Unmarshal(v); err != nil {
+				return err
+			}
+			m.UninterpretedOption = append(m.UninterpretedOption, x)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type MethodOptions_IdempotencyLevel int32
+
+const (
+	MethodOptions_IDEMPOTENCY_UNKNOWN MethodOptions_IdempotencyLevel = 0
+	MethodOptions_NO_SIDE_EFFECTS     MethodOptions_IdempotencyLevel = 1
+	MethodOptions_IDEMPOTENT          MethodOptions_IdempotencyLevel = 2
+)
+
+func (x MethodOptions_IdempotencyLevel) String() string {
+	switch x {
+	case MethodOptions_IDEMPOTENCY_UNKNOWN:
+		return "IDEMPOTENCY_UNKNOWN"
+	case MethodOptions_NO_SIDE_EFFECTS:
+		return "NO_SIDE_EFFECTS"
+	case MethodOptions_IDEMPOTENT:
+		return "IDEMPOTENT"
+	}
+	return strconv.Itoa(int(x))
+}
+
+type UninterpretedOption struct {
+	Name             []*UninterpretedOption_NamePart
+	IdentifierValue  *string
+	PositiveIntValue *uint64
+	NegativeIntValue *int64
+	DoubleValue      *float64
+	StringValue      []byte
+	AggregateValue   *string
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *UninterpretedOption) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *UninterpretedOption) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	for _, x := range m.Name {
+		b = wire.AppendTag(b, 2, wire.BytesType)
+		b = wire.AppendMessage(b, x)
+	}
+	if m.IdentifierValue != nil {
+		b = wire.AppendTag(b, 3, wire.BytesType)
+		b = wire.AppendString(b, *m.IdentifierValue)
+	}
+	if m.PositiveIntValue != nil {
+		b = wire.AppendTag(b, 4, wire.VarintType)
+		b = wire.AppendVarint(b, *m.PositiveIntValue)
+	}
+	if m.NegativeIntValue != nil {
+		b = wire.AppendTag(b, 5, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(*m.NegativeIntValue))
+	}
+	if m.DoubleValue != nil {
+		b = wire.AppendTag(b, 6, wire.Fixed64Type)
+		b = wire.AppendFixed64(b, math.Float64bits(*m.DoubleValue))
+	}
+	if m.StringValue != nil {
+		b = wire.AppendTag(b, 7, wire.BytesType)
+		b = wire.AppendBytes(b, m.StringValue)
+	}
+	if m.AggregateValue != nil {
+		b = wire.AppendTag(b, 8, wire.BytesType)
+		b = wire.AppendString(b, *m.AggregateValue)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *UninterpretedOption) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 2 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			x := new(UninterpretedOption_NamePart)
+			if err := x.Unmarshal(v); err != nil {
+				return err
+			}
+			m.Name = append(m.Name, x)
+		case num == 3 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.IdentifierValue = new(string)
+			*m.IdentifierValue = string(v)
+		case num == 4 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.PositiveIntValue = new(uint64)
+			*m.PositiveIntValue = v
+		case num == 5 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.NegativeIntValue = new(int64)
+			*m.NegativeIntValue = int64(v)
+		case num == 6 && typ == wire.Fixed64Type:
+			var v uint64
+			v, n = wire.ConsumeFixed64(b)
+			if n < 0 {
+				break
+			}
+			m.DoubleValue = new(float64)
+			*m.DoubleValue = math.Float64frombits(v)
+		case num == 7 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.StringValue = append([]byte{}, v...)
+		case num == 8 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.AggregateValue = new(string)
+			*m.AggregateValue = string(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type UninterpretedOption_NamePart struct {
+	NamePart    *string
+	IsExtension *bool
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *UninterpretedOption_NamePart) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *UninterpretedOption_NamePart) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.NamePart != nil {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		b = wire.AppendString(b, *m.NamePart)
+	}
+	if m.IsExtension != nil {
+		b = wire.AppendTag(b, 2, wire.VarintType)
+		b = wire.AppendVarint(b, wire.EncodeBool(*m.IsExtension))
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *UninterpretedOption_NamePart) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.NamePart = new(string)
+			*m.NamePart = string(v)
+		case num == 2 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.IsExtension = new(bool)
+			*m.IsExtension = wire.DecodeBool(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type FeatureSet struct {
+	FieldPresence         *FeatureSet_FieldPresence
+	EnumType              *FeatureSet_EnumType
+	RepeatedFieldEncoding *FeatureSet_RepeatedFieldEncoding
+	Utf8Validation        *FeatureSet_Utf8Validation
+	MessageEncoding       *FeatureSet_MessageEncoding
+	JsonFormat            *FeatureSet_JsonFormat
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *FeatureSet) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *FeatureSet) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.FieldPresence != nil {
+		b = wire.AppendTag(b, 1, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(*m.FieldPresence))
+	}
+	if m.EnumType != nil {
+		b = wire.AppendTag(b, 2, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(*m.EnumType))
+	}
+	if m.RepeatedFieldEncoding != nil {
+		b = wire.AppendTag(b, 3, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(*m.RepeatedFieldEncoding))
+	}
+	if m.Utf8Validation != nil {
+		b = wire.AppendTag(b, 4, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(*m.Utf8Validation))
+	}
+	if m.MessageEncoding != nil {
+		b = wire.AppendTag(b, 5, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(*m.MessageEncoding))
+	}
+	if m.JsonFormat != nil {
+		b = wire.AppendTag(b, 6, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(*m.JsonFormat))
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *FeatureSet) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.FieldPresence = new(FeatureSet_FieldPresence)
+			*m.FieldPresence = FeatureSet_FieldPresence(v)
+		case num == 2 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.EnumType = new(FeatureSet_EnumType)
+			*m.EnumType = FeatureSet_EnumType(v)
+		case num == 3 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.RepeatedFieldEncoding = new(FeatureSet_RepeatedFieldEncoding)
+			*m.RepeatedFieldEncoding = FeatureSet_RepeatedFieldEncoding(v)
+		case num == 4 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Utf8Validation = new(FeatureSet_Utf8Validation)
+			*m.Utf8Validation = FeatureSet_Utf8Validation(v)
+		case num == 5 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.MessageEncoding = new(FeatureSet_MessageEncoding)
+			*m.MessageEncoding = FeatureSet_MessageEncoding(v)
+		case num == 6 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.JsonFormat = new(FeatureSet_JsonFormat)
+			*m.JsonFormat = FeatureSet_JsonFormat(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type FeatureSet_FieldPresence int32
+
+const (
+	FeatureSet_FIELD_PRESENCE_UNKNOWN FeatureSet_FieldPresence = 0
+	FeatureSet_EXPLICIT               FeatureSet_FieldPresence = 1
+	FeatureSet_IMPLICIT               FeatureSet_FieldPresence = 2
+	FeatureSet_LEGACY_REQUIRED        FeatureSet_FieldPresence = 3
+)
+
+func (x FeatureSet_FieldPresence) String() string {
+	switch x {
+	case FeatureSet_FIELD_PRESENCE_UNKNOWN:
+		return "FIELD_PRESENCE_UNKNOWN"
+	case FeatureSet_EXPLICIT:
+		return "EXPLICIT"
+	case FeatureSet_IMPLICIT:
+		return "IMPLICIT"
+	case FeatureSet_LEGACY_REQUIRED:
+		return "LEGACY_REQUIRED"
+	}
+	return strconv.Itoa(int(x))
+}
+
+type FeatureSet_EnumType int32
+
+const (
+	FeatureSet_ENUM_TYPE_UNKNOWN FeatureSet_EnumType = 0
+	FeatureSet_OPEN              FeatureSet_EnumType = 1
+	FeatureSet_CLOSED            FeatureSet_EnumType = 2
+)
+
+func (x FeatureSet_EnumType) String() string {
+	switch x {
+	case FeatureSet_ENUM_TYPE_UNKNOWN:
+		return "ENUM_TYPE_UNKNOWN"
+	case FeatureSet_OPEN:
+		return "OPEN"
+	case FeatureSet_CLOSED:
+		return "CLOSED"
+	}
+	return strconv.Itoa(int(x))
+}
+
+type FeatureSet_RepeatedFieldEncoding int32
+
+const (
+	FeatureSet_REPEATED_FIELD_ENCODING_UNKNOWN FeatureSet_RepeatedFieldEncoding = 0
+	FeatureSet_PACKED                          FeatureSet_RepeatedFieldEncoding = 1
+	FeatureSet_EXPANDED                        FeatureSet_RepeatedFieldEncoding = 2
+)
+
+func (x FeatureSet_RepeatedFieldEncoding) String() string {
+	switch x {
+	case FeatureSet_REPEATED_FIELD_ENCODING_UNKNOWN:
+		return "REPEATED_FIELD_ENCODING_UNKNOWN"
+	case FeatureSet_PACKED:
+		return "PACKED"
+	case FeatureSet_EXPANDED:
+		return "EXPANDED"
+	}
+	return strconv.Itoa(int(x))
+}
+
+type FeatureSet_Utf8Validation int32
+
+const (
+	FeatureSet_UTF8_VALIDATION_UNKNOWN FeatureSet_Utf8Validation = 0
+	FeatureSet_NONE                    FeatureSet_Utf8Validation = 1
+	FeatureSet_VERIFY                  FeatureSet_Utf8Validation = 2
+)
+
+func (x FeatureSet_Utf8Validation) String() string {
+	switch x {
+	case FeatureSet_UTF8_VALIDATION_UNKNOWN:
+		return "UTF8_VALIDATION_UNKNOWN"
+	case FeatureSet_NONE:
+		return "NONE"
+	case FeatureSet_VERIFY:
+		return "VERIFY"
+	}
+	return strconv.Itoa(int(x))
+}
+
+type FeatureSet_MessageEncoding int32
+
+const (
+	FeatureSet_MESSAGE_ENCODING_UNKNOWN FeatureSet_MessageEncoding = 0
+	FeatureSet_LENGTH_PREFIXED          FeatureSet_MessageEncoding = 1
+	FeatureSet_DELIMITED                FeatureSet_MessageEncoding = 2
+)
+
+func (x FeatureSet_MessageEncoding) String() string {
+	switch x {
+	case FeatureSet_MESSAGE_ENCODING_UNKNOWN:
+		return "MESSAGE_ENCODING_UNKNOWN"
+	case FeatureSet_LENGTH_PREFIXED:
+		return "LENGTH_PREFIXED"
+	case FeatureSet_DELIMITED:
+		return "DELIMITED"
+	}
+	return strconv.Itoa(int(x))
+}
+
+type FeatureSet_JsonFormat int32
+
+const (
+	FeatureSet_JSON_FORMAT_UNKNOWN FeatureSet_JsonFormat = 0
+	FeatureSet_ALLOW               FeatureSet_JsonFormat = 1
+	FeatureSet_LEGACY_BEST_EFFORT  FeatureSet_JsonFormat = 2
+)
+
+func (x FeatureSet_JsonFormat) String() string {
+	switch x {
+	case FeatureSet_JSON_FORMAT_UNKNOWN:
+		return "JSON_FORMAT_UNKNOWN"
+	case FeatureSet_ALLOW:
+		return "ALLOW"
+	case FeatureSet_LEGACY_BEST_EFFORT:
+		return "LEGACY_BEST_EFFORT"
+	}
+	return strconv.Itoa(int(x))
+}
+
+type FeatureSetDefaults struct {
+	Defaults       []*FeatureSetDefaults_FeatureSetEditionDefault
+	MinimumEdition *Edition
+	MaximumEdition *Edition
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *FeatureSetDefaults) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *FeatureSetDefaults) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	for _, x := range m.Defaults {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		b = wire.AppendMessage(b, x)
+	}
+	if m.MinimumEdition != nil {
+		b = wire.AppendTag(b, 4, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(*m.MinimumEdition))
+	}
+	if m.MaximumEdition != nil {
+		b = wire.AppendTag(b, 5, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(*m.MaximumEdition))
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *FeatureSetDefaults) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			x := new(FeatureSetDefaults_FeatureSetEditionDefault)
+			if err := x.Unmarshal(v); err != nil {
+				return err
+			}
+			m.Defaults = append(m.Defaults, x)
+		case num == 4 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.MinimumEdition = new(Edition)
+			*m.MinimumEdition = Edition(v)
+		case num == 5 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.MaximumEdition = new(Edition)
+			*m.MaximumEdition = Edition(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type FeatureSetDefaults_FeatureSetEditionDefault struct {
+	Edition  *Edition
+	Features *FeatureSet
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *FeatureSetDefaults_FeatureSetEditionDefault) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *FeatureSetDefaults_FeatureSetEditionDefault) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Features != nil {
+		b = wire.AppendTag(b, 2, wire.BytesType)
+		b = wire.AppendMessage(b, m.Features)
+	}
+	if m.Edition != nil {
+		b = wire.AppendTag(b, 3, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(*m.Edition))
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *FeatureSetDefaults_FeatureSetEditionDefault) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 3 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Edition = new(Edition)
+			*m.Edition = Edition(v)
+		case num == 2 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			if m.Features == nil {
+				m.Features = new(FeatureSet)
+			}
+			if err := m.Features.Unmarshal(v); err != nil {
+				return err
+			}
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type SourceCodeInfo struct {
+	Location []*SourceCodeInfo_Location
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *SourceCodeInfo) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *SourceCodeInfo) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	for _, x := range m.Location {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		b = wire.AppendMessage(b, x)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *SourceCodeInfo) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			x := new(SourceCodeInfo_Location)
+			if err := x.Unmarshal(v); err != nil {
+				return err
+			}
+			m.Location = append(m.Location, x)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type SourceCodeInfo_Location struct {
+	Path                    []int32
+	Span                    []int32
+	LeadingComments         *string
+	TrailingComments        *string
+	LeadingDetachedComments []string
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *SourceCodeInfo_Location) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *SourceCodeInfo_Location) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if len(m.Path) > 0 {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		start := len(b)
+		for _, x := range m.Path {
+			b = wire.AppendVarint(b, uint64(x))
+		}
+		b = wire.InsertLength(b, start)
+	}
+	if len(m.Span) > 0 {
+		b = wire.AppendTag(b, 2, wire.BytesType)
+		start := len(b)
+		for _, x := range m.Span {
+			b = wire.AppendVarint(b, uint64(x))
+		}
+		b = wire.InsertLength(b, start)
+	}
+	if m.LeadingComments != nil {
+		b = wire.AppendTag(b, 3, wire.BytesType)
+		b = wire.AppendString(b, *m.LeadingComments)
+	}
+	if m.TrailingComments != nil {
+		b = wire.AppendTag(b, 4, wire.BytesType)
+		b = wire.AppendString(b, *m.TrailingComments)
+	}
+	for _, x := range m.LeadingDetachedComments {
+		b = wire.AppendTag(b, 6, wire.BytesType)
+		b = wire.AppendString(b, x)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *SourceCodeInfo_Location) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			for len(v) > 0 {
+				var k int
+				var x uint64
+				x, k = wire.ConsumeVarint(v)
+				if k < 0 {
+					return wire.ParseError(k)
+				}
+				m.Path = append(m.Path, int32(x))
+				v = v[k:]
+			}
+		case num == 1 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Path = append(m.Path, int32(v))
+		case num == 2 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			for len(v) > 0 {
+				var k int
+				var x uint64
+				x, k = wire.ConsumeVarint(v)
+				if k < 0 {
+					return wire.ParseError(k)
+				}
+				m.Span = append(m.Span, int32(x))
+				v = v[k:]
+			}
+		case num == 2 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Span = append(m.Span, int32(v))
+		case num == 3 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.LeadingComments = new(string)
+			*m.LeadingComments = string(v)
+		case num == 4 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.TrailingComments = new(string)
+			*m.TrailingComments = string(v)
+		case num == 6 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.LeadingDetachedComments = append(m.LeadingDetachedComments, string(v))
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type GeneratedCodeInfo struct {
+	Annotation []*GeneratedCodeInfo_Annotation
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *GeneratedCodeInfo) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *GeneratedCodeInfo) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	for _, x := range m.Annotation {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		b = wire.AppendMessage(b, x)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *GeneratedCodeInfo) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			x := new(GeneratedCodeInfo_Annotation)
+			if err := x.Unmarshal(v); err != nil {
+				return err
+			}
+			m.Annotation = append(m.Annotation, x)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type GeneratedCodeInfo_Annotation struct {
+	Path       []int32
+	SourceFile *string
+	Begin      *int32
+	End        *int32
+	Semantic   *GeneratedCodeInfo_Annotation_Semantic
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *GeneratedCodeInfo_Annotation) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *GeneratedCodeInfo_Annotation) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if len(m.Path) > 0 {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		start := len(b)
+		for _, x := range m.Path {
+			b = wire.AppendVarint(b, uint64(x))
+		}
+		b = wire.InsertLength(b, start)
+	}
+	if m.SourceFile != nil {
+		b = wire.AppendTag(b, 2, wire.BytesType)
+		b = wire.AppendString(b, *m.SourceFile)
+	}
+	if m.Begin != nil {
+		b = wire.AppendTag(b, 3, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(*m.Begin))
+	}
+	if m.End != nil {
+		b = wire.AppendTag(b, 4, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(*m.End))
+	}
+	if m.Semantic != nil {
+		b = wire.AppendTag(b, 5, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(*m.Semantic))
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *GeneratedCodeInfo_Annotation) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			for len(v) > 0 {
+				var k int
+				var x uint64
+				x, k = wire.ConsumeVarint(v)
+				if k < 0 {
+					return wire.ParseError(k)
+				}
+				m.Path = append(m.Path, int32(x))
+				v = v[k:]
+			}
+		case num == 1 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Path = append(m.Path, int32(v))
+		case num == 2 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.SourceFile = new(string)
+			*m.SourceFile = string(v)
+		case num == 3 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Begin = new(int32)
+			*m.Begin = int32(v)
+		case num == 4 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.End = new(int32)
+			*m.End = int32(v)
+		case num == 5 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Semantic = new(GeneratedCodeInfo_Annotation_Semantic)
+			*m.Semantic = GeneratedCodeInfo_Annotation_Semantic(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type GeneratedCodeInfo_Annotation_Semantic int32
+
+const (
+	GeneratedCodeInfo_Annotation_NONE  GeneratedCodeInfo_Annotation_Semantic = 0
+	GeneratedCodeInfo_Annotation_SET   GeneratedCodeInfo_Annotation_Semantic = 1
+	GeneratedCodeInfo_Annotation_ALIAS GeneratedCodeInfo_Annotation_Semantic = 2
+)
+
+func (x GeneratedCodeInfo_Annotation_Semantic) String() string {
+	switch x {
+	case GeneratedCodeInfo_Annotation_NONE:
+		return "NONE"
+	case GeneratedCodeInfo_Annotation_SET:
+		return "SET"
+	case GeneratedCodeInfo_Annotation_ALIAS:
+		return "ALIAS"
+	}
+	return strconv.Itoa(int(x))
+}
+
+type Duration struct {
+	Seconds int64
+	Nanos   int32
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *Duration) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *Duration) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Seconds != 0 {
+		b = wire.AppendTag(b, 1, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(m.Seconds))
+	}
+	if m.Nanos != 0 {
+		b = wire.AppendTag(b, 2, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(m.Nanos))
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *Duration) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Seconds = int64(v)
+		case num == 2 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Nanos = int32(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type Empty struct {
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *Empty) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *Empty) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *Empty) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		n = wire.ConsumeFieldValue(num, typ, b)
+		if n >= 0 {
+			m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type FieldMask struct {
+	Paths []string
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *FieldMask) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *FieldMask) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	for _, x := range m.Paths {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		b = wire.AppendString(b, x)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *FieldMask) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Paths = append(m.Paths, string(v))
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type SourceContext struct {
+	FileName string
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *SourceContext) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *SourceContext) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.FileName != "" {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		b = wire.AppendString(b, m.FileName)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *SourceContext) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.FileName = string(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type NullValue int32
+
+const (
+	NullValue_NULL_VALUE NullValue = 0
+)
+
+func (x NullValue) String() string {
+	switch x {
+	case NullValue_NULL_VALUE:
+		return "NULL_VALUE"
+	}
+	return strconv.Itoa(int(x))
+}
+
+type Struct struct {
+	Fields map[string]*Value
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *Struct) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *Struct) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	for k, v := range m.Fields {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		start := len(b)
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		b = wire.AppendString(b, k)
+		b = wire.AppendTag(b, 2, wire.BytesType)
+		b = wire.AppendMessage(b, v)
+		b = wire.InsertLength(b, start)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *Struct) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			var key string
+			val := new(Value)
+			for len(v) > 0 {
+				num, typ, k := wire.ConsumeTag(v)
+				if k < 0 {
+					return wire.ParseError(k)
+				}
+				v = v[k:]
+
+				switch {
+				case num == 1 && typ == wire.BytesType:
+					var x []byte
+					x, k = wire.ConsumeBytes(v)
+					if k < 0 {
+						break
+					}
+					key = string(x)
+				case num == 2 && typ == wire.BytesType:
+					var x []byte
+					x, k = wire.ConsumeBytes(v)
+					if k < 0 {
+						break
+					}
+					y := new(Value)
+					if err := y.Unmarshal(x); err != nil {
+						return err
+					}
+					val = y
+				default:
+					k = wire.ConsumeFieldValue(num, typ, v)
+				}
+				if k < 0 {
+					return wire.ParseError(k)
+				}
+				v = v[k:]
+			}
+			if m.Fields == nil {
+				m.Fields = make(map[string]*Value)
+			}
+			m.Fields[key] = val
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type Value struct {
+	Kind isValue_Kind
+
+	XXX_unrecognized []byte
+}
+
+type isValue_Kind interface {
+	isValue_Kind()
+}
+
+type Value_NullValue struct {
+	NullValue NullValue
+}
+
+type Value_NumberValue struct {
+	NumberValue float64
+}
+
+type Value_StringValue struct {
+	StringValue string
+}
+
+type Value_BoolValue struct {
+	BoolValue bool
+}
+
+type Value_StructValue struct {
+	StructValue *Struct
+}
+
+type Value_ListValue struct {
+	ListValue *ListValue
+}
+
+func (*Value_NullValue) isValue_Kind()   {}
+func (*Value_NumberValue) isValue_Kind() {}
+func (*Value_StringValue) isValue_Kind() {}
+func (*Value_BoolValue) isValue_Kind()   {}
+func (*Value_StructValue) isValue_Kind() {}
+func (*Value_ListValue) isValue_Kind()   {}
+
+// Marshal returns the wire format encoding of m.
+func (m *Value) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *Value) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	switch x := m.Kind.(type) {
+	case *Value_NullValue:
+		b = wire.AppendTag(b, 1, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(x.NullValue))
+	case *Value_NumberValue:
+		b = wire.AppendTag(b, 2, wire.Fixed64Type)
+		b = wire.AppendFixed64(b, math.Float64bits(x.NumberValue))
+	case *Value_StringValue:
+		b = wire.AppendTag(b, 3, wire.BytesType)
+		b = wire.AppendString(b, x.StringValue)
+	case *Value_BoolValue:
+		b = wire.AppendTag(b, 4, wire.VarintType)
+		b = wire.AppendVarint(b, wire.EncodeBool(x.BoolValue))
+	case *Value_StructValue:
+		b = wire.AppendTag(b, 5, wire.BytesType)
+		b = wire.AppendMessage(b, x.StructValue)
+	case *Value_ListValue:
+		b = wire.AppendTag(b, 6, wire.BytesType)
+		b = wire.AppendMessage(b, x.ListValue)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *Value) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Kind = &Value_NullValue{NullValue: NullValue(v)}
+		case num == 2 && typ == wire.Fixed64Type:
+			var v uint64
+			v, n = wire.ConsumeFixed64(b)
+			if n < 0 {
+				break
+			}
+			m.Kind = &Value_NumberValue{NumberValue: math.Float64frombits(v)}
+		case num == 3 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Kind = &Value_StringValue{StringValue: string(v)}
+		case num == 4 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Kind = &Value_BoolValue{BoolValue: wire.DecodeBool(v)}
+		case num == 5 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			x := new(Struct)
+			if err := x.Unmarshal(v); err != nil {
+				return err
+			}
+			m.Kind = &Value_StructValue{StructValue: x}
+		case num == 6 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			x := new(ListValue)
+			if err := x.Unmarshal(v); err != nil {
+				return err
+			}
+			m.Kind = &Value_ListValue{ListValue: x}
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type ListValue struct {
+	Values []*Value
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *ListValue) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *ListValue) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	for _, x := range m.Values {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		b = wire.AppendMessage(b, x)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *ListValue) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			x := new(Value)
+			if err := x.Unmarshal(v); err != nil {
+				return err
+			}
+			m.Values = append(m.Values, x)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type Timestamp struct {
+	Seconds int64
+	Nanos   int32
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *Timestamp) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *Timestamp) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Seconds != 0 {
+		b = wire.AppendTag(b, 1, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(m.Seconds))
+	}
+	if m.Nanos != 0 {
+		b = wire.AppendTag(b, 2, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(m.Nanos))
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *Timestamp) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Seconds = int64(v)
+		case num == 2 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Nanos = int32(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type Syntax int32
+
+const (
+	Syntax_SYNTAX_PROTO2   Syntax = 0
+	Syntax_SYNTAX_PROTO3   Syntax = 1
+	Syntax_SYNTAX_EDITIONS Syntax = 2
+)
+
+func (x Syntax) String() string {
+	switch x {
+	case Syntax_SYNTAX_PROTO2:
+		return "SYNTAX_PROTO2"
+	case Syntax_SYNTAX_PROTO3:
+		return "SYNTAX_PROTO3"
+	case Syntax_SYNTAX_EDITIONS:
+		return "SYNTAX_EDITIONS"
+	}
+	return strconv.Itoa(int(x))
+}
+
+type Type struct {
+	Name          string
+	Fields        []*Field
+	Oneofs        []string
+	Options       []*Option
+	SourceContext *SourceContext
+	Syntax        Syntax
+	Edition       string
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *Type) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *Type) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Name != "" {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		b = wire.AppendString(b, m.Name)
+	}
+	for _, x := range m.Fields {
+		b = wire.AppendTag(b, 2, wire.BytesType)
+		b = wire.AppendMessage(b, x)
+	}
+	for _, x := range m.Oneofs {
+		b = wire.AppendTag(b, 3, wire.BytesType)
+		b = wire.AppendString(b, x)
+	}
+	for _, x := range m.Options {
+		b = wire.AppendTag(b, 4, wire.BytesType)
+		b = wire.AppendMessage(b, x)
+	}
+	if m.SourceContext != nil {
+		b = wire.AppendTag(b, 5, wire.BytesType)
+		b = wire.AppendMessage(b, m.SourceContext)
+	}
+	if m.Syntax != 0 {
+		b = wire.AppendTag(b, 6, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(m.Syntax))
+	}
+	if m.Edition != "" {
+		b = wire.AppendTag(b, 7, wire.BytesType)
+		b = wire.AppendString(b, m.Edition)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *Type) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Name = string(v)
+		case num == 2 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			x := new(Field)
+			if err := x.Unmarshal(v); err != nil {
+				return err
+			}
+			m.Fields = append(m.Fields, x)
+		case num == 3 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Oneofs = append(m.Oneofs, string(v))
+		case num == 4 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			x := new(Option)
+			if err := x.Unmarshal(v); err != nil {
+				return err
+			}
+			m.Options = append(m.Options, x)
+		case num == 5 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			if m.SourceContext == nil {
+				m.SourceContext = new(SourceContext)
+			}
+			if err := m.SourceContext.Unmarshal(v); err != nil {
+				return err
+			}
+		case num == 6 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Syntax = Syntax(v)
+		case num == 7 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Edition = string(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type Field struct {
+	Kind         Field_Kind
+	Cardinality  Field_Cardinality
+	Number       int32
+	Name         string
+	TypeUrl      string
+	OneofIndex   int32
+	Packed       bool
+	Options      []*Option
+	JsonName     string
+	DefaultValue string
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *Field) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *Field) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Kind != 0 {
+		b = wire.AppendTag(b, 1, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(m.Kind))
+	}
+	if m.Cardinality != 0 {
+		b = wire.AppendTag(b, 2, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(m.Cardinality))
+	}
+	if m.Number != 0 {
+		b = wire.AppendTag(b, 3, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(m.Number))
+	}
+	if m.Name != "" {
+		b = wire.AppendTag(b, 4, wire.BytesType)
+		b = wire.AppendString(b, m.Name)
+	}
+	if m.TypeUrl != "" {
+		b = wire.AppendTag(b, 6, wire.BytesType)
+		b = wire.AppendString(b, m.TypeUrl)
+	}
+	if m.OneofIndex != 0 {
+		b = wire.AppendTag(b, 7, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(m.OneofIndex))
+	}
+	if m.Packed {
+		b = wire.AppendTag(b, 8, wire.VarintType)
+		b = wire.AppendVarint(b, wire.EncodeBool(m.Packed))
+	}
+	for _, x := range m.Options {
+		b = wire.AppendTag(b, 9, wire.BytesType)
+		b = wire.AppendMessage(b, x)
+	}
+	if m.JsonName != "" {
+		b = wire.AppendTag(b, 10, wire.BytesType)
+		b = wire.AppendString(b, m.JsonName)
+	}
+	if m.DefaultValue != "" {
+		b = wire.AppendTag(b, 11, wire.BytesType)
+		b = wire.AppendString(b, m.DefaultValue)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *Field) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Kind = Field_Kind(v)
+		case num == 2 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Cardinality = Field_Cardinality(v)
+		case num == 3 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Number = int32(v)
+		case num == 4 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Name = string(v)
+		case num == 6 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.TypeUrl = string(v)
+		case num == 7 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.OneofIndex = int32(v)
+		case num == 8 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Packed = wire.DecodeBool(v)
+		case num == 9 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			x := new(Option)
+			if err := x.Unmarshal(v); err != nil {
+				return err
+			}
+			m.Options = append(m.Options, x)
+		case num == 10 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.JsonName = string(v)
+		case num == 11 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.DefaultValue = string(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type Field_Kind int32
+
+const (
+	Field_TYPE_UNKNOWN  Field_Kind = 0
+	Field_TYPE_DOUBLE   Field_Kind = 1
+	Field_TYPE_FLOAT    Field_Kind = 2
+	Field_TYPE_INT64    Field_Kind = 3
+	Field_TYPE_UINT64   Field_Kind = 4
+	Field_TYPE_INT32    Field_Kind = 5
+	Field_TYPE_FIXED64  Field_Kind = 6
+	Field_TYPE_FIXED32  Field_Kind = 7
+	Field_TYPE_BOOL     Field_Kind = 8
+	Field_TYPE_STRING   Field_Kind = 9
+	Field_TYPE_GROUP    Field_Kind = 10
+	Field_TYPE_MESSAGE  Field_Kind = 11
+	Field_TYPE_BYTES    Field_Kind = 12
+	Field_TYPE_UINT32   Field_Kind = 13
+	Field_TYPE_ENUM     Field_Kind = 14
+	Field_TYPE_SFIXED32 Field_Kind = 15
+	Field_TYPE_SFIXED64 Field_Kind = 16
+	Field_TYPE_SINT32   Field_Kind = 17
+	Field_TYPE_SINT64   Field_Kind = 18
+)
+
+func (x Field_Kind) String() string {
+	switch x {
+	case Field_TYPE_UNKNOWN:
+		return "TYPE_UNKNOWN"
+	case Field_TYPE_DOUBLE:
+		return "TYPE_DOUBLE"
+	case Field_TYPE_FLOAT:
+		return "TYPE_FLOAT"
+	case Field_TYPE_INT64:
+		return "TYPE_INT64"
+	case Field_TYPE_UINT64:
+		return "TYPE_UINT64"
+	case Field_TYPE_INT32:
+		return "TYPE_INT32"
+	case Field_TYPE_FIXED64:
+		return "TYPE_FIXED64"
+	case Field_TYPE_FIXED32:
+		return "TYPE_FIXED32"
+	case Field_TYPE_BOOL:
+		return "TYPE_BOOL"
+	case Field_TYPE_STRING:
+		return "TYPE_STRING"
+	case Field_TYPE_GROUP:
+		return "TYPE_GROUP"
+	case Field_TYPE_MESSAGE:
+		return "TYPE_MESSAGE"
+	case Field_TYPE_BYTES:
+		return "TYPE_BYTES"
+	case Field_TYPE_UINT32:
+		return "TYPE_UINT32"
+	case Field_TYPE_ENUM:
+		return "TYPE_ENUM"
+	case Field_TYPE_SFIXED32:
+		return "TYPE_SFIXED32"
+	case Field_TYPE_SFIXED64:
+		return "TYPE_SFIXED64"
+	case Field_TYPE_SINT32:
+		return "TYPE_SINT32"
+	case Field_TYPE_SINT64:
+		return "TYPE_SINT64"
+	}
+	return strconv.Itoa(int(x))
+}
+
+type Field_Cardinality int32
+
+const (
+	Field_CARDINALITY_UNKNOWN  Field_Cardinality = 0
+	Field_CARDINALITY_OPTIONAL Field_Cardinality = 1
+	Field_CARDINALITY_REQUIRED Field_Cardinality = 2
+	Field_CARDINALITY_REPEATED Field_Cardinality = 3
+)
+
+func (x Field_Cardinality) String() string {
+	switch x {
+	case Field_CARDINALITY_UNKNOWN:
+		return "CARDINALITY_UNKNOWN"
+	case Field_CARDINALITY_OPTIONAL:
+		return "CARDINALITY_OPTIONAL"
+	case Field_CARDINALITY_REQUIRED:
+		return "CARDINALITY_REQUIRED"
+	case Field_CARDINALITY_REPEATED:
+		return "CARDINALITY_REPEATED"
+	}
+	return strconv.Itoa(int(x))
+}
+
+type Enum struct {
+	Name          string
+	Enumvalue     []*EnumValue
+	Options       []*Option
+	SourceContext *SourceContext
+	Syntax        Syntax
+	Edition       string
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *Enum) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *Enum) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Name != "" {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		b = wire.AppendString(b, m.Name)
+	}
+	for _, x := range m.Enumvalue {
+		b = wire.AppendTag(b, 2, wire.BytesType)
+		b = wire.AppendMessage(b, x)
+	}
+	for _, x := range m.Options {
+		b = wire.AppendTag(b, 3, wire.BytesType)
+		b = wire.AppendMessage(b, x)
+	}
+	if m.SourceContext != nil {
+		b = wire.AppendTag(b, 4, wire.BytesType)
+		b = wire.AppendMessage(b, m.SourceContext)
+	}
+	if m.Syntax != 0 {
+		b = wire.AppendTag(b, 5, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(m.Syntax))
+	}
+	if m.Edition != "" {
+		b = wire.AppendTag(b, 6, wire.BytesType)
+		b = wire.AppendString(b, m.Edition)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *Enum) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Name = string(v)
+		case num == 2 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			x := new(EnumValue)
+			if err := x.Unmarshal(v); err != nil {
+				return err
+			}
+			m.Enumvalue = append(m.Enumvalue, x)
+		case num == 3 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			x := new(Option)
+			if err := x.Unmarshal(v); err != nil {
+				return err
+			}
+			m.Options = append(m.Options, x)
+		case num == 4 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			if m.SourceContext == nil {
+				m.SourceContext = new(SourceContext)
+			}
+			if err := m.SourceContext.Unmarshal(v); err != nil {
+				return err
+			}
+		case num == 5 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Syntax = Syntax(v)
+		case num == 6 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Edition = string(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type EnumValue struct {
+	Name    string
+	Number  int32
+	Options []*Option
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *EnumValue) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *EnumValue) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Name != "" {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		b = wire.AppendString(b, m.Name)
+	}
+	if m.Number != 0 {
+		b = wire.AppendTag(b, 2, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(m.Number))
+	}
+	for _, x := range m.Options {
+		b = wire.AppendTag(b, 3, wire.BytesType)
+		b = wire.AppendMessage(b, x)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *EnumValue) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Name = string(v)
+		case num == 2 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Number = int32(v)
+		case num == 3 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			x := new(Option)
+			if err := x.Unmarshal(v); err != nil {
+				return err
+			}
+			m.Options = append(m.Options, x)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type Option struct {
+	Name  string
+	Value *Any
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *Option) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *Option) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Name != "" {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		b = wire.AppendString(b, m.Name)
+	}
+	if m.Value != nil {
+		b = wire.AppendTag(b, 2, wire.BytesType)
+		b = wire.AppendMessage(b, m.Value)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *Option) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Name = string(v)
+		case num == 2 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			if m.Value == nil {
+				m.Value = new(Any)
+			}
+			if err := m.Value.Unmarshal(v); err != nil {
+				return err
+			}
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type DoubleValue struct {
+	Value float64
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *DoubleValue) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *DoubleValue) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Value != 0 {
+		b = wire.AppendTag(b, 1, wire.Fixed64Type)
+		b = wire.AppendFixed64(b, math.Float64bits(m.Value))
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *DoubleValue) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.Fixed64Type:
+			var v uint64
+			v, n = wire.ConsumeFixed64(b)
+			if n < 0 {
+				break
+			}
+			m.Value = math.Float64frombits(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type FloatValue struct {
+	Value float32
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *FloatValue) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *FloatValue) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Value != 0 {
+		b = wire.AppendTag(b, 1, wire.Fixed32Type)
+		b = wire.AppendFixed32(b, math.Float32bits(m.Value))
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *FloatValue) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.Fixed32Type:
+			var v uint32
+			v, n = wire.ConsumeFixed32(b)
+			if n < 0 {
+				break
+			}
+			m.Value = math.Float32frombits(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type Int64Value struct {
+	Value int64
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *Int64Value) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *Int64Value) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Value != 0 {
+		b = wire.AppendTag(b, 1, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(m.Value))
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *Int64Value) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Value = int64(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type UInt64Value struct {
+	Value uint64
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *UInt64Value) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *UInt64Value) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Value != 0 {
+		b = wire.AppendTag(b, 1, wire.VarintType)
+		b = wire.AppendVarint(b, m.Value)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *UInt64Value) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Value = v
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type Int32Value struct {
+	Value int32
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *Int32Value) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *Int32Value) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Value != 0 {
+		b = wire.AppendTag(b, 1, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(m.Value))
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *Int32Value) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Value = int32(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type UInt32Value struct {
+	Value uint32
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *UInt32Value) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *UInt32Value) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Value != 0 {
+		b = wire.AppendTag(b, 1, wire.VarintType)
+		b = wire.AppendVarint(b, uint64(m.Value))
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *UInt32Value) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Value = uint32(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type BoolValue struct {
+	Value bool
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *BoolValue) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *BoolValue) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Value {
+		b = wire.AppendTag(b, 1, wire.VarintType)
+		b = wire.AppendVarint(b, wire.EncodeBool(m.Value))
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *BoolValue) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.VarintType:
+			var v uint64
+			v, n = wire.ConsumeVarint(b)
+			if n < 0 {
+				break
+			}
+			m.Value = wire.DecodeBool(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type StringValue struct {
+	Value string
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *StringValue) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *StringValue) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if m.Value != "" {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		b = wire.AppendString(b, m.Value)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *StringValue) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Value = string(v)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+type BytesValue struct {
+	Value []byte
+
+	XXX_unrecognized []byte
+}
+
+// Marshal returns the wire format encoding of m.
+func (m *BytesValue) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *BytesValue) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+	if len(m.Value) > 0 {
+		b = wire.AppendTag(b, 1, wire.BytesType)
+		b = wire.AppendBytes(b, m.Value)
+	}
+	b = append(b, m.XXX_unrecognized...)
+	return b
+}
+
+// Unmarshal merges the wire format encoding in b into m.
+func (m *BytesValue) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == 1 && typ == wire.BytesType:
+			var v []byte
+			v, n = wire.ConsumeBytes(b)
+			if n < 0 {
+				break
+			}
+			m.Value = append([]byte{}, v...)
+		default:
+			n = wire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+			}
+		}
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package wktpb_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/Clement-Jean/protein/gogen/internal/pbtest"
+	"github.com/Clement-Jean/protein/gogen/internal/wktpb"
+)
+
+func TestRoundTrip(t *testing.T) {
+	duration := &wktpb.Duration{Seconds: -3, Nanos: -500}
+	any := &wktpb.Any{TypeUrl: "type.googleapis.com/google.protobuf.Duration", Value: duration.Marshal()}
+
+	t.Run("any", func(t *testing.T) {
+		pbtest.RoundTrip(t, any)
+
+		var got wktpb.Duration
+		if err := got.Unmarshal(any.Value); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(duration, &got); diff != "" {
+			t.Errorf("(-want +got):\n%s", diff)
+		}
+	})
+	t.Run("time", func(t *testing.T) {
+		pbtest.RoundTrip(t, duration)
+		pbtest.RoundTrip(t, &wktpb.Timestamp{Seconds: 1700000000, Nanos: 999999999})
+	})
+	t.Run("empty and field mask", func(t *testing.T) {
+		pbtest.RoundTrip(t, &wktpb.Empty{})
+		pbtest.RoundTrip(t, &wktpb.FieldMask{Paths: []string{"a.b", "c"}})
+	})
+	t.Run("wrappers", func(t *testing.T) {
+		pbtest.RoundTrip(t, &wktpb.DoubleValue{Value: -1.25})
+		pbtest.RoundTrip(t, &wktpb.FloatValue{Value: 2.5})
+		pbtest.RoundTrip(t, &wktpb.Int64Value{Value: -1})
+		pbtest.RoundTrip(t, &wktpb.UInt64Value{Value: 1 << 63})
+		pbtest.RoundTrip(t, &wktpb.Int32Value{Value: -1})
+		pbtest.RoundTrip(t, &wktpb.UInt32Value{Value: 1 << 31})
+		pbtest.RoundTrip(t, &wktpb.BoolValue{Value: true})
+		pbtest.RoundTrip(t, &wktpb.StringValue{Value: "s"})
+		pbtest.RoundTrip(t, &wktpb.BytesValue{Value: []byte{0xff}})
+	})
+	t.Run("struct", func(t *testing.T) {
+		pbtest.RoundTrip(t, &wktpb.Struct{Fields: map[string]*wktpb.Value{
+			"null":   {Kind: &wktpb.Value_NullValue{}},
+			"number": {Kind: &wktpb.Value_NumberValue{NumberValue: 1.5}},
+			"string": {Kind: &wktpb.Value_StringValue{StringValue: "s"}},
+			"bool":   {Kind: &wktpb.Value_BoolValue{BoolValue: false}},
+			"struct": {Kind: &wktpb.Value_StructValue{StructValue: &wktpb.Struct{Fields: map[string]*wktpb.Value{
+				"nested": {Kind: &wktpb.Value_ListValue{ListValue: &wktpb.ListValue{}}},
+			}}}},
+			"list": {Kind: &wktpb.Value_ListValue{ListValue: &wktpb.ListValue{Values: []*wktpb.Value{
+				{Kind: &wktpb.Value_NumberValue{NumberValue: 1}},
+				{Kind: &wktpb.Value_StringValue{StringValue: ""}},
+			}}}},
+		}})
+	})
+	t.Run("type and api", func(t *testing.T) {
+		option := &wktpb.Option{Name: "deprecated", Value: any}
+		pbtest.RoundTrip(t, &wktpb.Type{
+			Name: "pkg.Message",
+			Fields: []*wktpb.Field{{
+				Kind:        wktpb.Field_TYPE_STRING,
+				Cardinality: wktpb.Field_CARDINALITY_REPEATED,
+				Number:      1,
+				Name:        "names",
+				Packed:      true,
+				Options:     []*wktpb.Option{option},
+				JsonName:    "names",
+			}},
+			Oneofs:        []string{"choice"},
+			SourceContext: &wktpb.SourceContext{FileName: "pkg/message.proto"},
+			Syntax:        wktpb.Syntax_SYNTAX_PROTO3,
+		})
+		pbtest.RoundTrip(t, &wktpb.Enum{
+			Name:      "pkg.Enum",
+			Enumvalue: []*wktpb.EnumValue{{Name: "A", Number: 0}, {Name: "B", Number: -1}},
+			Syntax:    wktpb.Syntax_SYNTAX_EDITIONS,
+			Edition:   "2023",
+		})
+		pbtest.RoundTrip(t, &wktpb.Api{
+			Name:    "pkg.Service",
+			Methods: []*wktpb.Method{{Name: "Get", RequestTypeUrl: "a", ResponseStreaming: true}},
+			Options: []*wktpb.Option{option},
+			Version: "v1",
+			Mixins:  []*wktpb.Mixin{{Name: "pkg.Other", Root: "other"}},
+		})
+	})
+	t.Run("descriptor", func(t *testing.T) {
+		pbtest.RoundTrip(t, &wktpb.FileDescriptorSet{File: []*wktpb.FileDescriptorProto{{
+			Name:             pbtest.Ptr("pkg/message.proto"),
+			Package:          pbtest.Ptr("pkg"),
+			Dependency:       []string{"google/protobuf/any.proto"},
+			PublicDependency: []int32{0},
+			MessageType: []*wktpb.DescriptorProto{{
+				Name: pbtest.Ptr("Message"),
+				Field: []*wktpb.FieldDescriptorProto{{
+					Name:     pbtest.Ptr("ids"),
+					Number:   pbtest.Ptr[int32](1),
+					Label:    pbtest.Ptr(wktpb.FieldDescriptorProto_LABEL_REPEATED),
+					Type:     pbtest.Ptr(wktpb.FieldDescriptorProto_TYPE_INT32),
+					JsonName: pbtest.Ptr("ids"),
+					Options: &wktpb.FieldOptions{
+						Packed:   pbtest.Ptr(true),
+						Ctype:    pbtest.Ptr(wktpb.FieldOptions_CORD),
+						Targets:  []wktpb.FieldOptions_OptionTargetType{wktpb.FieldOptions_TARGET_TYPE_FIELD},
+						Features: &wktpb.FeatureSet{FieldPresence: pbtest.Ptr(wktpb.FeatureSet_EXPLICIT)},
+					},
+				}},
+				NestedType:     []*wktpb.DescriptorProto{{Name: pbtest.Ptr("Nested")}},
+				ExtensionRange: []*wktpb.DescriptorProto_ExtensionRange{{Start: pbtest.Ptr[int32](100), End: pbtest.Ptr[int32](200)}},
+				ReservedName:   []string{"old"},
+			}},
+			Options: &wktpb.FileOptions{
+				GoPackage:   pbtest.Ptr("example.com/pkg"),
+				OptimizeFor: pbtest.Ptr(wktpb.FileOptions_LITE_RUNTIME),
+				UninterpretedOption: []*wktpb.UninterpretedOption{{
+					Name:             []*wktpb.UninterpretedOption_NamePart{{NamePart: pbtest.Ptr("custom"), IsExtension: pbtest.Ptr(true)}},
+					NegativeIntValue: pbtest.Ptr[int64](-1),
+					StringValue:      []byte{},
+				}},
+			},
+			SourceCodeInfo: &wktpb.SourceCodeInfo{Location: []*wktpb.SourceCodeInfo_Location{{
+				Path:                    []int32{4, 0, 2, 0},
+				Span:                    []int32{3, 2, 30},
+				LeadingDetachedComments: []string{" detached\n"},
+			}}},
+			Syntax:  pbtest.Ptr("editions"),
+			Edition: pbtest.Ptr(wktpb.Edition_EDITION_2023),
+		}}})
+	})
+}
+
+func TestEncoding(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  pbtest.Message
+		expected []byte
+	}{
+		{"timestamp", &wktpb.Timestamp{Seconds: 1, Nanos: 2}, []byte{0x08, 0x01, 0x10, 0x02}},
+		{"default values are not encoded", &wktpb.Int32Value{}, nil},
+		{"bool", &wktpb.BoolValue{Value: true}, []byte{0x08, 0x01}},
+		{"double", &wktpb.DoubleValue{Value: 1}, []byte{0x09, 0, 0, 0, 0, 0, 0, 0xf0, 0x3f}},
+		{"null value", &wktpb.Value{Kind: &wktpb.Value_NullValue{}}, []byte{0x08, 0x00}},
+		{
+			// packed = true in descriptor.proto
+			"packed path",
+			&wktpb.SourceCodeInfo_Location{Path: []int32{4, 0}},
+			[]byte{0x0a, 0x02, 0x04, 0x00},
+		},
+		{
+			// proto2 repeated fields are expanded by default
+			"expanded dependencies",
+			&wktpb.FileDescriptorProto{PublicDependency: []int32{1, 2}},
+			[]byte{0x50, 0x01, 0x50, 0x02},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if diff := cmp.Diff(test.expected, test.message.Marshal()); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,435 @@
+package gogen
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/Clement-Jean/protein/ast"
+	"github.com/Clement-Jean/protein/features"
+	"github.com/Clement-Jean/protein/lexer"
+	"github.com/Clement-Jean/protein/symbols"
+)
+
+// value describes how a single value is encoded. The encode format
+// converts the Go value to the argument of the append function, the
+// decode format converts the consumed value to the Go type.
+type value struct {
+	goType   string
+	wireType string // Varint, Fixed32, Fixed64 or Bytes
+	encode   string
+	decode   string
+	message  string // the Go name of the message type, if any
+}
+
+var scalars = map[lexer.TokenKind]value{
+	lexer.TokenKindTypeInt32:    {"int32", "Varint", "uint64(%s)", "int32(%s)", ""},
+	lexer.TokenKindTypeInt64:    {"int64", "Varint", "uint64(%s)", "int64(%s)", ""},
+	lexer.TokenKindTypeUint32:   {"uint32", "Varint", "uint64(%s)", "uint32(%s)", ""},
+	lexer.TokenKindTypeUint64:   {"uint64", "Varint", "%s", "%s", ""},
+	lexer.TokenKindTypeSint32:   {"int32", "Varint", "wire.EncodeZigZag(int64(%s))", "int32(wire.DecodeZigZag(uint64(uint32(%s))))", ""},
+	lexer.TokenKindTypeSint64:   {"int64", "Varint", "wire.EncodeZigZag(%s)", "wire.DecodeZigZag(%s)", ""},
+	lexer.TokenKindTypeBool:     {"bool", "Varint", "wire.EncodeBool(%s)", "wire.DecodeBool(%s)", ""},
+	lexer.TokenKindTypeFixed32:  {"uint32", "Fixed32", "%s", "%s", ""},
+	lexer.TokenKindTypeSfixed32: {"int32", "Fixed32", "uint32(%s)", "int32(%s)", ""},
+	lexer.TokenKindTypeFloat:    {"float32", "Fixed32", "math.Float32bits(%s)", "math.Float32frombits(%s)", ""},
+	lexer.TokenKindTypeFixed64:  {"uint64", "Fixed64", "%s", "%s", ""},
+	lexer.TokenKindTypeSfixed64: {"int64", "Fixed64", "uint64(%s)", "int64(%s)", ""},
+	lexer.TokenKindTypeDouble:   {"float64", "Fixed64", "math.Float64bits(%s)", "math.Float64frombits(%s)", ""},
+	lexer.TokenKindTypeString:   {"string", "Bytes", "%s", "string(%s)", ""},
+	lexer.TokenKindTypeBytes:    {"[]byte", "Bytes", "%s", "append([]byte{}, %s...)", ""},
+}
+
+// raw is the type returned by the consume function.
+func (v value) raw() string {
+	switch v.wireType {
+	case "Fixed32":
+		return "uint32"
+	case "Bytes":
+		return "[]byte"
+	}
+	return "uint64"
+}
+
+// append returns the statement appending the Go value expr.
+func (v value) append(expr string) string {
+	fn := v.wireType
+	switch {
+	case v.message != "":
+		fn = "Message"
+	case v.goType == "string":
+		fn = "String"
+	}
+	return fmt.Sprintf("b = wire.Append%s(b, %s)", fn, fmt.Sprintf(v.encode, expr))
+}
+
+// nonZero returns the condition under which expr is encoded when the
+// field has no presence.
+func (v value) nonZero(expr string) string {
+	switch v.goType {
+	case "bool":
+		return expr
+	case "string":
+		return expr + ` != ""`
+	case "[]byte":
+		return "len(" + expr + ") > 0"
+	}
+	return expr + " != 0"
+}
+
+type field struct {
+	*ast.Field
+	name     string // the Go name
+	number   int64
+	value    value
+	key      value // for maps
+	repeated bool
+	packed   bool
+	explicit bool // has presence, nil when not set
+	pointer  bool // explicit scalars, except bytes
+	oneof    *oneof
+	wrapper  string // the type wrapping the oneof fields
+}
+
+func (f *field) goType() string {
+	switch {
+	case f.Map != nil:
+		return "map[" + f.key.goType + "]" + f.value.goType
+	case f.repeated:
+		return "[]" + f.value.goType
+	case f.pointer:
+		return "*" + f.value.goType
+	}
+	return f.value.goType
+}
+
+type oneof struct {
+	name   string // the Go name of the struct field
+	iface  string
+	fields []*field
+}
+
+// methods are the names of the generated methods, fields with the
+// same name are suffixed with an underscore.
+var methods = []string{"Marshal", "MarshalAppend", "Unmarshal"}
+
+func (g *generator) valueType(scope string, typ ast.Type) (value, bool) {
+	if typ.IsScalar() {
+		v, ok := scalars[typ.Scalar]
+		return v, ok
+	}
+
+	sym, ok := g.table.Resolve(scope, typ.Name)
+	if !ok {
+		g.errs = append(g.errs, fmt.Errorf("unknown type %q in %q", typ.Name.String(), scope))
+		return value{}, false
+	}
+	name, ok := g.names[sym.Name]
+	if !ok {
+		g.errs = append(g.errs, fmt.Errorf("type %q used in %q is not generated", sym.Name, scope))
+		return value{}, false
+	}
+
+	if sym.Kind == symbols.KindEnum {
+		return value{goType: name, wireType: "Varint", encode: "uint64(%s)", decode: name + "(%s)"}, true
+	}
+	return value{goType: "*" + name, wireType: "Bytes", encode: "%s", message: name}, true
+}
+
+func (g *generator) fields(scope, goName string, res *features.Resolution, msg *ast.Message) ([]*field, []*oneof) {
+	var (
+		fields []*field
+		oneofs []*oneof
+	)
+	byOneof := make(map[*ast.Oneof]*oneof)
+
+	for _, f := range msg.Fields {
+		fd := &field{Field: f, name: camelCase(f.Name.Text)}
+		if slices.Contains(methods, fd.name) {
+			fd.name += "_"
+		}
+
+		number, err := f.Number.Int()
+		if err != nil {
+			g.errs = append(g.errs, fmt.Errorf("invalid number %s for %q", f.Number.Text, join(scope, f.Name.Text)))
+			continue
+		}
+		fd.number = number
+
+		set := res.Field(f)
+		if set.MessageEncoding == features.MessageEncodingDelimited {
+			g.errs = append(g.errs, fmt.Errorf("%q uses the delimited encoding, which is not supported", join(scope, f.Name.Text)))
+			continue
+		}
+
+		var ok bool
+		if f.Map != nil {
+			keyOK := false
+			fd.key, keyOK = g.valueType(scope, f.Map.Key)
+			fd.value, ok = g.valueType(scope, f.Map.Value)
+			ok = ok && keyOK
+		} else {
+			fd.value, ok = g.valueType(scope, f.Type)
+		}
+		if !ok {
+			continue
+		}
+
+		fd.repeated = f.Label == ast.LabelRepeated
+		fd.packed = fd.repeated && set.RepeatedFieldEncoding == features.RepeatedFieldEncodingPacked && fd.value.wireType != "Bytes"
+		if !fd.repeated && f.Map == nil && f.Oneof == nil {
+			fd.explicit = fd.value.message != "" || set.FieldPresence != features.FieldPresenceImplicit
+			fd.pointer = fd.explicit && fd.value.message == "" && fd.value.goType != "[]byte"
+		}
+
+		if f.Oneof != nil {
+			o, ok := byOneof[f.Oneof]
+			if !ok {
+				o = &oneof{name: camelCase(f.Oneof.Name.Text)}
+				if slices.Contains(methods, o.name) {
+					o.name += "_"
+				}
+				o.iface = "is" + goName + "_" + o.name
+				byOneof[f.Oneof] = o
+				oneofs = append(oneofs, o)
+			}
+			fd.oneof = o
+			fd.wrapper = goName + "_" + fd.name
+			for g.types[fd.wrapper] {
+				fd.wrapper += "_"
+			}
+			g.types[fd.wrapper] = true
+			o.fields = append(o.fields, fd)
+		}
+		fields = append(fields, fd)
+	}
+	return fields, oneofs
+}
+
+// message generates the struct of msg, declared in scope, along with
+// its oneofs, methods and nested types.
+func (g *generator) message(scope string, res *features.Resolution, msg *ast.Message) {
+	name := join(scope, msg.Name.Text)
+	goName := g.names[name]
+	fields, oneofs := g.fields(name, goName, res, msg)
+
+	g.printf("\ntype %s struct {\n", goName)
+	declared := make(map[*oneof]bool)
+	for _, f := range fields {
+		if f.oneof == nil {
+			g.printf("%s %s\n", f.name, f.goType())
+		} else if !declared[f.oneof] {
+			declared[f.oneof] = true
+			g.printf("%s %s\n", f.oneof.name, f.oneof.iface)
+		}
+	}
+	g.printf("\nXXX_unrecognized []byte\n}\n")
+
+	for _, o := range oneofs {
+		g.printf("\ntype %s interface {\n%s()\n}\n", o.iface, o.iface)
+		for _, f := range o.fields {
+			g.printf("\ntype %s struct {\n%s %s\n}\n", f.wrapper, f.name, f.value.goType)
+		}
+		g.printf("\n")
+		for _, f := range o.fields {
+			g.printf("func (*%s) %s() {}\n", f.wrapper, o.iface)
+		}
+	}
+
+	g.marshal(goName, fields)
+	g.unmarshal(goName, fields)
+
+	for _, enum := range msg.Enums {
+		g.enum(name, goName, enum)
+	}
+	for _, nested := range msg.Messages {
+		g.message(name, res, nested)
+	}
+}
+
+// tagged returns the statements appending the field number and the
+// Go value expr.
+func tagged(number int64, v value, expr string) string {
+	return fmt.Sprintf("b = wire.AppendTag(b, %d, wire.%sType)\n%s", number, v.wireType, v.append(expr))
+}
+
+func (g *generator) marshal(goName string, fields []*field) {
+	g.printf(`
+// Marshal returns the wire format encoding of m.
+func (m *%[1]s) Marshal() []byte {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the wire format encoding of m to b.
+func (m *%[1]s) MarshalAppend(b []byte) []byte {
+	if m == nil {
+		return b
+	}
+`, goName)
+
+	// the fields are encoded in the order of their numbers
+	sorted := slices.SortedStableFunc(slices.Values(fields), func(a, b *field) int {
+		return cmp.Compare(a.number, b.number)
+	})
+	encoded := make(map[*oneof]bool)
+	for _, f := range sorted {
+		expr := "m." + f.name
+		switch {
+		case f.oneof != nil:
+			if encoded[f.oneof] {
+				continue
+			}
+			encoded[f.oneof] = true
+
+			g.printf("switch x := m.%s.(type) {\n", f.oneof.name)
+			for _, member := range f.oneof.fields {
+				g.printf("case *%s:\n%s\n", member.wrapper, tagged(member.number, member.value, "x."+member.name))
+			}
+			g.printf("}\n")
+		case f.Map != nil:
+			g.printf("for k, v := range %s {\n", expr)
+			g.printf("b = wire.AppendTag(b, %d, wire.BytesType)\nstart := len(b)\n", f.number)
+			g.printf("%s\n%s\n", tagged(1, f.key, "k"), tagged(2, f.value, "v"))
+			g.printf("b = wire.InsertLength(b, start)\n}\n")
+		case f.packed:
+			g.printf("if len(%s) > 0 {\n", expr)
+			g.printf("b = wire.AppendTag(b, %d, wire.BytesType)\nstart := len(b)\n", f.number)
+			g.printf("for _, x := range %s {\n%s\n}\n", expr, f.value.append("x"))
+			g.printf("b = wire.InsertLength(b, start)\n}\n")
+		case f.repeated:
+			g.printf("for _, x := range %s {\n%s\n}\n", expr, tagged(f.number, f.value, "x"))
+		case f.pointer:
+			g.printf("if %s != nil {\n%s\n}\n", expr, tagged(f.number, f.value, "*"+expr))
+		case f.explicit:
+			g.printf("if %s != nil {\n%s\n}\n", expr, tagged(f.number, f.value, expr))
+		default:
+			g.printf("if %s {\n%s\n}\n", f.value.nonZero(expr), tagged(f.number, f.value, expr))
+		}
+	}
+	g.printf("b = append(b, m.XXX_unrecognized...)\nreturn b\n}\n")
+}
+
+// decode consumes a value from buf, with n the number of bytes read,
+// and stores it. The raw value is named tmp, messages are decoded in
+// a new message named elem. onErr is executed when n is negative.
+func (g *generator) decode(v value, buf, n, tmp, elem, onErr string, store func(string) string) {
+	g.printf("var %s %s\n%s, %s = wire.Consume%s(%s)\n", tmp, v.raw(), tmp, n, v.wireType, buf)
+	g.printf("if %s < 0 {\n%s\n}\n", n, onErr)
+	if v.message != "" {
+		g.printf("%s := new(%s)\nif err := %s.Unmarshal(%s); err != nil {\nreturn err\n}\n", elem, v.message, elem, tmp)
+		g.printf("%s\n", store(elem))
+		return
+	}
+	g.printf("%s\n", store(fmt.Sprintf(v.decode, tmp)))
+}
+
+func (g *generator) unmarshal(goName string, fields []*field) {
+	g.printf(`
+// Unmarshal merges the wire format encoding in b into m.
+func (m *%s) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		start := b
+		num, typ, n := wire.ConsumeTag(b)
+		if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+
+`, goName)
+
+	// the unknown fields, tag included, are kept as they are
+	unknown := `n = wire.ConsumeFieldValue(num, typ, b)
+if n >= 0 {
+	m.XXX_unrecognized = append(m.XXX_unrecognized, start[:len(start)-len(b)+n]...)
+}
+`
+	if len(fields) == 0 {
+		g.printf("%s", unknown)
+	} else {
+		g.printf("switch {\n")
+		for _, f := range fields {
+			g.unmarshalField(f)
+		}
+		g.printf("default:\n%s}\n", unknown)
+	}
+
+	g.printf(`if n < 0 {
+			return wire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+`)
+}
+
+func (g *generator) unmarshalField(f *field) {
+	expr := "m." + f.name
+	caseFor := func(v value) {
+		g.printf("case num == %d && typ == wire.%sType:\n", f.number, v.wireType)
+	}
+	appendTo := func(e string) string { return fmt.Sprintf("%s = append(%s, %s)", expr, expr, e) }
+
+	switch {
+	case f.oneof != nil:
+		caseFor(f.value)
+		g.decode(f.value, "b", "n", "v", "x", "break", func(e string) string {
+			return fmt.Sprintf("m.%s = &%s{%s: %s}", f.oneof.name, f.wrapper, f.name, e)
+		})
+	case f.Map != nil:
+		g.printf("case num == %d && typ == wire.BytesType:\n", f.number)
+		g.printf("var v []byte\nv, n = wire.ConsumeBytes(b)\nif n < 0 {\nbreak\n}\n")
+		g.printf("var key %s\n", f.key.goType)
+		if f.value.message != "" {
+			g.printf("val := new(%s)\n", f.value.message)
+		} else {
+			g.printf("var val %s\n", f.value.goType)
+		}
+		g.printf(`for len(v) > 0 {
+num, typ, k := wire.ConsumeTag(v)
+if k < 0 {
+	return wire.ParseError(k)
+}
+v = v[k:]
+
+switch {
+case num == 1 && typ == wire.%sType:
+`, f.key.wireType)
+		g.decode(f.key, "v", "k", "x", "", "break", func(e string) string { return "key = " + e })
+		g.printf("case num == 2 && typ == wire.%sType:\n", f.value.wireType)
+		g.decode(f.value, "v", "k", "x", "y", "break", func(e string) string { return "val = " + e })
+		g.printf(`default:
+	k = wire.ConsumeFieldValue(num, typ, v)
+}
+if k < 0 {
+	return wire.ParseError(k)
+}
+v = v[k:]
+}
+`)
+		g.printf("if %s == nil {\n%s = make(%s)\n}\n%s[key] = val\n", expr, expr, f.goType(), expr)
+	case f.repeated:
+		if f.value.wireType != "Bytes" {
+			// packed and expanded encodings are both accepted
+			g.printf("case num == %d && typ == wire.BytesType:\n", f.number)
+			g.printf("var v []byte\nv, n = wire.ConsumeBytes(b)\nfor len(v) > 0 {\nvar k int\n")
+			g.decode(f.value, "v", "k", "x", "", "return wire.ParseError(k)", appendTo)
+			g.printf("v = v[k:]\n}\n")
+		}
+		caseFor(f.value)
+		g.decode(f.value, "b", "n", "v", "x", "break", appendTo)
+	case f.value.message != "":
+		caseFor(f.value)
+		g.printf("var v []byte\nv, n = wire.ConsumeBytes(b)\nif n < 0 {\nbreak\n}\n")
+		g.printf("if %s == nil {\n%s = new(%s)\n}\n", expr, expr, f.value.message)
+		g.printf("if err := %s.Unmarshal(v); err != nil {\nreturn err\n}\n", expr)
+	case f.pointer:
+		caseFor(f.value)
+		g.decode(f.value, "b", "n", "v", "", "break", func(e string) string {
+			return fmt.Sprintf("%s = new(%s)\n*%s = %s", expr, f.value.goType, expr, e)
+		})
+	default:
+		caseFor(f.value)
+		g.decode(f.value, "b", "n", "v", "", "break", func(e string) string { return expr + " = " + e })
+	}
+}
@@ -0,0 +1,274 @@
+// Package header reads the header of proto files without parsing
+// their definitions. This only pays off on files with definitions:
+// on small files (e.g. empty.proto, duration.proto), Scan is no faster
+// than a full lex and parse.
+package header
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Clement-Jean/protein/ast"
+	"github.com/Clement-Jean/protein/lexer"
+	"github.com/Clement-Jean/protein/source"
+)
+
+// Header is the part of a file before the first definition.
+type Header struct {
+	Syntax  string
+	Edition string
+	Package string
+	Imports []Import
+	Options []Option
+}
+
+type Import struct {
+	Path   string
+	Public bool
+	Weak   bool
+}
+
+// Option is a file option. Name is written as in the source
+// (e.g. (my.ext).field) and string values are unquoted.
+type Option struct {
+	Name  string
+	Value string
+}
+
+// Error is a malformed statement starting at Offset.
+type Error struct {
+	Msg    string
+	Offset uint32
+}
+
+func (e *Error) Error() string {
+	return e.Msg
+}
+
+// Option returns the value of the option called name.
+func (h *Header) Option(name string) (string, bool) {
+	for _, opt := range h.Options {
+		if opt.Name == name {
+			return opt.Value, true
+		}
+	}
+	return "", false
+}
+
+type token struct {
+	kind lexer.TokenKind
+	text []byte
+}
+
+type scanner struct {
+	src  *source.Buffer
+	h    *Header
+	errs []error
+
+	stmt  []token
+	start uint32
+	depth int // depth of an aggregate value
+}
+
+// Scan reads the header of src. It only lexes the file until the
+// first message, enum, service or extend.
+func Scan(src *source.Buffer) (*Header, []error) {
+	l, err := lexer.NewFromSource(src)
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	s := &scanner{
+		src: src,
+		h:   &Header{},
+	}
+	for tok, err := range l.Tokens() {
+		if err != nil {
+			s.errs = append(s.errs, err)
+		}
+
+		if !s.add(tok) {
+			break
+		}
+	}
+
+	if len(s.stmt) != 0 {
+		s.error("unterminated statement")
+	}
+	return s.h, s.errs
+}
+
+// ScanFile reads the header of filename.
+func ScanFile(filename string) (*Header, []error) {
+	src, err := source.NewFromFile(filename)
+	if err != nil {
+		return nil, []error{err}
+	}
+	defer src.Close()
+
+	return Scan(src)
+}
+
+func (s *scanner) error(msg string) {
+	s.errs = append(s.errs, &Error{Msg: msg, Offset: s.start})
+	s.stmt = s.stmt[:0]
+}
+
+// add processes tok. It returns false when the header ends.
+func (s *scanner) add(tok lexer.TokenInfo) bool {
+	kind := tok.Kind
+	switch kind {
+	case lexer.TokenKindBOF, lexer.TokenKindComment, lexer.TokenKindError:
+		return true
+	case lexer.TokenKindEOF:
+		return false
+	case lexer.TokenKindMessage, lexer.TokenKindEnum, lexer.TokenKindService, lexer.TokenKindExtend:
+		if len(s.stmt) == 0 {
+			return false
+		}
+	}
+
+	text := s.src.Range(tok.Offset, tok.End())
+	if len(s.stmt) == 0 {
+		s.start = tok.Offset
+	}
+
+	switch kind {
+	case lexer.TokenKindLeftBrace:
+		s.depth++
+	case lexer.TokenKindRightBrace:
+		s.depth--
+	}
+
+	s.stmt = append(s.stmt, token{kind: kind, text: text})
+	if kind == lexer.TokenKindSemicolon && s.depth <= 0 {
+		s.statement()
+		s.stmt = s.stmt[:0]
+		s.depth = 0
+	}
+	return true
+}
+
+func unquote(tok token) (string, bool) {
+	if tok.kind != lexer.TokenKindStr {
+		return "", false
+	}
+
+	v := ast.Value{Token: ast.Token{Kind: tok.kind, Text: string(tok.text)}}
+	str, err := v.String()
+	return str, err == nil
+}
+
+// fullIdent returns the name written by toks, identifiers separated
+// by dots (e.g. a.b.c).
+func fullIdent(toks []token) (string, bool) {
+	if len(toks)%2 == 0 {
+		return "", false
+	}
+
+	var b strings.Builder
+	for i, tok := range toks {
+		if i%2 == 0 && !tok.kind.IsIdentifier() || i%2 == 1 && tok.kind != lexer.TokenKindDot {
+			return "", false
+		}
+		b.Write(tok.text)
+	}
+	return b.String(), true
+}
+
+func (s *scanner) statement() {
+	stmt := s.stmt[:len(s.stmt)-1] // without ;
+	if len(stmt) == 0 {
+		return
+	}
+
+	switch stmt[0].kind {
+	case lexer.TokenKindSyntax, lexer.TokenKindEdition:
+		if len(stmt) != 3 || stmt[1].kind != lexer.TokenKindEqual {
+			s.error(fmt.Sprintf("invalid %s statement", stmt[0].kind))
+			return
+		}
+
+		v, ok := unquote(stmt[2])
+		if !ok {
+			s.error(fmt.Sprintf("invalid %s statement", stmt[0].kind))
+			return
+		}
+
+		if stmt[0].kind == lexer.TokenKindSyntax {
+			s.h.Syntax = v
+		} else {
+			s.h.Edition = v
+		}
+	case lexer.TokenKindPackage:
+		name, ok := fullIdent(stmt[1:])
+		if !ok {
+			s.error("invalid package statement")
+			return
+		}
+		s.h.Package = name
+	case lexer.TokenKindImport:
+		imp := Import{}
+		rest := stmt[1:]
+		if len(rest) == 2 {
+			switch rest[0].kind {
+			case lexer.TokenKindPublic:
+				imp.Public = true
+			case lexer.TokenKindWeak:
+				imp.Weak = true
+			}
+			rest = rest[1:]
+		}
+
+		path, ok := "", len(rest) == 1
+		if ok {
+			path, ok = unquote(rest[0])
+		}
+		if !ok {
+			s.error("invalid import statement")
+			return
+		}
+		imp.Path = path
+		s.h.Imports = append(s.h.Imports, imp)
+	case lexer.TokenKindOption:
+		s.option(stmt[1:])
+	default:
+		s.error(fmt.Sprintf("unexpected %s", stmt[0].kind))
+	}
+}
+
+func (s *scanner) option(stmt []token) {
+	eq := -1
+	for i, tok := range stmt {
+		if tok.kind == lexer.TokenKindEqual {
+			eq = i
+			break
+		}
+	}
+	if eq <= 0 || eq == len(stmt)-1 {
+		s.error("invalid option statement")
+		return
+	}
+
+	var name strings.Builder
+	for _, tok := range stmt[:eq] {
+		name.Write(tok.text)
+	}
+
+	var value string
+	if v, ok := unquote(stmt[eq+1]); ok && len(stmt) == eq+2 {
+		value = v
+	} else {
+		// constants are kept as is, aggregates are joined
+		var b strings.Builder
+		for i, tok := range stmt[eq+1:] {
+			if i != 0 {
+				b.WriteByte(' ')
+			}
+			b.Write(tok.text)
+		}
+		value = b.String()
+	}
+
+	s.h.Options = append(s.h.Options, Option{Name: name.String(), Value: value})
+}
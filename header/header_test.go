@@ -0,0 +1,164 @@
+package header_test
+
+import (
+	"io/fs"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/Clement-Jean/protein/header"
+	"github.com/Clement-Jean/protein/lexer"
+	"github.com/Clement-Jean/protein/parser"
+	"github.com/Clement-Jean/protein/source"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var (
+	_, b, _, _ = runtime.Caller(0)
+	basepath   = filepath.Dir(b)
+)
+
+func TestScan(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected *header.Header
+		errs     []string
+	}{
+		{
+			name: "full",
+			input: `// comment
+syntax = "proto3";
+
+package google.protobuf;
+
+import "a.proto";
+import public "b.proto";
+import weak 'c.proto';
+
+option go_package = "google.golang.org/protobuf/types/known/anypb";
+option java_multiple_files = true;
+option (my.ext).field = -1;
+option (agg) = { a: 1 };
+
+message Any {}
+import "ignored.proto";
+`,
+			expected: &header.Header{
+				Syntax:  "proto3",
+				Package: "google.protobuf",
+				Imports: []header.Import{
+					{Path: "a.proto"},
+					{Path: "b.proto", Public: true},
+					{Path: "c.proto", Weak: true},
+				},
+				Options: []header.Option{
+					{Name: "go_package", Value: "google.golang.org/protobuf/types/known/anypb"},
+					{Name: "java_multiple_files", Value: "true"},
+					{Name: "(my.ext).field", Value: "-1"},
+					{Name: "(agg)", Value: "{ a : 1 }"},
+				},
+			},
+		},
+		{
+			name:     "edition",
+			input:    `edition = "2023"; package a; extend Foo { int32 bar = 1; }`,
+			expected: &header.Header{Edition: "2023", Package: "a"},
+		},
+		{
+			name:     "empty",
+			input:    ``,
+			expected: &header.Header{},
+		},
+		{
+			name:     "invalid",
+			input:    "syntax = proto3;\nimport ;\npackage a\n",
+			expected: &header.Header{},
+			errs: []string{
+				"invalid syntax statement",
+				"invalid import statement",
+				"unterminated statement",
+			},
+		},
+		{
+			name:     "invalid_package",
+			input:    "package;\npackage a.;\npackage .a;\npackage a b;\npackage \"a\";",
+			expected: &header.Header{},
+			errs: []string{
+				"invalid package statement",
+				"invalid package statement",
+				"invalid package statement",
+				"invalid package statement",
+				"invalid package statement",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			src, err := source.NewFromReader(strings.NewReader(test.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			h, errs := header.Scan(src)
+
+			var got []string
+			for _, err := range errs {
+				got = append(got, err.Error())
+			}
+			if diff := cmp.Diff(test.errs, got); diff != "" {
+				t.Errorf("%s errors mismatch (-want +got):\n%s", t.Name(), diff)
+			}
+			if diff := cmp.Diff(test.expected, h); diff != "" {
+				t.Errorf("%s mismatch (-want +got):\n%s", t.Name(), diff)
+			}
+		})
+	}
+}
+
+func findFiles(root, ext string) (files []string) {
+	filepath.WalkDir(root, func(s string, d fs.DirEntry, e error) error {
+		if e != nil {
+			return e
+		}
+		if filepath.Ext(d.Name()) == ext {
+			files = append(files, s)
+		}
+		return nil
+	})
+	return files
+}
+
+func BenchmarkScan(b *testing.B) {
+	corpusPath := filepath.Join(basepath, "../corpus/")
+
+	for _, s := range findFiles(corpusPath, ".proto") {
+		src, err := source.NewFromFile(s)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Cleanup(func() { src.Close() })
+
+		b.Run("header/"+filepath.Base(s), func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				header.Scan(src)
+			}
+		})
+
+		b.Run("parser/"+filepath.Base(s), func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				l, err := lexer.NewFromSource(src)
+				if err != nil {
+					b.Fatal(err)
+				}
+				toks, _ := l.Lex()
+				parser.New(toks).Parse()
+			}
+		})
+	}
+}
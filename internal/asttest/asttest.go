@@ -0,0 +1,49 @@
+// Package asttest builds the files used by the tests of the packages
+// working on the AST.
+package asttest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Clement-Jean/protein/ast"
+	"github.com/Clement-Jean/protein/lexer"
+	"github.com/Clement-Jean/protein/parser"
+	"github.com/Clement-Jean/protein/source"
+)
+
+// NewFile builds the file of input, the lexing and parsing errors
+// are fatal.
+func NewFile(t testing.TB, input string) *ast.File {
+	t.Helper()
+
+	file, errs := Parse(t, input)
+	if len(errs) != 0 {
+		t.Fatal(errs)
+	}
+	return file
+}
+
+// Parse builds the file of input and returns the parsing errors, the
+// nodes in error are not part of the file. The lexing errors are fatal.
+func Parse(t testing.TB, input string) (*ast.File, []error) {
+	t.Helper()
+
+	src, err := source.NewFromReader(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := lexer.NewFromSource(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tb, errs := l.Lex()
+	if len(errs) != 0 {
+		t.Fatal(errs)
+	}
+
+	pt, errs := parser.New(tb).Parse()
+	return ast.New(src, tb, pt), errs
+}
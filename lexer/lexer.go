@@ -3,36 +3,47 @@ package lexer
 import (
 	"bytes"
 	"io"
+	"io/fs"
+	"iter"
+	"slices"
 	"strings"
 
 	"github.com/Clement-Jean/protein/source"
 )
 
 type Lexer struct {
-	src         *source.Buffer
-	toks        *TokenizedBuffer
-	errs        []error
-	currLineIdx LineIdx
-	srcPos      uint32 // the idx at which the file content really starts
-	tokPos      uint32 // the begining of a token
-	readPos     uint32 // the idx we are reading at in src
+	src     *source.Buffer
+	toks    *TokenizedBuffer
+	errs    []error
+	srcPos  uint32 // the idx at which the file content really starts
+	tokPos  uint32 // the begining of a token
+	readPos uint32 // the idx we are reading at in src
 }
 
 func NewFromSource(src *source.Buffer) (*Lexer, error) {
+	return NewFromSourceWithBuffer(src, &TokenizedBuffer{})
+}
+
+// NewFromSourceWithBuffer creates a Lexer which reuses the memory of
+// toks. toks is reset and must not be used for anything else after.
+func NewFromSourceWithBuffer(src *source.Buffer, toks *TokenizedBuffer) (*Lexer, error) {
 	var srcPos uint32 = 0
-	if bytes.Equal(src.Range(0, 3), []byte{0xEF, 0xBB, 0xBF}) {
+	if bytes.Equal(src.Range(0, min(3, src.Len())), []byte{0xEF, 0xBB, 0xBF}) {
 		// skip UTF8 BOM
 		srcPos = 3
 	}
 
+	toks.Reset()
 	return &Lexer{
 		src:     src,
-		toks:    &TokenizedBuffer{},
+		toks:    toks,
 		srcPos:  srcPos,
 		readPos: srcPos,
 	}, nil
 }
 
+// NewFromFile creates a Lexer reading filename. The source is never
+// closed, use source.NewFromFile and NewFromSource to release it.
 func NewFromFile(filename string) (*Lexer, error) {
 	src, err := source.NewFromFile(filename)
 	if err != nil {
@@ -41,6 +52,16 @@ func NewFromFile(filename string) (*Lexer, error) {
 	return NewFromSource(src)
 }
 
+// NewFromFS creates a Lexer reading filename from fsys. As for
+// NewFromFile, the source is never closed.
+func NewFromFS(fsys fs.FS, filename string) (*Lexer, error) {
+	src, err := source.NewFromFS(fsys, filename)
+	if err != nil {
+		return nil, err
+	}
+	return NewFromSource(src)
+}
+
 func NewFromReader(r io.Reader) (*Lexer, error) {
 	src, err := source.NewFromReader(r)
 	if err != nil {
@@ -92,6 +113,7 @@ func (l *Lexer) emit(kind TokenKind, position uint32) stateFn {
 	l.toks.TokenInfos = append(l.toks.TokenInfos, TokenInfo{
 		Kind:   kind,
 		Offset: position,
+		Len:    l.readPos - position,
 	})
 	return nil
 }
@@ -103,7 +125,7 @@ func (l *Lexer) error(err error) stateFn {
 
 func (l *Lexer) makeLines() {
 	nbLines := bytes.Count(l.src.Bytes(), []byte{'\n'}) + 1
-	l.toks.LineInfos = make([]LineInfo, nbLines)
+	l.toks.LineInfos = slices.Grow(l.toks.LineInfos[:0], nbLines)[:nbLines]
 
 	i := 0
 	start := l.srcPos
@@ -125,6 +147,37 @@ func (l *Lexer) start() {
 	l.emit(TokenKindBOF, l.tokPos)
 }
 
+// Tokens lexes the source lazily. The errors are yielded along with
+// their TokenKindError token and the iteration ends after the EOF token.
+// The tokens are not kept, this is an alternative to Lex for callers
+// only interested in a part of the source.
+func (l *Lexer) Tokens() iter.Seq2[TokenInfo, error] {
+	return func(yield func(TokenInfo, error) bool) {
+		l.start()
+
+		for {
+			for state := l.lexProto(); state != nil; {
+				state = state()
+			}
+
+			errIdx := 0
+			for _, tok := range l.toks.TokenInfos {
+				var err error
+				if tok.Kind == TokenKindError {
+					err = l.errs[errIdx]
+					errIdx++
+				}
+
+				if !yield(tok, err) || tok.Kind == TokenKindEOF {
+					return
+				}
+			}
+			l.toks.TokenInfos = l.toks.TokenInfos[:0]
+			l.errs = l.errs[:0]
+		}
+	}
+}
+
 func (l *Lexer) Lex() (*TokenizedBuffer, []error) {
 	l.makeLines()
 	l.start()
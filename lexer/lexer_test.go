@@ -1,9 +1,11 @@
 package lexer_test
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io/fs"
+	"os"
 	"path/filepath"
 	"reflect"
 	"runtime"
@@ -11,6 +13,7 @@ import (
 	"testing"
 
 	"github.com/Clement-Jean/protein/lexer"
+	"github.com/Clement-Jean/protein/source"
 )
 
 type TestCase struct {
@@ -81,6 +84,7 @@ func rangeTestCase(name string, start, end lexer.TokenKind) TestCase {
 		info := &expectedTokenInfos[i+1]
 		info.Kind = lexer.TokenKind(realStart + i)
 		info.Offset = uint32(offset)
+		info.Len = uint32(len(info.Kind.String()))
 
 		b.WriteString(info.Kind.String())
 		b.WriteString(" ")
@@ -107,7 +111,7 @@ var tests = []TestCase{
 		input: "&",
 		tokenInfos: []lexer.TokenInfo{
 			{Kind: lexer.TokenKindBOF},
-			{Kind: lexer.TokenKindError},
+			{Kind: lexer.TokenKindError, Len: 1},
 			{Kind: lexer.TokenKindEOF, Offset: 1},
 		},
 		lineInfos: []lexer.LineInfo{
@@ -120,10 +124,10 @@ var tests = []TestCase{
 		input: "🙈",
 		tokenInfos: []lexer.TokenInfo{
 			{Kind: lexer.TokenKindBOF},
-			{Kind: lexer.TokenKindError},
-			{Kind: lexer.TokenKindError, Offset: 1},
-			{Kind: lexer.TokenKindError, Offset: 2},
-			{Kind: lexer.TokenKindError, Offset: 3},
+			{Kind: lexer.TokenKindError, Len: 1},
+			{Kind: lexer.TokenKindError, Offset: 1, Len: 1},
+			{Kind: lexer.TokenKindError, Offset: 2, Len: 1},
+			{Kind: lexer.TokenKindError, Offset: 3, Len: 1},
 			{Kind: lexer.TokenKindEOF, Offset: 4},
 		},
 		lineInfos: []lexer.LineInfo{
@@ -178,7 +182,7 @@ var tests = []TestCase{
 		input: "//this is a comment",
 		tokenInfos: []lexer.TokenInfo{
 			{Kind: lexer.TokenKindBOF},
-			{Kind: lexer.TokenKindComment},
+			{Kind: lexer.TokenKindComment, Len: 19},
 			{Kind: lexer.TokenKindEOF, Offset: 19},
 		},
 		lineInfos: []lexer.LineInfo{
@@ -190,7 +194,7 @@ var tests = []TestCase{
 		input: "//this is a comment\n",
 		tokenInfos: []lexer.TokenInfo{
 			{Kind: lexer.TokenKindBOF},
-			{Kind: lexer.TokenKindComment},
+			{Kind: lexer.TokenKindComment, Len: 19},
 			{Kind: lexer.TokenKindEOF, Offset: 20},
 		},
 		lineInfos: []lexer.LineInfo{
@@ -203,7 +207,7 @@ var tests = []TestCase{
 		input: "/*this is a comment*/",
 		tokenInfos: []lexer.TokenInfo{
 			{Kind: lexer.TokenKindBOF},
-			{Kind: lexer.TokenKindComment},
+			{Kind: lexer.TokenKindComment, Len: 21},
 			{Kind: lexer.TokenKindEOF, Offset: 21},
 		},
 		lineInfos: []lexer.LineInfo{
@@ -215,7 +219,7 @@ var tests = []TestCase{
 		input: "/*this is * a comment*/",
 		tokenInfos: []lexer.TokenInfo{
 			{Kind: lexer.TokenKindBOF},
-			{Kind: lexer.TokenKindComment},
+			{Kind: lexer.TokenKindComment, Len: 23},
 			{Kind: lexer.TokenKindEOF, Offset: 23},
 		},
 		lineInfos: []lexer.LineInfo{
@@ -227,7 +231,7 @@ var tests = []TestCase{
 		input: "/*this is a comment",
 		tokenInfos: []lexer.TokenInfo{
 			{Kind: lexer.TokenKindBOF},
-			{Kind: lexer.TokenKindError},
+			{Kind: lexer.TokenKindError, Len: 19},
 			{Kind: lexer.TokenKindEOF, Offset: 19},
 		},
 		lineInfos: []lexer.LineInfo{
@@ -235,13 +239,111 @@ var tests = []TestCase{
 		},
 		errs: []error{errors.New("unclosed multiline comment")},
 	},
+	{
+		name:  "line_comment_crlf",
+		input: "// a\r\nb",
+		tokenInfos: []lexer.TokenInfo{
+			{Kind: lexer.TokenKindBOF},
+			{Kind: lexer.TokenKindComment, Len: 5},
+			{Kind: lexer.TokenKindIdentifier, Offset: 6, Len: 1},
+			{Kind: lexer.TokenKindEOF, Offset: 7},
+		},
+		lineInfos: []lexer.LineInfo{
+			{Start: 0},
+			{Start: 6},
+		},
+	},
+	{
+		name:  "multiline_comment_empty",
+		input: "/**/",
+		tokenInfos: []lexer.TokenInfo{
+			{Kind: lexer.TokenKindBOF},
+			{Kind: lexer.TokenKindComment, Len: 4},
+			{Kind: lexer.TokenKindEOF, Offset: 4},
+		},
+		lineInfos: []lexer.LineInfo{
+			{Start: 0},
+		},
+	},
+	{
+		// the * of the opening /* does not close the comment
+		name:  "multiline_comment_slash",
+		input: "/*/",
+		tokenInfos: []lexer.TokenInfo{
+			{Kind: lexer.TokenKindBOF},
+			{Kind: lexer.TokenKindError, Len: 3},
+			{Kind: lexer.TokenKindEOF, Offset: 3},
+		},
+		lineInfos: []lexer.LineInfo{
+			{Start: 0},
+		},
+		errs: []error{errors.New("unclosed multiline comment")},
+	},
+	{
+		name:  "multiline_comment_slash_closed",
+		input: "/*/ */",
+		tokenInfos: []lexer.TokenInfo{
+			{Kind: lexer.TokenKindBOF},
+			{Kind: lexer.TokenKindComment, Len: 6},
+			{Kind: lexer.TokenKindEOF, Offset: 6},
+		},
+		lineInfos: []lexer.LineInfo{
+			{Start: 0},
+		},
+	},
+	{
+		// the comment ends at the first */
+		name:  "multiline_comment_closed_twice",
+		input: "/* */*/",
+		tokenInfos: []lexer.TokenInfo{
+			{Kind: lexer.TokenKindBOF},
+			{Kind: lexer.TokenKindComment, Len: 5},
+			{Kind: lexer.TokenKindError, Offset: 5, Len: 1},
+			{Kind: lexer.TokenKindSlash, Offset: 6, Len: 1},
+			{Kind: lexer.TokenKindEOF, Offset: 7},
+		},
+		lineInfos: []lexer.LineInfo{
+			{Start: 0},
+		},
+		errs: []error{errors.New("invalid char '*'")},
+	},
+	{
+		name:  "slash",
+		input: "a/b /",
+		tokenInfos: []lexer.TokenInfo{
+			{Kind: lexer.TokenKindBOF},
+			{Kind: lexer.TokenKindIdentifier, Len: 1},
+			{Kind: lexer.TokenKindSlash, Offset: 1, Len: 1},
+			{Kind: lexer.TokenKindIdentifier, Offset: 2, Len: 1},
+			{Kind: lexer.TokenKindSlash, Offset: 4, Len: 1},
+			{Kind: lexer.TokenKindEOF, Offset: 5},
+		},
+		lineInfos: []lexer.LineInfo{
+			{Start: 0},
+		},
+	},
+	{
+		name:  "multiline_comment_newlines",
+		input: "/*\n*/\nmessage",
+		tokenInfos: []lexer.TokenInfo{
+			{Kind: lexer.TokenKindBOF},
+			{Kind: lexer.TokenKindComment, Len: 5},
+			{Kind: lexer.TokenKindMessage, Offset: 6, Len: 7},
+			{Kind: lexer.TokenKindEOF, Offset: 13},
+		},
+		lineInfos: []lexer.LineInfo{
+			{Start: 0},
+			{Start: 3},
+			{Start: 6},
+		},
+	},
 	{
 		name:  "identifier",
 		input: "hello_world2024 HelloWorld2024",
 		tokenInfos: []lexer.TokenInfo{
 			{Kind: lexer.TokenKindBOF},
-			{Kind: lexer.TokenKindIdentifier},
-			{Kind: lexer.TokenKindIdentifier, Offset: 16},
+			{Kind: lexer.TokenKindIdentifier, Len: 15},
+			{Kind: lexer.TokenKindIdentifier, Offset: 16, Len: 14},
 			{Kind: lexer.TokenKindEOF, Offset: 30},
 		},
 		lineInfos: []lexer.LineInfo{
@@ -253,8 +355,8 @@ var tests = []TestCase{
 		input: "'test' \"test\"",
 		tokenInfos: []lexer.TokenInfo{
 			{Kind: lexer.TokenKindBOF},
-			{Kind: lexer.TokenKindStr},
-			{Kind: lexer.TokenKindStr, Offset: 7},
+			{Kind: lexer.TokenKindStr, Len: 6},
+			{Kind: lexer.TokenKindStr, Offset: 7, Len: 6},
 			{Kind: lexer.TokenKindEOF, Offset: 13},
 		},
 		lineInfos: []lexer.LineInfo{
@@ -266,7 +368,7 @@ var tests = []TestCase{
 		input: "'🙈🙉🙊'",
 		tokenInfos: []lexer.TokenInfo{
 			{Kind: lexer.TokenKindBOF},
-			{Kind: lexer.TokenKindStr},
+			{Kind: lexer.TokenKindStr, Len: 14},
 			{Kind: lexer.TokenKindEOF, Offset: 14},
 		},
 		lineInfos: []lexer.LineInfo{
@@ -278,7 +380,7 @@ var tests = []TestCase{
 		input: "'this is a \\\"123string\\\"'",
 		tokenInfos: []lexer.TokenInfo{
 			{Kind: lexer.TokenKindBOF},
-			{Kind: lexer.TokenKindStr},
+			{Kind: lexer.TokenKindStr, Len: 25},
 			{Kind: lexer.TokenKindEOF, Offset: 25},
 		},
 		lineInfos: []lexer.LineInfo{
@@ -290,7 +392,7 @@ var tests = []TestCase{
 		input: "'test",
 		tokenInfos: []lexer.TokenInfo{
 			{Kind: lexer.TokenKindBOF},
-			{Kind: lexer.TokenKindError},
+			{Kind: lexer.TokenKindError, Len: 5},
 			{Kind: lexer.TokenKindEOF, Offset: 5},
 		},
 		lineInfos: []lexer.LineInfo{
@@ -303,8 +405,8 @@ var tests = []TestCase{
 		input: "'test\n'",
 		tokenInfos: []lexer.TokenInfo{
 			{Kind: lexer.TokenKindBOF},
-			{Kind: lexer.TokenKindError},
-			{Kind: lexer.TokenKindError, Offset: 6},
+			{Kind: lexer.TokenKindError, Len: 5},
+			{Kind: lexer.TokenKindError, Offset: 6, Len: 1},
 			{Kind: lexer.TokenKindEOF, Offset: 7},
 		},
 		lineInfos: []lexer.LineInfo{
@@ -321,7 +423,7 @@ var tests = []TestCase{
 		input: "\"test'",
 		tokenInfos: []lexer.TokenInfo{
 			{Kind: lexer.TokenKindBOF},
-			{Kind: lexer.TokenKindError},
+			{Kind: lexer.TokenKindError, Len: 6},
 			{Kind: lexer.TokenKindEOF, Offset: 6},
 		},
 		lineInfos: []lexer.LineInfo{
@@ -334,10 +436,10 @@ var tests = []TestCase{
 		input: "5 0 -5 +5",
 		tokenInfos: []lexer.TokenInfo{
 			{Kind: lexer.TokenKindBOF},
-			{Kind: lexer.TokenKindInt},
-			{Kind: lexer.TokenKindInt, Offset: 2},
-			{Kind: lexer.TokenKindInt, Offset: 4},
-			{Kind: lexer.TokenKindInt, Offset: 7},
+			{Kind: lexer.TokenKindInt, Len: 1},
+			{Kind: lexer.TokenKindInt, Offset: 2, Len: 1},
+			{Kind: lexer.TokenKindInt, Offset: 4, Len: 2},
+			{Kind: lexer.TokenKindInt, Offset: 7, Len: 2},
 			{Kind: lexer.TokenKindEOF, Offset: 9},
 		},
 		lineInfos: []lexer.LineInfo{
@@ -349,8 +451,8 @@ var tests = []TestCase{
 		input: "0xff 0XFF",
 		tokenInfos: []lexer.TokenInfo{
 			{Kind: lexer.TokenKindBOF},
-			{Kind: lexer.TokenKindInt},
-			{Kind: lexer.TokenKindInt, Offset: 5},
+			{Kind: lexer.TokenKindInt, Len: 4},
+			{Kind: lexer.TokenKindInt, Offset: 5, Len: 4},
 			{Kind: lexer.TokenKindEOF, Offset: 9},
 		},
 		lineInfos: []lexer.LineInfo{
@@ -362,7 +464,7 @@ var tests = []TestCase{
 		input: "056",
 		tokenInfos: []lexer.TokenInfo{
 			{Kind: lexer.TokenKindBOF},
-			{Kind: lexer.TokenKindInt},
+			{Kind: lexer.TokenKindInt, Len: 3},
 			{Kind: lexer.TokenKindEOF, Offset: 3},
 		},
 		lineInfos: []lexer.LineInfo{
@@ -374,15 +476,15 @@ var tests = []TestCase{
 		input: "-8.8 +0.8 -.8 +.8 .8 .8e8 .8e+8 .8e-8 8e8",
 		tokenInfos: []lexer.TokenInfo{
 			{Kind: lexer.TokenKindBOF},
-			{Kind: lexer.TokenKindFloat},
-			{Kind: lexer.TokenKindFloat, Offset: 5},
-			{Kind: lexer.TokenKindFloat, Offset: 10},
-			{Kind: lexer.TokenKindFloat, Offset: 14},
-			{Kind: lexer.TokenKindFloat, Offset: 18},
-			{Kind: lexer.TokenKindFloat, Offset: 21},
-			{Kind: lexer.TokenKindFloat, Offset: 26},
-			{Kind: lexer.TokenKindFloat, Offset: 32},
-			{Kind: lexer.TokenKindFloat, Offset: 38},
+			{Kind: lexer.TokenKindFloat, Len: 4},
+			{Kind: lexer.TokenKindFloat, Offset: 5, Len: 4},
+			{Kind: lexer.TokenKindFloat, Offset: 10, Len: 3},
+			{Kind: lexer.TokenKindFloat, Offset: 14, Len: 3},
+			{Kind: lexer.TokenKindFloat, Offset: 18, Len: 2},
+			{Kind: lexer.TokenKindFloat, Offset: 21, Len: 4},
+			{Kind: lexer.TokenKindFloat, Offset: 26, Len: 5},
+			{Kind: lexer.TokenKindFloat, Offset: 32, Len: 5},
+			{Kind: lexer.TokenKindFloat, Offset: 38, Len: 3},
 			{Kind: lexer.TokenKindEOF, Offset: 41},
 		},
 		lineInfos: []lexer.LineInfo{
@@ -395,6 +497,64 @@ func TestLexer(t *testing.T) {
 	runTestCases(t, tests)
 }
 
+func TestTokens(t *testing.T) {
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			l, err := lexer.NewFromReader(strings.NewReader(test.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var tokenInfos []lexer.TokenInfo
+			var errs []error
+			for tok, err := range l.Tokens() {
+				tokenInfos = append(tokenInfos, tok)
+				if err != nil {
+					errs = append(errs, err)
+				}
+			}
+
+			if !reflect.DeepEqual(test.errs, errs) {
+				t.Fatalf(`
+expected errors: %+v
+            got: %+v`, test.errs, errs)
+			}
+
+			if !reflect.DeepEqual(test.tokenInfos, tokenInfos) {
+				t.Fatalf(`
+expected token infos: %+v
+                 got: %+v`, test.tokenInfos, tokenInfos)
+			}
+		})
+	}
+}
+
+func TestTokensStop(t *testing.T) {
+	l, err := lexer.NewFromReader(strings.NewReader("syntax = \"proto3\"; message A {}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var kinds []lexer.TokenKind
+	for tok := range l.Tokens() {
+		if tok.Kind == lexer.TokenKindMessage {
+			break
+		}
+		kinds = append(kinds, tok.Kind)
+	}
+
+	expected := []lexer.TokenKind{
+		lexer.TokenKindBOF,
+		lexer.TokenKindSyntax,
+		lexer.TokenKindEqual,
+		lexer.TokenKindStr,
+		lexer.TokenKindSemicolon,
+	}
+	if !reflect.DeepEqual(expected, kinds) {
+		t.Fatalf("expected %v, got %v", expected, kinds)
+	}
+}
+
 var toks *lexer.TokenizedBuffer
 
 var (
@@ -421,17 +581,162 @@ func BenchmarkLexer(b *testing.B) {
 	}
 }
 
+func BenchmarkTokensFirstMessage(b *testing.B) {
+	corpusPath := filepath.Join(basepath, "../corpus/")
+
+	for _, s := range findFiles(corpusPath, ".proto") {
+		content, err := os.ReadFile(s)
+		if err != nil {
+			b.Fatal(err)
+		}
+		src, err := source.NewFromReader(bytes.NewReader(content))
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(s, func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				l, err := lexer.NewFromSource(src)
+				if err != nil {
+					b.Fatal(err)
+				}
+
+				for tok := range l.Tokens() {
+					if tok.Kind == lexer.TokenKindMessage {
+						break
+					}
+				}
+			}
+		})
+	}
+}
+
 func FuzzLexer(f *testing.F) {
 	for _, tc := range tests {
 		f.Add(tc.input)
 	}
+	for _, s := range findFiles(filepath.Join(basepath, "../corpus/"), ".proto") {
+		content, err := os.ReadFile(s)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(string(content))
+	}
 
 	f.Fuzz(func(t *testing.T, s string) {
-		l, err := lexer.NewFromReader(strings.NewReader(s))
+		src, err := source.NewFromReader(strings.NewReader(s))
 		if err != nil {
-			f.Fatal(err)
+			t.Skip(err) // e.g. odd UTF-16
+		}
+		l, err := lexer.NewFromSource(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		toks, errs := l.Lex()
+		infos := toks.TokenInfos
+		if len(infos) < 2 || infos[0].Kind != lexer.TokenKindBOF || infos[len(infos)-1].Kind != lexer.TokenKindEOF {
+			t.Fatalf("expected BOF ... EOF, got %v", infos)
 		}
 
-		toks, _ = l.Lex()
+		nbErrors := 0
+		for i, info := range infos {
+			if info.Offset > src.Len() {
+				t.Fatalf("token %d: offset %d out of the source (len %d)", i, info.Offset, src.Len())
+			}
+			if i > 0 && info.Offset < infos[i-1].Offset {
+				t.Fatalf("token %d: offset %d before the previous one", i, info.Offset)
+			}
+			if info.Kind == lexer.TokenKindError {
+				nbErrors++
+			}
+			if end := info.End(); end < info.Offset || (i+1 < len(infos) && end > infos[i+1].Offset) {
+				t.Fatalf("token %d: end %d outside of [%d, next token]", i, end, info.Offset)
+			}
+		}
+		if nbErrors != len(errs) {
+			t.Fatalf("expected %d errors, got %d", nbErrors, len(errs))
+		}
 	})
 }
+
+func TestPosition(t *testing.T) {
+	input := "syntax = \"proto3\";\r\n\r\nmessage A {\r\n}"
+	l, err := lexer.NewFromReader(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tb, errs := l.Lex()
+	if len(errs) != 0 {
+		t.Fatal(errs)
+	}
+
+	tests := []struct {
+		offset uint32
+		line   lexer.LineIdx
+		column uint32
+	}{
+		{0, 0, 0},
+		{19, 0, 19}, // \r
+		{20, 1, 0},
+		{22, 2, 0},
+		{30, 2, 8},
+		{35, 3, 0},
+	}
+	for _, test := range tests {
+		line, column := tb.Position(test.offset)
+		if line != test.line || column != test.column {
+			t.Errorf("offset %d: expected %d:%d, got %d:%d", test.offset, test.line, test.column, line, column)
+		}
+	}
+
+	src, err := source.NewFromReader(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var lines []string
+	for i := range tb.LineInfos {
+		idx := lexer.LineIdx(i)
+		lines = append(lines, string(src.Range(tb.LineInfos[idx].Start, tb.LineEnd(src, idx))))
+	}
+
+	expected := []string{`syntax = "proto3";`, "", "message A {", "}"}
+	if !reflect.DeepEqual(expected, lines) {
+		t.Fatalf("expected %q, got %q", expected, lines)
+	}
+}
+
+func TestTokenText(t *testing.T) {
+	input := "syntax = \"a b\"; // c  \r\n/* d\n*/ -1.5e3 &'e\n0x1F"
+	src, err := source.NewFromReader(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, err := lexer.NewFromSource(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tb, _ := l.Lex()
+
+	var texts []string
+	for i := range tb.TokenInfos {
+		texts = append(texts, string(tb.TokenText(src, uint32(i))))
+	}
+
+	expected := []string{
+		"", // BOF
+		"syntax", "=", `"a b"`, ";",
+		"// c  \r",
+		"/* d\n*/",
+		"-1.5e3",
+		"&", "'e", // errors
+		"0x1F",
+		"", // EOF
+	}
+	if !reflect.DeepEqual(expected, texts) {
+		t.Fatalf("expected %q, got %q", expected, texts)
+	}
+}
@@ -1,6 +1,7 @@
 package lexer
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"slices"
@@ -41,13 +42,10 @@ func (l *Lexer) lexLineComment() (state stateFn) {
 
 func (l *Lexer) goToEndOfLineComment() (len uint32) {
 	start := l.readPos
-	ch := l.next()
-	for ch != 0 && ch != '\n' {
-		ch = l.next()
-	}
-
-	if ch == '\n' {
-		l.backup()
+	if idx := bytes.IndexByte(l.src.From(start), '\n'); idx != -1 {
+		l.readPos += uint32(idx)
+	} else {
+		l.readPos = l.src.Len()
 	}
 	return l.readPos - start
 }
@@ -67,13 +65,13 @@ func (l *Lexer) lexMultilineComment() (state stateFn) {
 
 func (l *Lexer) goToEndOfMultilineComment() (len uint32, ok bool) {
 	start := l.readPos
-	for ch := l.next(); ch != 0; ch = l.next() {
-		if ch == '*' {
-			if peek := l.next(); peek == '/' {
-				return l.readPos - start, true
-			}
-		}
+	// skip the opening /* so that /*/ is not a comment
+	rest := l.src.From(min(start+2, l.src.Len()))
+	if idx := bytes.Index(rest, []byte("*/")); idx != -1 {
+		l.readPos = min(start+2, l.src.Len()) + uint32(idx) + 2
+		return l.readPos - start, true
 	}
+	l.readPos = l.src.Len()
 	return l.readPos - start, false
 }
 
@@ -163,12 +161,9 @@ func (l *Lexer) lexProto() (state stateFn) {
 	case '\v', '\f', '\r', '\t', ' ', 0x85, 0xA0:
 		break // skip
 	case '\n':
-		l.currLineIdx++
-		if int(l.currLineIdx) >= len(l.toks.LineInfos) {
-			l.currLineIdx--
-			return l.emit(TokenKindEOF, l.tokPos)
-		}
-		l.tokPos = l.toks.LineInfos[l.currLineIdx].Start
+		// the next token starts after the newline, the line infos are
+		// not used since multiline comments can contain newlines
+		l.tokPos = l.readPos
 		return nil
 	case '_':
 		state = l.emit(TokenKindUnderscore, l.tokPos)
@@ -191,7 +186,7 @@ func (l *Lexer) lexProto() (state stateFn) {
 			break
 		}
 		l.backup()
-		return l.lexNumber
+		return l.lexNumber()
 	case '{':
 		state = l.emit(TokenKindLeftBrace, l.tokPos)
 	case '}':
@@ -212,13 +207,13 @@ func (l *Lexer) lexProto() (state stateFn) {
 		switch {
 		case isLetter(ch):
 			l.backup()
-			return l.lexIdentifier
+			return l.lexIdentifier()
 		case isQuote(ch):
 			l.backup()
-			return l.lexString
+			return l.lexString()
 		case isDigit(ch) || ch == '-' || ch == '+' || ch == '.':
 			l.backup()
-			return l.lexNumber
+			return l.lexNumber()
 		case ch == '/':
 			if l.readPos >= l.src.Len() {
 				state = l.emit(TokenKindSlash, l.tokPos)
@@ -228,10 +223,10 @@ func (l *Lexer) lexProto() (state stateFn) {
 			switch l.src.At(l.readPos) {
 			case '/':
 				l.backup()
-				return l.lexLineComment
+				return l.lexLineComment()
 			case '*':
 				l.backup()
-				return l.lexMultilineComment
+				return l.lexMultilineComment()
 			default:
 				state = l.emit(TokenKindSlash, l.tokPos)
 			}
@@ -1,5 +1,7 @@
 package lexer
 
+import "iter"
+
 //go:generate stringer -type=TokenKind -linecomment
 type TokenKind uint8
 
@@ -181,3 +183,54 @@ func (k TokenKind) MatchingClosingSymbol() TokenKind {
 	pos := k - TokenKindLeftBrace
 	return TokenKindLeftBrace + mid + pos
 }
+
+var scalarTypes = [...]TokenKind{
+	TokenKindTypeDouble,
+	TokenKindTypeFloat,
+	TokenKindTypeInt32,
+	TokenKindTypeInt64,
+	TokenKindTypeUint32,
+	TokenKindTypeUint64,
+	TokenKindTypeSint32,
+	TokenKindTypeSint64,
+	TokenKindTypeFixed32,
+	TokenKindTypeFixed64,
+	TokenKindTypeSfixed32,
+	TokenKindTypeSfixed64,
+	TokenKindTypeBool,
+	TokenKindTypeString,
+	TokenKindTypeBytes,
+}
+
+func (k TokenKind) IsScalarType() bool {
+	for _, kind := range scalarTypes {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// ScalarTypes returns the kinds of the scalar types, in the order of
+// their descriptor types (double first).
+func ScalarTypes() iter.Seq[TokenKind] {
+	return func(yield func(TokenKind) bool) {
+		for _, kind := range scalarTypes {
+			if !yield(kind) {
+				return
+			}
+		}
+	}
+}
+
+// Keywords returns the keywords, in alphabetical order, along with
+// their kinds.
+func Keywords() iter.Seq2[string, TokenKind] {
+	return func(yield func(string, TokenKind) bool) {
+		for i, literal := range literals {
+			if !yield(literal, kinds[i]) {
+				return
+			}
+		}
+	}
+}
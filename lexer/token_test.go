@@ -24,6 +24,11 @@ var tokenTests = []struct {
 		s:    "}])>",
 		pred: TokenKind.IsClosingSymbol,
 	},
+	{
+		name: "scalar type",
+		s:    "double float int32 int64 uint32 uint64 sint32 sint64 fixed32 fixed64 sfixed32 sfixed64 bool string bytes",
+		pred: TokenKind.IsScalarType,
+	},
 }
 
 func TestTokens(t *testing.T) {
@@ -1,16 +1,26 @@
 package lexer
 
-import "slices"
+import (
+	"slices"
+
+	"github.com/Clement-Jean/protein/source"
+)
 
 type LineIdx uint32
 
 type TokenInfo struct {
 	Offset uint32
+	Len    uint32
 	//  LineIdx LineIdx // LineInfo index inside TokenizedBuffer.LineInfos
 	//  Column  uint32  // relative zero-based index from the beginning of a line
 	Kind TokenKind
 }
 
+// End returns the offset right after the token.
+func (tok TokenInfo) End() uint32 {
+	return tok.Offset + tok.Len
+}
+
 type LineInfo struct {
 	Start uint32 // offset from the begining of the input text
 	//  Len   uint32
@@ -21,8 +31,26 @@ type TokenizedBuffer struct {
 	LineInfos  []LineInfo
 }
 
+// Reset empties the buffer while keeping the allocated memory.
+func (tb *TokenizedBuffer) Reset() {
+	tb.TokenInfos = tb.TokenInfos[:0]
+	tb.LineInfos = tb.LineInfos[:0]
+}
+
+// TokenSpan returns the [start, end) offsets of the token at idx.
+// The BOF and EOF tokens are empty.
+func (tb *TokenizedBuffer) TokenSpan(idx uint32) (start, end uint32) {
+	tok := tb.TokenInfos[idx]
+	return tok.Offset, tok.End()
+}
+
+// TokenText returns the text of the token at idx in src.
+func (tb *TokenizedBuffer) TokenText(src *source.Buffer, idx uint32) []byte {
+	return src.Range(tb.TokenSpan(idx))
+}
+
 func (tb *TokenizedBuffer) FindLineIndex(offset uint32) LineIdx {
-	idx, _ := slices.BinarySearchFunc(tb.LineInfos, offset, func(li LineInfo, offset uint32) int {
+	idx, found := slices.BinarySearchFunc(tb.LineInfos, offset, func(li LineInfo, offset uint32) int {
 		if li.Start < offset {
 			return -1
 		} else if li.Start > offset {
@@ -30,10 +58,31 @@ func (tb *TokenizedBuffer) FindLineIndex(offset uint32) LineIdx {
 		}
 		return 0
 	})
-	idx--
+	if !found {
+		idx--
+	}
 	return LineIdx(idx)
 }
 
+// Position returns the line of offset and its column in bytes.
+func (tb *TokenizedBuffer) Position(offset uint32) (LineIdx, uint32) {
+	idx := tb.FindLineIndex(offset)
+	return idx, offset - tb.LineInfos[idx].Start
+}
+
+// LineEnd returns the offset at which the line idx ends, excluding
+// the line terminator (\n or \r\n).
+func (tb *TokenizedBuffer) LineEnd(src *source.Buffer, idx LineIdx) uint32 {
+	end := src.Len()
+	if int(idx)+1 < len(tb.LineInfos) {
+		end = tb.LineInfos[idx+1].Start - 1
+	}
+	if end > tb.LineInfos[idx].Start && src.At(end-1) == '\r' {
+		end--
+	}
+	return end
+}
+
 func (tb *TokenizedBuffer) GetIndentColumnNumber(idx LineIdx) uint32 {
 	return tb.LineInfos[idx].Start + 1
 }
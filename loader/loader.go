@@ -0,0 +1,156 @@
+package loader
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+	"strings"
+
+	"github.com/Clement-Jean/protein/ast"
+	"github.com/Clement-Jean/protein/lexer"
+	"github.com/Clement-Jean/protein/parser"
+	"github.com/Clement-Jean/protein/source"
+	"github.com/Clement-Jean/protein/wellknown"
+)
+
+// File is a parsed file along with the files it imports. Errs contains
+// the lexing, parsing and import errors.
+type File struct {
+	Path    string
+	Source  *source.File // the file in the FileSet of the Loader
+	AST     *ast.File
+	Errs    []error
+	Imports []*File // the imports which could be loaded
+}
+
+// Loader parses files and their imports from a file system. The
+// import paths are relative to the root of the file system. The
+// google/protobuf/*.proto and google/protobuf/compiler/plugin.proto
+// files missing from the file system are loaded from the wellknown
+// package. A Loader is not safe for concurrent use.
+type Loader struct {
+	fsys    fs.FS
+	fset    *source.FileSet
+	files   map[string]*File
+	order   []*File
+	loading []string // stack of the files being loaded
+}
+
+func New(fsys fs.FS) *Loader {
+	return &Loader{
+		fsys:  fsys,
+		fset:  source.NewFileSet(),
+		files: make(map[string]*File),
+	}
+}
+
+// Load parses path and, recursively, the files it imports. Each
+// file is only parsed once per Loader. An error is returned when
+// path cannot be read, the other errors are in the files.
+func (l *Loader) Load(path string) (*File, error) {
+	if f, ok := l.files[path]; ok {
+		return f, nil
+	}
+
+	src, err := source.NewFromFS(l.fsys, path)
+	if errors.Is(err, fs.ErrNotExist) {
+		// like the includes bundled with protoc
+		if wkt, wktErr := source.NewFromFS(wellknown.FS, path); wktErr == nil {
+			src, err = wkt, nil
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := l.fset.AddFile(path, src)
+	if err != nil {
+		return nil, err
+	}
+
+	f := l.parse(path, src, file)
+	l.files[path] = f
+
+	if f.AST != nil {
+		l.loading = append(l.loading, path)
+		for _, imp := range f.AST.Imports {
+			l.loadImport(f, imp)
+		}
+		l.loading = l.loading[:len(l.loading)-1]
+	}
+
+	l.order = append(l.order, f)
+	return f, nil
+}
+
+func (l *Loader) parse(path string, src *source.Buffer, file *source.File) *File {
+	f := &File{
+		Path:   path,
+		Source: file,
+		Errs:   src.Validate(),
+	}
+
+	lex, err := lexer.NewFromSource(src)
+	if err != nil {
+		f.Errs = append(f.Errs, err)
+		return f
+	}
+
+	toks, errs := lex.Lex()
+	f.Errs = append(f.Errs, errs...)
+
+	tree, errs := parser.NewForFile(f.Source, toks, nil).Parse()
+	f.Errs = append(f.Errs, errs...)
+
+	f.AST = ast.New(src, toks, tree)
+	return f
+}
+
+func (l *Loader) loadImport(f *File, imp *ast.Import) {
+	path, err := imp.Path.String()
+	if err != nil {
+		f.Errs = append(f.Errs, fmt.Errorf("invalid import path %s: %w", imp.Path.Text, err))
+		return
+	}
+
+	for i, loading := range l.loading {
+		if loading == path {
+			cycle := append(slices.Clone(l.loading[i:]), path)
+			f.Errs = append(f.Errs, fmt.Errorf("import cycle: %s", strings.Join(cycle, " -> ")))
+			return
+		}
+	}
+
+	dep, err := l.Load(path)
+	if err != nil {
+		f.Errs = append(f.Errs, fmt.Errorf("import %q: %w", path, err))
+		return
+	}
+	f.Imports = append(f.Imports, dep)
+}
+
+// FileSet returns the positions of the loaded files.
+func (l *Loader) FileSet() *source.FileSet {
+	return l.fset
+}
+
+// Files returns the loaded files, the dependencies before the
+// files importing them.
+func (l *Loader) Files() []*File {
+	return l.order
+}
+
+// Close releases the sources of all the loaded files.
+func (l *Loader) Close() error {
+	var err error
+	for _, f := range l.order {
+		if f.AST == nil {
+			continue
+		}
+		if cerr := f.AST.Src.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
@@ -0,0 +1,163 @@
+package loader_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/Clement-Jean/protein/loader"
+	"github.com/Clement-Jean/protein/parser"
+	"github.com/Clement-Jean/protein/source"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func paths(files []*loader.File) []string {
+	var paths []string
+	for _, f := range files {
+		paths = append(paths, f.Path)
+	}
+	return paths
+}
+
+func errors(f *loader.File) []string {
+	var errs []string
+	for _, err := range f.Errs {
+		errs = append(errs, err.Error())
+	}
+	return errs
+}
+
+func TestLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.proto":       {Data: []byte(`import "b/b.proto"; import "c.proto";`)},
+		"b/b.proto":     {Data: []byte(`import "c.proto"; message B {}`)},
+		"c.proto":       {Data: []byte(`message C {}`)},
+		"cycle.proto":   {Data: []byte(`import "cycle2.proto";`)},
+		"cycle2.proto":  {Data: []byte(`import "cycle.proto";`)},
+		"missing.proto": {Data: []byte(`import "nope.proto";`)},
+	}
+
+	l := loader.New(fsys)
+	a, err := l.Load("a.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff([]string{"b/b.proto", "c.proto"}, paths(a.Imports)); diff != "" {
+		t.Errorf("imports mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"c.proto", "b/b.proto", "a.proto"}, paths(l.Files())); diff != "" {
+		t.Errorf("files mismatch (-want +got):\n%s", diff)
+	}
+	if a.Imports[0].Imports[0] != a.Imports[1] {
+		t.Error("expected c.proto to be loaded once")
+	}
+
+	cycle, err := l.Load("cycle.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"import cycle: cycle.proto -> cycle2.proto -> cycle.proto"}, errors(cycle.Imports[0])); diff != "" {
+		t.Errorf("cycle mismatch (-want +got):\n%s", diff)
+	}
+
+	missing, err := l.Load("missing.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{`import "nope.proto": open nope.proto: file does not exist`}, errors(missing)); diff != "" {
+		t.Errorf("missing mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := l.Load("nope.proto"); err == nil {
+		t.Error("expected an error")
+	}
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadWellKnown(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.proto": {Data: []byte(`import "google/protobuf/type.proto"; import "google/protobuf/empty.proto";`)},
+		// the files of the file system take precedence
+		"google/protobuf/empty.proto": {Data: []byte(`message Vendored {}`)},
+	}
+
+	l := loader.New(fsys)
+	defer l.Close()
+
+	a, err := l.Load("a.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string(nil), errors(a)); diff != "" {
+		t.Errorf("errors mismatch (-want +got):\n%s", diff)
+	}
+
+	expected := []string{
+		"google/protobuf/any.proto",
+		"google/protobuf/source_context.proto",
+		"google/protobuf/type.proto",
+		"google/protobuf/empty.proto",
+		"a.proto",
+	}
+	if diff := cmp.Diff(expected, paths(l.Files())); diff != "" {
+		t.Errorf("files mismatch (-want +got):\n%s", diff)
+	}
+
+	empty := a.Imports[1]
+	if len(empty.AST.Messages) != 1 || empty.AST.Messages[0].Name.Text != "Vendored" {
+		t.Errorf("expected the vendored empty.proto, got %s", empty.AST.Src.Bytes())
+	}
+}
+
+func TestLoadOverlay(t *testing.T) {
+	overlay := source.NewOverlay(fstest.MapFS{
+		"a.proto": {Data: []byte(`import "b.proto";`)},
+		"b.proto": {Data: []byte(`message B {}`)},
+	})
+	// unsaved changes in b.proto
+	overlay.Set("b.proto", []byte(`message Unsaved {}`))
+
+	a, err := loader.New(overlay).Load("a.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := a.Imports[0]
+	if len(b.AST.Messages) != 1 || b.AST.Messages[0].Name.Text != "Unsaved" {
+		t.Errorf("expected the overlay content, got %s", b.AST.Src.Bytes())
+	}
+}
+
+func TestLoadPositions(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.proto": {Data: []byte("import \"b.proto\";\nmessage A {}")},
+		"b.proto": {Data: []byte("syntax = \"proto3\";\nmessage B {\n  int32 = 1;\n}")},
+	}
+
+	l := loader.New(fsys)
+	defer l.Close()
+
+	a, err := l.Load("a.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := a.Imports[0]
+	if len(b.Errs) == 0 {
+		t.Fatal("expected errors in b.proto")
+	}
+
+	expected, ok := b.Errs[0].(*parser.ExpectedError)
+	if !ok {
+		t.Fatalf("expected an ExpectedError, got %v", b.Errs[0])
+	}
+	if pos := l.FileSet().Position(expected.Pos).String(); pos != "b.proto:3:9" {
+		t.Errorf("expected b.proto:3:9, got %s", pos)
+	}
+	if l.FileSet().File(expected.Pos) != b.Source {
+		t.Error("expected the error to be in b.proto")
+	}
+}
@@ -0,0 +1,85 @@
+package options
+
+import (
+	"sync"
+
+	"github.com/Clement-Jean/protein/ast"
+	"github.com/Clement-Jean/protein/lexer"
+	"github.com/Clement-Jean/protein/parser"
+	"github.com/Clement-Jean/protein/source"
+	"github.com/Clement-Jean/protein/symbols"
+	"github.com/Clement-Jean/protein/wellknown"
+)
+
+// descriptor indexes the embedded google/protobuf/descriptor.proto,
+// it defines the standard options (e.g. java_package, deprecated).
+var descriptor = sync.OnceValue(func() *symbols.Table {
+	src, err := source.NewFromFS(wellknown.FS, "google/protobuf/descriptor.proto")
+	if err != nil {
+		panic(err)
+	}
+
+	l, err := lexer.NewFromSource(src)
+	if err != nil {
+		panic(err)
+	}
+
+	tb, errs := l.Lex()
+	if len(errs) != 0 {
+		panic(errs[0])
+	}
+
+	pt, errs := parser.New(tb).Parse()
+	if len(errs) != 0 {
+		panic(errs[0])
+	}
+
+	table, errs := symbols.New(ast.New(src, tb, pt))
+	if len(errs) != 0 {
+		panic(errs[0])
+	}
+	return table
+})
+
+// targets are the options messages and the elements they apply to.
+var targets = []struct{ kind, element string }{
+	{"FileOptions", "a file"},
+	{"MessageOptions", "a message"},
+	{"FieldOptions", "a field"},
+	{"OneofOptions", "a oneof"},
+	{"EnumOptions", "an enum"},
+	{"EnumValueOptions", "an enum value"},
+	{"ServiceOptions", "a service"},
+	{"MethodOptions", "an rpc"},
+}
+
+func element(kind string) string {
+	for _, t := range targets {
+		if t.kind == kind {
+			return t.element
+		}
+	}
+	return kind
+}
+
+// standard returns the field of the options message kind named name.
+// The options defined for other elements are reported as such.
+func (in *interpreter) standard(name ast.FullIdent, kind string) (*symbols.Symbol, bool) {
+	text := name.String()
+	option := func(kind string) (*symbols.Symbol, bool) {
+		sym, ok := descriptor().Lookup(join(descriptorPackage, kind+"."+text))
+		return sym, ok && sym.Kind == symbols.KindField && len(name.Parts) == 1
+	}
+
+	if sym, ok := option(kind); ok {
+		return sym, true
+	}
+	for _, t := range targets {
+		if _, ok := option(t.kind); ok {
+			in.errorf(name.Parts[0].Idx, "option %q is not allowed on %s, only on %s", text, element(kind), t.element)
+			return nil, false
+		}
+	}
+	in.errorf(name.Parts[0].Idx, "unknown option %q", text)
+	return nil, false
+}
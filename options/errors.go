@@ -0,0 +1,11 @@
+package options
+
+// Error is an invalid custom option located at the token TokIdx.
+type Error struct {
+	Msg    string
+	TokIdx uint32
+}
+
+func (e *Error) Error() string {
+	return e.Msg
+}
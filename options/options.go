@@ -0,0 +1,458 @@
+// Package options interprets the custom options, the options named
+// after an extension between parentheses (e.g. (my.ext).field).
+package options
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/Clement-Jean/protein/ast"
+	"github.com/Clement-Jean/protein/lexer"
+	"github.com/Clement-Jean/protein/symbols"
+)
+
+const descriptorPackage = "google.protobuf"
+
+// Option is an interpreted option. Path holds the field of Target (a
+// standard option or an extension) and then the fields named by the
+// option, Target is the options message (e.g.
+// google.protobuf.FieldOptions).
+type Option struct {
+	Option *ast.Option
+	Target string
+	Path   []*symbols.Symbol
+}
+
+type interpreter struct {
+	file  *ast.File
+	table *symbols.Table
+	opts  []*Option
+	errs  []error
+}
+
+// Interpret resolves the options of file, the standard ones against
+// the embedded descriptor.proto and the custom ones against the
+// extensions in table, and checks their values like protoc does.
+// The table should index file and its dependencies.
+func Interpret(file *ast.File, table *symbols.Table) ([]*Option, []error) {
+	in := &interpreter{file: file, table: table}
+
+	pkg := ""
+	if file.Package != nil {
+		pkg = file.Package.Name.String()
+	}
+
+	in.options(pkg, file.Options, "FileOptions")
+	for _, msg := range file.Messages {
+		in.message(pkg, msg)
+	}
+	for _, enum := range file.Enums {
+		in.enum(pkg, enum)
+	}
+	for _, service := range file.Services {
+		name := join(pkg, service.Name.Text)
+		in.options(name, service.Options, "ServiceOptions")
+		for _, rpc := range service.RPCs {
+			in.options(join(name, rpc.Name.Text), rpc.Options, "MethodOptions")
+		}
+	}
+	for _, extend := range file.Extends {
+		in.extend(pkg, extend)
+	}
+	return in.opts, in.errs
+}
+
+func join(scope, name string) string {
+	if scope == "" {
+		return name
+	}
+	return scope + "." + name
+}
+
+func parentScope(scope string) string {
+	if idx := strings.LastIndexByte(scope, '.'); idx != -1 {
+		return scope[:idx]
+	}
+	return ""
+}
+
+func (in *interpreter) errorf(tokIdx uint32, format string, args ...any) {
+	in.errs = append(in.errs, &Error{Msg: fmt.Sprintf(format, args...), TokIdx: tokIdx})
+}
+
+func (in *interpreter) message(scope string, msg *ast.Message) {
+	name := join(scope, msg.Name.Text)
+	in.options(name, msg.Options, "MessageOptions")
+
+	for _, field := range msg.Fields {
+		in.fieldOptions(name, field)
+	}
+	for _, oneof := range msg.Oneofs {
+		in.options(join(name, oneof.Name.Text), oneof.Options, "OneofOptions")
+	}
+	for _, nested := range msg.Messages {
+		in.message(name, nested)
+	}
+	for _, enum := range msg.Enums {
+		in.enum(name, enum)
+	}
+	for _, extend := range msg.Extends {
+		in.extend(name, extend)
+	}
+}
+
+func (in *interpreter) enum(scope string, enum *ast.Enum) {
+	name := join(scope, enum.Name.Text)
+	in.options(name, enum.Options, "EnumOptions")
+
+	for _, value := range enum.Values {
+		in.options(join(name, value.Name.Text), value.Options, "EnumValueOptions")
+	}
+}
+
+func (in *interpreter) extend(scope string, extend *ast.Extend) {
+	for _, field := range extend.Fields {
+		in.fieldOptions(scope, field)
+	}
+}
+
+// fieldOptions interprets the options of field, declared in scope.
+// The default and json_name options are not in FieldOptions, they
+// are fields of the field descriptor.
+func (in *interpreter) fieldOptions(scope string, field *ast.Field) {
+	name := join(scope, field.Name.Text)
+	set := make(map[string]bool)
+
+	for _, opt := range field.Options {
+		if len(opt.Name) != 1 || opt.Name[0].Extension || len(opt.Name[0].Name.Parts) != 1 {
+			continue
+		}
+
+		tok := opt.Name[0].Name.Parts[0]
+		switch tok.Text {
+		case "default", "json_name":
+			if set[tok.Text] {
+				in.errorf(tok.Idx, "option %q was already set", tok.Text)
+				continue
+			}
+			set[tok.Text] = true
+		}
+
+		switch {
+		case tok.Text == "json_name" && field.Extend != nil:
+			in.errorf(tok.Idx, `option "json_name" is not allowed on extensions`)
+		case tok.Text == "json_name":
+			if _, err := opt.Value.String(); err != nil {
+				in.errorf(opt.Value.Idx, "invalid value %s for string option %q", opt.Value.Text, tok.Text)
+			}
+		case tok.Text == "default" && (field.Label == ast.LabelRepeated || field.Map != nil):
+			in.errorf(tok.Idx, "repeated fields cannot have a default value")
+		case tok.Text == "default":
+			sym, ok := in.table.Lookup(name)
+			if !ok || sym.Decl != field {
+				// duplicates are reported by the symbol table
+				continue
+			}
+			if typ, ok := in.fieldType(sym); ok && typ.Kind == symbols.KindMessage {
+				in.errorf(tok.Idx, "message fields cannot have a default value")
+				continue
+			}
+			in.value(sym, opt.Value, tok.Text, false)
+		}
+	}
+	in.options(name, field.Options, "FieldOptions")
+}
+
+// options interprets the options of the element named scope.
+func (in *interpreter) options(scope string, opts []*ast.Option, kind string) {
+	target := descriptorPackage + "." + kind
+	set := make(map[string]bool)
+
+	for _, opt := range opts {
+		if len(opt.Name) == 0 || len(opt.Name[0].Name.Parts) == 0 {
+			continue
+		}
+
+		first := opt.Name[0]
+		if kind == "FieldOptions" && !first.Extension && len(opt.Name) == 1 {
+			if text := first.Name.String(); text == "default" || text == "json_name" {
+				// interpreted by fieldOptions
+				continue
+			}
+		}
+
+		var (
+			sym *symbols.Symbol
+			ok  bool
+		)
+		if first.Extension {
+			sym, ok = in.extension(scope, first.Name, target)
+		} else {
+			sym, ok = in.standard(first.Name, kind)
+		}
+		if !ok {
+			continue
+		}
+
+		path := []*symbols.Symbol{sym}
+		key := sym.Name
+		for _, part := range opt.Name[1:] {
+			if len(part.Name.Parts) == 0 {
+				break
+			}
+			prev := path[len(path)-1]
+			msg, ok := in.messageType(prev, part.Name)
+			if !ok {
+				break
+			}
+
+			var sym *symbols.Symbol
+			if part.Extension {
+				sym, ok = in.extension(scope, part.Name, msg.Name)
+			} else {
+				sym, ok = in.field(msg, part.Name)
+			}
+			if !ok {
+				break
+			}
+			key += "." + sym.Name
+			path = append(path, sym)
+		}
+		if len(path) != len(opt.Name) {
+			continue
+		}
+
+		last := path[len(path)-1]
+		name := opt.NameString()
+		if set[key] && !isRepeated(last) {
+			in.errorf(opt.Name[0].Name.Parts[0].Idx, "option %q was already set", name)
+			continue
+		}
+		set[key] = true
+
+		in.value(last, opt.Value, name, false)
+		in.opts = append(in.opts, &Option{Option: opt, Target: target, Path: path})
+	}
+}
+
+// extension resolves the extension name in scope and checks that it
+// extends the message target.
+func (in *interpreter) extension(scope string, name ast.FullIdent, target string) (*symbols.Symbol, bool) {
+	if len(name.Parts) == 0 {
+		return nil, false
+	}
+
+	sym, ok := in.table.ResolveExtension(scope, name)
+	if !ok {
+		in.errorf(name.Parts[0].Idx, "unknown extension %q", name.String())
+		return nil, false
+	}
+
+	if extendee := in.extendee(sym); extendee != target {
+		in.errorf(name.Parts[0].Idx, "extension %q extends %q, not %q", sym.Name, extendee, target)
+		return nil, false
+	}
+	return sym, true
+}
+
+// extendee returns the fully-qualified name of the message extended
+// by ext, or the name as written when it cannot be resolved.
+func (in *interpreter) extendee(ext *symbols.Symbol) string {
+	extend := ext.Decl.(*ast.Field).Extend
+	if sym, ok := in.resolve(parentScope(ext.Name), extend.Extendee); ok {
+		return sym.Name
+	}
+	return strings.TrimPrefix(extend.Extendee.String(), ".")
+}
+
+// field returns the field named name in the message msg.
+func (in *interpreter) field(msg *symbols.Symbol, name ast.FullIdent) (*symbols.Symbol, bool) {
+	if len(name.Parts) == 0 {
+		return nil, false
+	}
+
+	text := name.String()
+	sym, ok := in.lookup(join(msg.Name, text))
+	if !ok || sym.Kind != symbols.KindField {
+		in.errorf(name.Parts[0].Idx, "unknown field %q in %q", text, msg.Name)
+		return nil, false
+	}
+	return sym, true
+}
+
+// fieldType returns the message or enum type of the field (or
+// extension) sym, it returns false for scalars and unknown types.
+func (in *interpreter) fieldType(sym *symbols.Symbol) (*symbols.Symbol, bool) {
+	field := sym.Decl.(*ast.Field)
+	if field.Map != nil || field.Type.IsScalar() {
+		return nil, false
+	}
+	return in.resolve(parentScope(sym.Name), field.Type.Name)
+}
+
+// lookup and resolve fall back to the embedded descriptor.proto when
+// the table does not index it.
+func (in *interpreter) lookup(name string) (*symbols.Symbol, bool) {
+	if sym, ok := in.table.Lookup(name); ok {
+		return sym, true
+	}
+	return descriptor().Lookup(name)
+}
+
+func (in *interpreter) resolve(scope string, name ast.FullIdent) (*symbols.Symbol, bool) {
+	if sym, ok := in.table.Resolve(scope, name); ok {
+		return sym, true
+	}
+	return descriptor().Resolve(scope, name)
+}
+
+// messageType returns the message type of sym, whose fields are set
+// by the next part of an option name.
+func (in *interpreter) messageType(sym *symbols.Symbol, next ast.FullIdent) (*symbols.Symbol, bool) {
+	typ, ok := in.fieldType(sym)
+	if !ok || typ.Kind != symbols.KindMessage {
+		in.errorf(next.Parts[0].Idx, "field %q is not a message", sym.Name)
+		return nil, false
+	}
+	if isRepeated(sym) {
+		in.errorf(next.Parts[0].Idx, "option %q is repeated, set it with an aggregate value", sym.Name)
+		return nil, false
+	}
+	return typ, true
+}
+
+func isRepeated(sym *symbols.Symbol) bool {
+	field := sym.Decl.(*ast.Field)
+	return field.Label == ast.LabelRepeated || field.Map != nil
+}
+
+// value checks that v can be assigned to the field sym. In the text
+// format (aggregates), enum values can also be set by number.
+func (in *interpreter) value(sym *symbols.Symbol, v ast.Value, name string, text bool) {
+	field := sym.Decl.(*ast.Field)
+	if field.Map != nil {
+		// the entries are aggregates of key and value, not checked
+		return
+	}
+
+	if field.Type.IsScalar() {
+		in.scalar(field.Type.Scalar, v, name)
+		return
+	}
+
+	typ, ok := in.fieldType(sym)
+	if !ok {
+		// unknown types are reported by the type checker
+		return
+	}
+
+	switch decl := typ.Decl.(type) {
+	case *ast.Enum:
+		in.enumValue(typ.Name, decl, v, name, text)
+	case *ast.Message:
+		if !v.IsAggregate() {
+			in.errorf(v.Idx, "option %q is a message, set it with an aggregate value", name)
+			return
+		}
+		in.aggregate(typ, v)
+	}
+}
+
+func (in *interpreter) scalar(kind lexer.TokenKind, v ast.Value, name string) {
+	var (
+		err     error
+		inRange = true
+	)
+
+	switch kind {
+	case lexer.TokenKindTypeInt32, lexer.TokenKindTypeSint32, lexer.TokenKindTypeSfixed32:
+		var i int64
+		i, err = v.Int()
+		inRange = i >= math.MinInt32 && i <= math.MaxInt32
+	case lexer.TokenKindTypeInt64, lexer.TokenKindTypeSint64, lexer.TokenKindTypeSfixed64:
+		_, err = v.Int()
+	case lexer.TokenKindTypeUint32, lexer.TokenKindTypeFixed32:
+		var u uint64
+		u, err = v.Uint()
+		inRange = u <= math.MaxUint32
+	case lexer.TokenKindTypeUint64, lexer.TokenKindTypeFixed64:
+		_, err = v.Uint()
+	case lexer.TokenKindTypeFloat, lexer.TokenKindTypeDouble:
+		_, err = v.Float()
+	case lexer.TokenKindTypeBool:
+		_, err = v.Bool()
+	case lexer.TokenKindTypeString, lexer.TokenKindTypeBytes:
+		_, err = v.String()
+	}
+
+	switch {
+	case errors.Is(err, strconv.ErrRange) || (err == nil && !inRange):
+		in.errorf(v.Idx, "value %s out of range for %s option %q", v.Text, kind, name)
+	case err != nil:
+		in.errorf(v.Idx, "invalid value %s for %s option %q", v.Text, kind, name)
+	}
+}
+
+func (in *interpreter) enumValue(enumName string, enum *ast.Enum, v ast.Value, name string, text bool) {
+	if text && v.Kind == lexer.TokenKindInt {
+		if _, err := v.Int(); err != nil {
+			in.errorf(v.Idx, "invalid value %s for %s option %q", v.Text, enumName, name)
+		}
+		return
+	}
+	if !v.Kind.IsIdentifier() {
+		in.errorf(v.Idx, "invalid value %s for %s option %q", v.Text, enumName, name)
+		return
+	}
+
+	for _, value := range enum.Values {
+		if value.Name.Text == v.Text {
+			return
+		}
+	}
+	in.errorf(v.Idx, "enum %q has no value named %q", enumName, v.Text)
+}
+
+// aggregate checks the fields of the text format value v against
+// the message msg. Extension names are fully-qualified.
+func (in *interpreter) aggregate(msg *symbols.Symbol, v ast.Value) {
+	set := make(map[string]bool)
+
+	for _, tf := range in.file.TextFields(v) {
+		if len(tf.Name.Parts) == 0 {
+			continue
+		}
+
+		var (
+			sym *symbols.Symbol
+			ok  bool
+		)
+		if tf.Extension {
+			sym, ok = in.extension("", tf.Name, msg.Name)
+		} else {
+			sym, ok = in.field(msg, tf.Name)
+		}
+		if !ok {
+			continue
+		}
+
+		name := tf.Name.String()
+		repeated := isRepeated(sym)
+		switch {
+		case set[sym.Name] && !repeated:
+			in.errorf(tf.Name.Parts[0].Idx, "field %q was already set", name)
+			continue
+		case tf.List && !repeated:
+			in.errorf(tf.Name.Parts[0].Idx, "field %q is not repeated, it cannot be set with a list", name)
+			continue
+		}
+		set[sym.Name] = true
+
+		for _, value := range tf.Values {
+			in.value(sym, value, name, true)
+		}
+	}
+}
@@ -0,0 +1,312 @@
+package options_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/Clement-Jean/protein/internal/asttest"
+	"github.com/Clement-Jean/protein/options"
+	"github.com/Clement-Jean/protein/symbols"
+)
+
+const custom = `syntax = "proto2";
+package custom;
+import "google/protobuf/descriptor.proto";
+
+enum Level { LOW = 0; HIGH = 1; }
+
+message Rule {
+  optional int32 min = 1;
+  optional string name = 2;
+  repeated string tags = 3;
+  optional Level level = 4;
+  optional Rule nested = 5;
+  extensions 100 to 200;
+}
+
+extend Rule {
+  optional bool strict = 100;
+}
+
+extend google.protobuf.FieldOptions {
+  optional Rule rule = 1000;
+  optional uint32 max = 1001;
+  repeated int32 ids = 1002;
+  optional Level level = 1003;
+}
+
+extend google.protobuf.MessageOptions {
+  optional string label = 1000;
+}
+
+extend google.protobuf.FileOptions {
+  optional double ratio = 1000;
+}
+`
+
+func TestInterpret(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name: "valid",
+			input: `package test;
+option (custom.ratio) = -1.5;
+message A {
+  option (custom.label) = "a";
+  optional int32 a = 1 [(custom.max) = 10, (custom.level) = HIGH, (custom.ids) = 1, (custom.ids) = 2];
+  optional int32 b = 2 [(custom.rule).min = 1, (custom.rule).nested.name = "n", (custom.rule).(custom.strict) = true];
+  optional int32 c = 3 [(custom.rule) = {
+    min: 1
+    tags: ["a", "b"]
+    tags: "c"
+    level: 1
+    nested { level: LOW };
+    [custom.strict]: true
+  }];
+}
+`,
+		},
+		{
+			name:     "unknown extension",
+			input:    `message A { option (custom.unknown) = 1; }`,
+			expected: []string{`unknown extension "custom.unknown"`},
+		},
+		{
+			name:     "wrong options",
+			input:    `message A { optional int32 a = 1 [(custom.label) = "a"]; }`,
+			expected: []string{`extension "custom.label" extends "google.protobuf.MessageOptions", not "google.protobuf.FieldOptions"`},
+		},
+		{
+			name: "already set",
+			input: `message A {
+  optional int32 a = 1 [(custom.max) = 1, (custom.max) = 2];
+  optional int32 b = 2 [(custom.rule).min = 1, (custom.rule).min = 2, (custom.rule).name = "b"];
+}
+`,
+			expected: []string{`option "(custom.max)" was already set`, `option "(custom.rule).min" was already set`},
+		},
+		{
+			name: "path",
+			input: `message A {
+  optional int32 a = 1 [(custom.max).min = 1];
+  optional int32 b = 2 [(custom.rule).unknown = 1];
+  optional int32 c = 3 [(custom.rule).(custom.max) = 1];
+}
+`,
+			expected: []string{
+				`field "custom.max" is not a message`,
+				`unknown field "unknown" in "custom.Rule"`,
+				`extension "custom.max" extends "google.protobuf.FieldOptions", not "custom.Rule"`,
+			},
+		},
+		{
+			name: "scalars",
+			input: `message A {
+  optional int32 a = 1 [(custom.max) = -1];
+  optional int32 b = 2 [(custom.max) = 4294967296];
+  optional int32 c = 3 [(custom.rule).min = "1"];
+  optional int32 d = 4 [(custom.rule).(custom.strict) = 1];
+}
+`,
+			expected: []string{
+				`invalid value -1 for uint32 option "(custom.max)"`,
+				`value 4294967296 out of range for uint32 option "(custom.max)"`,
+				`invalid value "1" for int32 option "(custom.rule).min"`,
+				`invalid value 1 for bool option "(custom.rule).(custom.strict)"`,
+			},
+		},
+		{
+			name: "enums",
+			input: `message A {
+  optional int32 a = 1 [(custom.level) = MEDIUM];
+  optional int32 b = 2 [(custom.level) = 1];
+}
+`,
+			expected: []string{
+				`enum "custom.Level" has no value named "MEDIUM"`,
+				`invalid value 1 for custom.Level option "(custom.level)"`,
+			},
+		},
+		{
+			name: "aggregates",
+			input: `message A {
+  optional int32 a = 1 [(custom.rule) = 1];
+  optional int32 b = 2 [(custom.rule) = { min: 1 min: 2 name: ["a"] unknown: 1; [custom.max]: 1 }];
+}
+`,
+			expected: []string{
+				`option "(custom.rule)" is a message, set it with an aggregate value`,
+				`field "min" was already set`,
+				`field "name" is not repeated, it cannot be set with a list`,
+				`unknown field "unknown" in "custom.Rule"`,
+				`extension "custom.max" extends "google.protobuf.FieldOptions", not "custom.Rule"`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			file := asttest.NewFile(t, test.input)
+			table, errs := symbols.New(asttest.NewFile(t, custom), file)
+			if len(errs) != 0 {
+				t.Fatal(errs)
+			}
+
+			_, errs = options.Interpret(file, table)
+			var got []string
+			for _, err := range errs {
+				got = append(got, err.Error())
+			}
+			if diff := cmp.Diff(test.expected, got); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestStandard(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name: "valid",
+			input: `syntax = "proto2";
+option java_package = "com.example";
+option go_package = "example.com/test";
+option optimize_for = LITE_RUNTIME;
+option cc_enable_arenas = true;
+message A {
+  option deprecated = true;
+  repeated int32 a = 1 [packed = true, deprecated = true];
+  optional A b = 2 [lazy = true, json_name = "bee"];
+  optional string c = 3 [default = "c", features.utf8_validation = NONE];
+  optional int32 d = 4 [default = -1, ctype = CORD, jstype = JS_STRING];
+}
+service S {
+  rpc M (A) returns (A) { option idempotency_level = NO_SIDE_EFFECTS; }
+}
+`,
+		},
+		{
+			name: "scope",
+			input: `option packed = true;
+message A {
+  option java_package = "a";
+  optional int32 a = 1 [optimize_for = SPEED, unknown = 1];
+}
+`,
+			expected: []string{
+				`option "packed" is not allowed on a file, only on a field`,
+				`option "java_package" is not allowed on a message, only on a file`,
+				`option "optimize_for" is not allowed on a field, only on a file`,
+				`unknown option "unknown"`,
+			},
+		},
+		{
+			name: "types",
+			input: `option java_package = 1;
+option optimize_for = FAST;
+message A {
+  option deprecated = "yes";
+  optional int32 a = 1 [features.field_presence = 1];
+}
+`,
+			expected: []string{
+				`invalid value 1 for string option "java_package"`,
+				`enum "google.protobuf.FileOptions.OptimizeMode" has no value named "FAST"`,
+				`invalid value "yes" for bool option "deprecated"`,
+				`invalid value 1 for google.protobuf.FeatureSet.FieldPresence option "features.field_presence"`,
+			},
+		},
+		{
+			name: "pseudo options",
+			input: `message A {
+  repeated int32 a = 1 [default = 1];
+  optional A b = 2 [default = {}];
+  optional int32 c = 3 [default = "c", json_name = 1];
+  optional int32 d = 4 [json_name = "d", json_name = "e"];
+}
+extend A {
+  optional int32 e = 5 [json_name = "e"];
+}
+`,
+			expected: []string{
+				`repeated fields cannot have a default value`,
+				`message fields cannot have a default value`,
+				`invalid value "c" for int32 option "default"`,
+				`invalid value 1 for string option "json_name"`,
+				`option "json_name" was already set`,
+				`option "json_name" is not allowed on extensions`,
+			},
+		},
+		{
+			name: "already set",
+			input: `option java_package = "a";
+option java_package = "b";
+`,
+			expected: []string{`option "java_package" was already set`},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			file := asttest.NewFile(t, test.input)
+			table, errs := symbols.New(file)
+			if len(errs) != 0 {
+				t.Fatal(errs)
+			}
+
+			_, errs = options.Interpret(file, table)
+			var got []string
+			for _, err := range errs {
+				got = append(got, err.Error())
+			}
+			if diff := cmp.Diff(test.expected, got); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInterpretPath(t *testing.T) {
+	file := asttest.NewFile(t, `message A {
+  optional int32 a = 1 [deprecated = true, (custom.rule).nested.(custom.strict) = true];
+}
+`)
+	table, errs := symbols.New(asttest.NewFile(t, custom), file)
+	if len(errs) != 0 {
+		t.Fatal(errs)
+	}
+
+	opts, errs := options.Interpret(file, table)
+	if len(errs) != 0 {
+		t.Fatal(errs)
+	}
+
+	var got [][]string
+	for _, opt := range opts {
+		if opt.Target != "google.protobuf.FieldOptions" {
+			t.Errorf("expected FieldOptions, got %q", opt.Target)
+		}
+
+		var path []string
+		for _, sym := range opt.Path {
+			path = append(path, sym.Name)
+		}
+		got = append(got, path)
+	}
+
+	expected := [][]string{
+		{"google.protobuf.FieldOptions.deprecated"},
+		{"custom.rule", "custom.Rule.nested", "custom.strict"},
+	}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,69 @@
+package parser
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Clement-Jean/protein/lexer"
+	"github.com/Clement-Jean/protein/source"
+)
+
+// Arena recycles the token and node buffers across parses. The
+// buffers of a Result are given back with Release and must not be
+// used after that. An Arena is safe for concurrent use.
+type Arena struct {
+	mu    sync.Mutex
+	toks  []*lexer.TokenizedBuffer
+	trees []ParseTree
+}
+
+func (a *Arena) get() (*lexer.TokenizedBuffer, ParseTree) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	toks := &lexer.TokenizedBuffer{}
+	if n := len(a.toks); n != 0 {
+		toks = a.toks[n-1]
+		a.toks = a.toks[:n-1]
+	}
+
+	var tree ParseTree
+	if n := len(a.trees); n != 0 {
+		tree = a.trees[n-1]
+		a.trees = a.trees[:n-1]
+	}
+	return toks, tree
+}
+
+// Parse lexes and parses src with recycled buffers. The errors are
+// positioned in file, which can be nil.
+func (a *Arena) Parse(src *source.Buffer, file *source.File) Result {
+	return parseSource(src, file, a)
+}
+
+// ParseFile lexes and parses filename with recycled buffers. The file
+// is added to fset.
+func (a *Arena) ParseFile(fset *source.FileSet, filename string) Result {
+	return parseFile(fset, filename, a)
+}
+
+// ParseFiles is ParseFiles with recycled buffers.
+func (a *Arena) ParseFiles(ctx context.Context, fset *source.FileSet, filenames []string, workers int) ([]Result, error) {
+	return parseFiles(ctx, fset, filenames, workers, a)
+}
+
+// Release gives the buffers of res back to the arena and clears them.
+func (a *Arena) Release(res *Result) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if res.Toks != nil {
+		res.Toks.Reset()
+		a.toks = append(a.toks, res.Toks)
+	}
+	if res.Tree != nil {
+		a.trees = append(a.trees, res.Tree[:0])
+	}
+	res.Toks = nil
+	res.Tree = nil
+}
@@ -0,0 +1,96 @@
+package parser_test
+
+import (
+	"testing"
+
+	"github.com/Clement-Jean/protein/lexer"
+	"github.com/Clement-Jean/protein/parser"
+	"github.com/Clement-Jean/protein/source"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func corpusSources(tb testing.TB) []*source.Buffer {
+	tb.Helper()
+
+	var srcs []*source.Buffer
+	for _, file := range corpusFiles(tb) {
+		src, err := source.NewFromFile(file)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		tb.Cleanup(func() { src.Close() })
+		srcs = append(srcs, src)
+	}
+	return srcs
+}
+
+func TestArena(t *testing.T) {
+	var arena parser.Arena
+	srcs := corpusSources(t)
+
+	// the results of the arena are compared with the ones of fresh
+	// buffers, a corruption by the reuse cannot be on both sides
+	type parsed struct {
+		toks *lexer.TokenizedBuffer
+		tree parser.ParseTree
+	}
+	expected := make([]parsed, len(srcs))
+	for i, src := range srcs {
+		l, err := lexer.NewFromSource(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		toks, _ := l.Lex()
+		tree, _ := parser.New(toks).Parse()
+		expected[i] = parsed{toks, tree}
+	}
+
+	for range 2 {
+		for i, src := range srcs {
+			res := arena.Parse(src, nil)
+
+			if diff := cmp.Diff(expected[i].tree, res.Tree); diff != "" {
+				t.Errorf("tree mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(expected[i].toks, res.Toks); diff != "" {
+				t.Errorf("tokens mismatch (-want +got):\n%s", diff)
+			}
+
+			arena.Release(&res)
+			if res.Toks != nil || res.Tree != nil {
+				t.Fatal("expected the buffers to be cleared")
+			}
+		}
+	}
+}
+
+func BenchmarkArena(b *testing.B) {
+	srcs := corpusSources(b)
+
+	b.Run("without", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			for _, src := range srcs {
+				l, err := lexer.NewFromSource(src)
+				if err != nil {
+					b.Fatal(err)
+				}
+				toks, _ := l.Lex()
+				parser.New(toks).Parse()
+			}
+		}
+	})
+
+	b.Run("with", func(b *testing.B) {
+		var arena parser.Arena
+
+		b.ReportAllocs()
+		for range b.N {
+			for _, src := range srcs {
+				res := arena.Parse(src, nil)
+				arena.Release(&res)
+			}
+		}
+	})
+}
@@ -0,0 +1,173 @@
+package parser
+
+import (
+	"context"
+	"runtime"
+	"sync"
+
+	"github.com/Clement-Jean/protein/lexer"
+	"github.com/Clement-Jean/protein/source"
+)
+
+// Result is the outcome of parsing a file. Errs contains the error
+// preventing the file from being read or the lexing and parsing errors.
+// Src is owned by the caller which should close it when done. File is
+// the file of Src in the source.FileSet, if any.
+type Result struct {
+	Filename string
+	Src      *source.Buffer
+	File     *source.File
+	Toks     *lexer.TokenizedBuffer
+	Tree     ParseTree
+	Errs     []error
+}
+
+// openFile reads filename and adds it to fset, the result has no
+// tokens and no tree yet. The file is added once prev is closed and
+// done is closed after, so that the files read concurrently are
+// added in order. They can be nil. Nothing is read when ctx is done.
+func openFile(ctx context.Context, fset *source.FileSet, filename string, prev, done chan struct{}) Result {
+	res := Result{Filename: filename}
+
+	var src *source.Buffer
+	err := ctx.Err()
+	if err == nil {
+		src, err = source.NewFromFile(filename)
+	}
+
+	if prev != nil {
+		<-prev
+	}
+	if err == nil {
+		if res.File, err = fset.AddFile(filename, src); err != nil {
+			src.Close()
+		}
+	}
+	if done != nil {
+		close(done)
+	}
+
+	if err != nil {
+		res.Errs = []error{err}
+		return res
+	}
+	res.Src = src
+	return res
+}
+
+func parseFile(fset *source.FileSet, filename string, a *Arena) Result {
+	res := openFile(context.Background(), fset, filename, nil, nil)
+	if res.Src == nil {
+		return res
+	}
+
+	res = parseSource(res.Src, res.File, a)
+	res.Filename = filename
+	return res
+}
+
+func parseSource(src *source.Buffer, file *source.File, a *Arena) Result {
+	res := Result{Src: src, File: file}
+
+	var toks *lexer.TokenizedBuffer
+	var tree ParseTree
+	if a != nil {
+		toks, tree = a.get()
+	} else {
+		toks = &lexer.TokenizedBuffer{}
+	}
+
+	l, err := lexer.NewFromSourceWithBuffer(src, toks)
+	if err != nil {
+		res.Errs = []error{err}
+		return res
+	}
+
+	toks, errs := l.Lex()
+	res.Toks = toks
+	res.Errs = append(src.Validate(), errs...)
+
+	tree, errs = NewForFile(file, toks, tree).Parse()
+	res.Tree = tree
+	res.Errs = append(res.Errs, errs...)
+	return res
+}
+
+// ParseFiles lexes and parses files concurrently with at most workers
+// goroutines (GOMAXPROCS when workers <= 0). The files are read by
+// the workers but added to fset in the order of filenames, and the
+// results are in the same order. When ctx is done, the files which
+// were not read yet are skipped, with ctx.Err() in their Errs, and
+// ctx.Err() is returned.
+func ParseFiles(ctx context.Context, fset *source.FileSet, filenames []string, workers int) ([]Result, error) {
+	return parseFiles(ctx, fset, filenames, workers, nil)
+}
+
+func parseFiles(ctx context.Context, fset *source.FileSet, filenames []string, workers int, a *Arena) ([]Result, error) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = min(workers, len(filenames))
+
+	// added[i] is closed once the file i is added to fset, the files
+	// are read by the workers but added in the order of filenames so
+	// that their positions are always the same
+	added := make([]chan struct{}, len(filenames))
+	for i := range added {
+		added[i] = make(chan struct{})
+	}
+
+	results := make([]Result, len(filenames))
+	indices := make(chan int)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for range workers {
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				var prev chan struct{}
+				if i > 0 {
+					prev = added[i-1]
+				}
+
+				// each worker only writes to its own results
+				res := &results[i]
+				if *res = openFile(ctx, fset, filenames[i], prev, added[i]); res.Src == nil {
+					continue
+				}
+				*res = parseSource(res.Src, res.File, a)
+				res.Filename = filenames[i]
+			}
+		}()
+	}
+
+	err := ctx.Err()
+	sent := 0
+	for sent < len(filenames) && err == nil {
+		select {
+		case <-ctx.Done():
+			err = ctx.Err()
+		case indices <- sent:
+			sent++
+		}
+	}
+	close(indices)
+	wg.Wait()
+
+	if err == nil {
+		// the context can be done after the files were sent
+		for _, res := range results[:sent] {
+			if res.Src == nil && len(res.Errs) == 1 && res.Errs[0] == ctx.Err() {
+				err = ctx.Err()
+				break
+			}
+		}
+	}
+
+	// the files which were not sent to the workers are skipped
+	for i := sent; i < len(results); i++ {
+		results[i] = Result{Filename: filenames[i], Errs: []error{err}}
+	}
+	return results, err
+}
@@ -0,0 +1,169 @@
+package parser_test
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/Clement-Jean/protein/lexer"
+	"github.com/Clement-Jean/protein/parser"
+	"github.com/Clement-Jean/protein/source"
+)
+
+func corpusFiles(tb testing.TB) []string {
+	tb.Helper()
+
+	var files []string
+	err := filepath.WalkDir(filepath.Join(basepath, "../corpus/"), func(s string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if filepath.Ext(d.Name()) == ".proto" {
+			files = append(files, s)
+		}
+		return nil
+	})
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return files
+}
+
+// closeSources closes the sources of the results, they are mapped in
+// memory for the big files.
+func closeSources(results []parser.Result) {
+	for _, res := range results {
+		if res.Src != nil {
+			res.Src.Close()
+		}
+	}
+}
+
+// parse lexes and parses filename sequentially.
+func parse(tb testing.TB, filename string) parser.ParseTree {
+	tb.Helper()
+
+	src, err := source.NewFromFile(filename)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	defer src.Close()
+
+	l, err := lexer.NewFromSource(src)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	toks, _ := l.Lex()
+	tree, _ := parser.New(toks).Parse()
+	return tree
+}
+
+func TestParseFiles(t *testing.T) {
+	dir := t.TempDir()
+	invalid := filepath.Join(dir, "invalid.proto")
+	if err := os.WriteFile(invalid, []byte("syntax = ;"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	files := append(corpusFiles(t), invalid, filepath.Join(dir, "missing.proto"))
+	fset := source.NewFileSet()
+	results, err := parser.ParseFiles(context.Background(), fset, files, 4)
+	defer closeSources(results)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(results) != len(files) {
+		t.Fatalf("expected %d results, got %d", len(files), len(results))
+	}
+	for i, res := range results {
+		if res.Filename != files[i] {
+			t.Errorf("expected %s at %d, got %s", files[i], i, res.Filename)
+		}
+	}
+
+	// the files are added in the order of files, whatever the
+	// order in which the workers parse them
+	var names []string
+	for _, f := range fset.Files() {
+		names = append(names, f.Name())
+	}
+	if expected := files[:len(files)-1]; !slices.Equal(expected, names) {
+		t.Errorf("expected the files %v, got %v", expected, names)
+	}
+
+	if res := results[len(files)-2]; len(res.Errs) == 0 || len(res.Tree) == 0 {
+		t.Errorf("expected a tree and errors for %s, got %v", res.Filename, res.Errs)
+	} else if err, ok := res.Errs[0].(*parser.ExpectedError); ok {
+		expected := source.Position{Filename: invalid, Offset: 9, Line: 1, Column: 10}
+		if pos := fset.Position(err.Pos); pos != expected {
+			t.Errorf("expected the error at %s, got %s", expected, pos)
+		}
+	} else {
+		t.Errorf("expected an ExpectedError, got %v", res.Errs[0])
+	}
+	if res := results[len(files)-1]; len(res.Errs) != 1 || res.Tree != nil {
+		t.Errorf("expected a read error for %s, got %v", res.Filename, res.Errs)
+	}
+
+	// the results are the same as parsing sequentially
+	for _, res := range results[:len(files)-2] {
+		if tree := parse(t, res.Filename); len(tree) != len(res.Tree) {
+			t.Errorf("%s: expected %d nodes, got %d", res.Filename, len(tree), len(res.Tree))
+		}
+	}
+}
+
+func TestParseFilesCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// the missing file would report a read error if it was opened
+	dir := t.TempDir()
+	files := append(corpusFiles(t), filepath.Join(dir, "missing.proto"))
+	fset := source.NewFileSet()
+	results, err := parser.ParseFiles(ctx, fset, files, 2)
+	defer closeSources(results)
+	if err != context.Canceled {
+		t.Fatalf("expected %v, got %v", context.Canceled, err)
+	}
+
+	for i, res := range results {
+		if res.Filename != files[i] || res.Src != nil || res.File != nil || res.Tree != nil {
+			t.Errorf("expected %s to be skipped", files[i])
+		}
+		if len(res.Errs) != 1 || res.Errs[0] != context.Canceled {
+			t.Errorf("expected %s to be canceled, got %v", files[i], res.Errs)
+		}
+	}
+	if files := fset.Files(); len(files) != 0 {
+		t.Errorf("expected no file to be opened, got %d", len(files))
+	}
+}
+
+func BenchmarkParseFiles(b *testing.B) {
+	files := corpusFiles(b)
+
+	b.Run("sequential", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			for _, file := range files {
+				parse(b, file)
+			}
+		}
+	})
+
+	b.Run("concurrent", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			results, err := parser.ParseFiles(context.Background(), source.NewFileSet(), files, 0)
+			closeSources(results)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,5 @@
+//go:build !protein_debug
+
+package parser
+
+const debug = false
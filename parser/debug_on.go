@@ -0,0 +1,6 @@
+//go:build protein_debug
+
+package parser
+
+// debug makes Parse verify the trees it builds.
+const debug = true
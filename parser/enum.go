@@ -12,7 +12,7 @@ func (p *Parser) parseEnumBlock() {
 	p.popState()
 
 	hasError := p.curr() != lexer.TokenKindLeftBrace
-	p.addLeafNode(hasError)
+	p.addExpectedLeaf(hasError, lexer.TokenKindLeftBrace)
 
 	if !hasError {
 		p.next()
@@ -52,7 +52,7 @@ func (p *Parser) parseEnumValue() {
 			break
 		}
 		p.expectedCurr(enumScopeExpected...)
-		p.skipPastLikelyEnd(p.currTok)
+		p.skipStatement()
 	}
 }
 
@@ -64,9 +64,15 @@ func (p *Parser) parseEnumFinish() {
 
 	if !state.hasError {
 		p.next()
+	} else if p.isMissing() {
+		p.expectedCurr(lexer.TokenKindRightBrace)
+		p.addMissingNode(lexer.TokenKindRightBrace, state)
+		return
 	} else {
 		p.expectedCurr(lexer.TokenKindRightBrace)
 		tokIdx = p.skipPastLikelyEnd(tokIdx)
+		p.addRecoveredNode(tokIdx, state, lexer.TokenKindRightBrace)
+		return
 	}
 
 	p.addNode(tokIdx, state)
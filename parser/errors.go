@@ -1,17 +1,131 @@
 package parser
 
 import (
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/Clement-Jean/protein/lexer"
+	"github.com/Clement-Jean/protein/source"
 )
 
+// ErrNoTokens is returned when parsing an empty TokenizedBuffer.
+var ErrNoTokens = errors.New("no tokens to parse")
+
+// ExpectedError is an unexpected token at Pos.
 type ExpectedError struct {
 	Expected []lexer.TokenKind
 	Got      lexer.TokenKind
-	TokIdx   uint32
+	Pos      source.Pos
+}
+
+// expectedGroups are the categories replacing their kinds in the
+// error messages when all of them are expected. The first matching
+// group takes the kinds.
+var expectedGroups = []struct {
+	name  string
+	kinds []lexer.TokenKind
+}{
+	{"a field label", []lexer.TokenKind{
+		lexer.TokenKindOptional,
+		lexer.TokenKindRepeated,
+		lexer.TokenKindRequired,
+	}},
+	{"a field type", append(slices.Collect(lexer.ScalarTypes()), lexer.TokenKindIdentifier)},
+	{"a map key type", mapKeyTypes},
+	{"a constant", constantTypes},
+	{"a nested definition", []lexer.TokenKind{
+		lexer.TokenKindMessage,
+		lexer.TokenKindEnum,
+		lexer.TokenKindOneOf,
+		lexer.TokenKindExtend,
+	}},
+	{"a definition", []lexer.TokenKind{
+		lexer.TokenKindMessage,
+		lexer.TokenKindEnum,
+		lexer.TokenKindService,
+		lexer.TokenKindExtend,
+	}},
+}
+
+// Categories returns the descriptions of the expected tokens, in the
+// order of Expected. The kinds forming a category (e.g. all the scalar
+// types and Identifier) are described by the category name and the
+// others on their own.
+func (e *ExpectedError) Categories() []string {
+	var names []string
+	grouped := make(map[lexer.TokenKind]int)
+	for i, group := range expectedGroups {
+		if !containsAll(e.Expected, group.kinds, grouped) {
+			continue
+		}
+		for _, kind := range group.kinds {
+			grouped[kind] = i
+		}
+	}
+
+	seen := make(map[int]bool)
+	for _, kind := range e.Expected {
+		i, ok := grouped[kind]
+		if !ok {
+			names = append(names, describe(kind))
+		} else if !seen[i] {
+			seen[i] = true
+			names = append(names, expectedGroups[i].name)
+		}
+	}
+	return names
+}
+
+func containsAll(expected, kinds []lexer.TokenKind, grouped map[lexer.TokenKind]int) bool {
+	for _, kind := range kinds {
+		if _, ok := grouped[kind]; ok || !slices.Contains(expected, kind) {
+			return false
+		}
+	}
+	return true
+}
+
+func describe(kind lexer.TokenKind) string {
+	switch kind {
+	case lexer.TokenKindEOF:
+		return "end of file"
+	case lexer.TokenKindError:
+		return "an invalid token"
+	case lexer.TokenKindComment:
+		return "a comment"
+	case lexer.TokenKindIdentifier:
+		return "an identifier"
+	case lexer.TokenKindInt:
+		return "an integer"
+	case lexer.TokenKindFloat:
+		return "a float"
+	case lexer.TokenKindStr:
+		return "a string"
+	}
+	return fmt.Sprintf("%q", kind.String())
 }
 
 func (e *ExpectedError) Error() string {
-	return fmt.Sprintf("expected %v, got %s", e.Expected, e.Got)
+	names := e.Categories()
+	var expected string
+	switch len(names) {
+	case 0:
+		expected = "nothing"
+	case 1:
+		expected = names[0]
+	default:
+		expected = strings.Join(names[:len(names)-1], ", ") + " or " + names[len(names)-1]
+	}
+	return fmt.Sprintf("expected %s, got %s", expected, describe(e.Got))
+}
+
+// Offset returns the offset of the error in the source. file is the
+// one given to the parser, nil if there was none.
+func (e *ExpectedError) Offset(file *source.File) uint32 {
+	if file == nil {
+		return uint32(e.Pos) - 1
+	}
+	return file.Offset(e.Pos)
 }
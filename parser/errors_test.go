@@ -0,0 +1,72 @@
+package parser_test
+
+import (
+	"testing"
+
+	"github.com/Clement-Jean/protein/lexer"
+	"github.com/Clement-Jean/protein/parser"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExpectedError(t *testing.T) {
+	tests := []struct {
+		name       string
+		expected   []lexer.TokenKind
+		got        lexer.TokenKind
+		categories []string
+		msg        string
+	}{
+		{
+			name:       "single",
+			expected:   []lexer.TokenKind{lexer.TokenKindSemicolon},
+			got:        lexer.TokenKindEOF,
+			categories: []string{`";"`},
+			msg:        `expected ";", got end of file`,
+		},
+		{
+			name:       "literals",
+			expected:   []lexer.TokenKind{lexer.TokenKindInt, lexer.TokenKindStr},
+			got:        lexer.TokenKindMessage,
+			categories: []string{"an integer", "a string"},
+			msg:        `expected an integer or a string, got "message"`,
+		},
+		{
+			name: "incomplete category",
+			expected: []lexer.TokenKind{
+				lexer.TokenKindMessage,
+				lexer.TokenKindEnum,
+				lexer.TokenKindRightBrace,
+			},
+			got:        lexer.TokenKindIdentifier,
+			categories: []string{`"message"`, `"enum"`, `"}"`},
+			msg:        `expected "message", "enum" or "}", got an identifier`,
+		},
+		{
+			name: "categories",
+			expected: []lexer.TokenKind{
+				lexer.TokenKindSyntax,
+				lexer.TokenKindService,
+				lexer.TokenKindEnum,
+				lexer.TokenKindMessage,
+				lexer.TokenKindExtend,
+			},
+			got:        lexer.TokenKindInt,
+			categories: []string{`"syntax"`, "a definition"},
+			msg:        `expected "syntax" or a definition, got an integer`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := &parser.ExpectedError{Expected: test.expected, Got: test.got}
+
+			if diff := cmp.Diff(test.categories, err.Categories()); diff != "" {
+				t.Errorf("categories mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(test.msg, err.Error()); diff != "" {
+				t.Errorf("message mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package parser
+
+import "github.com/Clement-Jean/protein/lexer"
+
+func (p *Parser) parseExtend() {
+	p.pushState(stateExtendFinish)
+	p.pushState(stateExtendBlock)
+	p.pushState(stateFullIdentifierRoot)
+	if p.curr() == lexer.TokenKindDot {
+		p.addLeafNode(false)
+		p.next()
+	}
+}
+
+func (p *Parser) parseExtendBlock() {
+	p.popState()
+
+	hasError := p.curr() != lexer.TokenKindLeftBrace
+	p.addExpectedLeaf(hasError, lexer.TokenKindLeftBrace)
+
+	if !hasError {
+		p.next()
+	} else {
+		p.expectedCurr(lexer.TokenKindLeftBrace)
+		p.skipPastLikelyEnd(p.currTok)
+	}
+
+	p.pushState(stateExtendValue)
+}
+
+var extendScopeExpected = []lexer.TokenKind{
+	lexer.TokenKindOptional,
+	lexer.TokenKindRepeated,
+	lexer.TokenKindRequired,
+	lexer.TokenKindTypeDouble,
+	lexer.TokenKindTypeFloat,
+	lexer.TokenKindTypeInt32,
+	lexer.TokenKindTypeInt64,
+	lexer.TokenKindTypeUint32,
+	lexer.TokenKindTypeUint64,
+	lexer.TokenKindTypeSint32,
+	lexer.TokenKindTypeSint64,
+	lexer.TokenKindTypeFixed32,
+	lexer.TokenKindTypeFixed64,
+	lexer.TokenKindTypeSfixed32,
+	lexer.TokenKindTypeSfixed64,
+	lexer.TokenKindTypeBool,
+	lexer.TokenKindTypeString,
+	lexer.TokenKindTypeBytes,
+	lexer.TokenKindIdentifier,
+	lexer.TokenKindRightBrace,
+}
+
+func (p *Parser) parseExtendValue() {
+	switch curr := p.curr(); curr {
+	case lexer.TokenKindSemicolon, lexer.TokenKindComment:
+		p.next()
+	case lexer.TokenKindEOF, lexer.TokenKindRightBrace:
+		p.popState()
+	default:
+		p.parseLabeledField(curr, extendScopeExpected)
+	}
+}
+
+func (p *Parser) parseExtendFinish() {
+	state := p.popState()
+	tokIdx := p.currTok
+
+	state.hasError = p.curr() != lexer.TokenKindRightBrace
+
+	if !state.hasError {
+		p.next()
+	} else if p.isMissing() {
+		p.expectedCurr(lexer.TokenKindRightBrace)
+		p.addMissingNode(lexer.TokenKindRightBrace, state)
+		return
+	} else {
+		p.expectedCurr(lexer.TokenKindRightBrace)
+		tokIdx = p.skipPastLikelyEnd(tokIdx)
+		p.addRecoveredNode(tokIdx, state, lexer.TokenKindRightBrace)
+		return
+	}
+
+	p.addNode(tokIdx, state)
+}
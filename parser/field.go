@@ -17,56 +17,74 @@ func (p *Parser) parseMessageFieldAssign() {
 	curr := p.curr()
 	hasError := !curr.IsIdentifier()
 
-	if hasError {
+	if curr == lexer.TokenKindEqual {
+		p.expectedCurr(lexer.TokenKindIdentifier)
+		p.addMissingLeaf(lexer.TokenKindIdentifier)
+	} else if hasError {
 		p.popState()
 		p.expectedCurr(lexer.TokenKindIdentifier)
 		tokIdx := p.skipPastLikelyEnd(p.currTok)
-		p.addNode(tokIdx, stateStackEntry{
+		p.addRecoveredNode(tokIdx, stateStackEntry{
 			tokIdx:       tokIdx,
 			subtreeStart: state.subtreeStart + 1,
 			hasError:     true,
-		})
+		}, lexer.TokenKindSemicolon)
 		return
-	}
-
-	// the name of a field can be a keyword (e.g) message
-	// so we override the kind to be an identifier in order
-	// to make sure we don't treat this as something else
-	// than an identifier
-	p.toks.TokenInfos[p.currTok].Kind = lexer.TokenKindIdentifier
+	} else {
+		// the name of a field can be a keyword (e.g) message
+		// so we override the kind to be an identifier in order
+		// to make sure we don't treat this as something else
+		// than an identifier
+		p.toks.TokenInfos[p.currTok].Kind = lexer.TokenKindIdentifier
 
-	p.addLeafNode(hasError)
-	curr = p.next()
+		p.addLeafNode(hasError)
+		curr = p.next()
+	}
 
 	hasError = curr != lexer.TokenKindEqual
 	equalTok := p.currTok
+	equalMissing := curr == lexer.TokenKindInt
 
-	if hasError {
-		p.addLeafNode(true)
+	if equalMissing {
+		p.expectedCurr(lexer.TokenKindEqual)
+	} else if hasError {
+		p.addExpectedLeaf(true, lexer.TokenKindEqual)
 		p.popState()
 		p.expectedCurr(lexer.TokenKindEqual)
+		p.nextAfterErrorLeaf()
 		tokIdx := p.skipPastLikelyEnd(p.currTok)
-		p.addNode(tokIdx, stateStackEntry{
+		p.addRecoveredNode(tokIdx, stateStackEntry{
 			tokIdx:       tokIdx,
 			subtreeStart: state.subtreeStart + 1,
 			hasError:     false,
-		})
+		}, lexer.TokenKindSemicolon)
 		return
+	} else {
+		curr = p.next()
 	}
-	curr = p.next()
 
 	hasError = curr != lexer.TokenKindInt
-	p.addLeafNode(hasError)
+	p.addExpectedLeaf(hasError, lexer.TokenKindInt)
 
 	if !hasError {
 		curr = p.next()
 	} else {
 		p.expectedCurr(lexer.TokenKindInt)
 		p.skipPastLikelyEnd(p.currTok)
+		curr = p.curr()
 	}
 
 	state.subtreeStart += introducerLen
-	p.addNode(equalTok, state)
+	if equalMissing {
+		p.tree = append(p.tree, Node{
+			TokIdx:      equalTok,
+			SubtreeSize: uint32(len(p.tree)) - state.subtreeStart + 1,
+			HasError:    true,
+			Missing:     lexer.TokenKindEqual,
+		})
+	} else {
+		p.addNode(equalTok, state)
+	}
 
 	if curr == lexer.TokenKindLeftSquare {
 		p.addLeafNode(false)
@@ -93,7 +111,7 @@ func (p *Parser) parseMessageFieldOption() {
 }
 
 func (p *Parser) parseMessageFieldOptionAssign() {
-	p.popState()
+	state := p.popState()
 
 	curr := p.curr()
 	hasError := curr != lexer.TokenKindEqual
@@ -102,11 +120,15 @@ func (p *Parser) parseMessageFieldOptionAssign() {
 		p.pushState(stateEnder)
 		curr = p.next()
 	} else {
-		p.tree = append(p.tree, Node{
+		node := Node{
 			TokIdx:      p.currTok,
 			HasError:    hasError,
-			SubtreeSize: 2,
-		})
+			SubtreeSize: uint32(len(p.tree)) - state.subtreeStart, // the name and the =
+		}
+		if isLeftForLater(curr) {
+			node.Missing = lexer.TokenKindEqual
+		}
+		p.tree = append(p.tree, node)
 		p.expectedCurr(lexer.TokenKindEqual)
 		p.skipTo(lexer.TokenKindComma, lexer.TokenKindRightSquare)
 		return
@@ -125,7 +147,7 @@ func (p *Parser) parseMessageFieldOptionAssign() {
 			return
 		}
 
-		p.addLeafNode(true)
+		p.addExpectedLeaf(true, lexer.TokenKindIdentifier)
 		p.expectedCurr(constantTypes...)
 		p.skipTo(lexer.TokenKindComma, lexer.TokenKindRightSquare)
 		return
@@ -152,6 +174,8 @@ func (p *Parser) parseMessageFieldOptionFinish() {
 	} else {
 		p.expectedCurr(lexer.TokenKindRightSquare)
 		currTok = p.skipPastLikelyEnd(p.currTok)
+		p.addRecoveredNode(currTok, state, lexer.TokenKindRightSquare)
+		return
 	}
 
 	p.addNode(currTok, state)
@@ -162,14 +186,20 @@ func (p *Parser) parseMessageFieldFinish() {
 	tokIdx := p.currTok
 
 	state.hasError = p.curr() != lexer.TokenKindSemicolon
+	state.subtreeStart++
 
 	if !state.hasError {
 		p.next()
+	} else if p.isMissing() {
+		p.expectedCurr(lexer.TokenKindSemicolon)
+		p.addMissingNode(lexer.TokenKindSemicolon, state)
+		return
 	} else {
 		p.expectedCurr(lexer.TokenKindSemicolon)
 		tokIdx = p.skipPastLikelyEnd(p.currTok)
+		p.addRecoveredNode(tokIdx, state, lexer.TokenKindSemicolon)
+		return
 	}
 
-	state.subtreeStart++
 	p.addNode(tokIdx, state)
 }
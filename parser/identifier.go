@@ -7,7 +7,7 @@ func (p *Parser) parseIdentifier() {
 
 	curr := p.curr()
 	hasError := !curr.IsIdentifier()
-	p.addLeafNode(hasError)
+	p.addExpectedLeaf(hasError, lexer.TokenKindIdentifier)
 
 	if !hasError {
 		p.next()
@@ -19,32 +19,36 @@ func (p *Parser) parseIdentifier() {
 }
 
 func (p *Parser) parseFullIdentifierRoot() {
-	p.popState()
+	root := p.popState()
 
 	curr := p.curr()
 	hasError := !curr.IsIdentifier()
-	p.addLeafNode(hasError)
+	p.addExpectedLeaf(hasError, lexer.TokenKindIdentifier)
 
 	if !hasError {
 		curr = p.next()
 	} else {
 		p.expectedCurr(lexer.TokenKindIdentifier)
+		curr = p.nextAfterErrorLeaf()
 	}
 
 	if state := p.topState(); state.st == stateFullIdentifierRest {
 		// we are coming back from a dot
 		p.popState()
-		top := p.topState()
-		top.subtreeStart++
-		p.addNode(state.tokIdx, top)
+		p.addNode(state.tokIdx, stateStackEntry{
+			subtreeStart: state.subtreeStart + 1,
+		})
 
 		if state.hasError {
 			return
 		}
+		root = state
 	}
 
 	if curr == lexer.TokenKindDot {
 		p.pushState(stateFullIdentifierRest)
+		// the identifier starts where the first root started
+		p.stack[len(p.stack)-1].subtreeStart = root.subtreeStart
 	}
 }
 
@@ -17,7 +17,7 @@ func (p *Parser) parseImportValue() {
 	}
 
 	hasError := curr != lexer.TokenKindStr
-	p.addLeafNode(hasError)
+	p.addExpectedLeaf(hasError, lexer.TokenKindStr)
 
 	if !hasError {
 		p.next()
@@ -35,9 +35,15 @@ func (p *Parser) parseImportFinish() {
 
 	if !state.hasError {
 		p.next()
+	} else if p.isMissing() {
+		p.expectedCurr(lexer.TokenKindSemicolon)
+		p.addMissingNode(lexer.TokenKindSemicolon, state)
+		return
 	} else {
 		p.expectedCurr(lexer.TokenKindSemicolon)
 		tokIdx = p.skipPastLikelyEnd(tokIdx)
+		p.addRecoveredNode(tokIdx, state, lexer.TokenKindSemicolon)
+		return
 	}
 
 	p.addNode(tokIdx, state)
@@ -25,7 +25,7 @@ func (p *Parser) parseMessageMapKeyValue() {
 	state := p.popState()
 	curr := p.curr()
 	hasError := curr != lexer.TokenKindLeftAngle
-	p.addLeafNode(hasError)
+	p.addExpectedLeaf(hasError, lexer.TokenKindLeftAngle)
 
 	if hasError {
 		p.expectedCurr(lexer.TokenKindLeftAngle)
@@ -35,7 +35,7 @@ func (p *Parser) parseMessageMapKeyValue() {
 	curr = p.next()
 
 	hasError = !slices.Contains(mapKeyTypes, curr)
-	p.addLeafNode(hasError)
+	p.addExpectedLeaf(hasError, lexer.TokenKindTypeString)
 
 	if !hasError {
 		curr = p.next()
@@ -49,7 +49,7 @@ func (p *Parser) parseMessageMapKeyValue() {
 	commaIdx := p.currTok
 
 	if hasError {
-		p.addLeafNode(hasError)
+		p.addExpectedLeaf(hasError, lexer.TokenKindComma)
 		p.expectedCurr(lexer.TokenKindComma)
 		p.skipTo(lexer.TokenKindComma, lexer.TokenKindRightAngle)
 		curr = p.curr()
@@ -59,7 +59,7 @@ func (p *Parser) parseMessageMapKeyValue() {
 	curr = p.next()
 
 	hasError = !curr.IsIdentifier()
-	p.addLeafNode(hasError)
+	p.addExpectedLeaf(hasError, lexer.TokenKindIdentifier)
 
 	if !hasError {
 		curr = p.next()
@@ -80,7 +80,7 @@ end_generic:
 		p.addNode(p.currTok, state)
 		p.next()
 	} else {
-		p.addLeafNode(hasError)
+		p.addExpectedLeaf(hasError, lexer.TokenKindRightAngle)
 		p.expectedCurr(lexer.TokenKindRightAngle)
 		p.skipPastLikelyEnd(p.currTok)
 	}
@@ -12,7 +12,7 @@ func (p *Parser) parseMessageBlock() {
 	p.popState()
 
 	hasError := p.curr() != lexer.TokenKindLeftBrace
-	p.addLeafNode(hasError)
+	p.addExpectedLeaf(hasError, lexer.TokenKindLeftBrace)
 
 	if !hasError {
 		p.next()
@@ -25,9 +25,11 @@ func (p *Parser) parseMessageBlock() {
 }
 
 var messageScopeExpected = []lexer.TokenKind{
-	lexer.TokenKindTypeFloat,
-	lexer.TokenKindOption,
+	lexer.TokenKindOptional,
+	lexer.TokenKindRepeated,
+	lexer.TokenKindRequired,
 	lexer.TokenKindTypeDouble,
+	lexer.TokenKindTypeFloat,
 	lexer.TokenKindTypeInt32,
 	lexer.TokenKindTypeInt64,
 	lexer.TokenKindTypeUint32,
@@ -41,12 +43,15 @@ var messageScopeExpected = []lexer.TokenKind{
 	lexer.TokenKindTypeBool,
 	lexer.TokenKindTypeString,
 	lexer.TokenKindTypeBytes,
-	lexer.TokenKindMap,
 	lexer.TokenKindIdentifier,
+	lexer.TokenKindMap,
+	lexer.TokenKindOption,
 	lexer.TokenKindReserved,
 	lexer.TokenKindExtensions,
-	lexer.TokenKindOneOf,
 	lexer.TokenKindMessage,
+	lexer.TokenKindEnum,
+	lexer.TokenKindOneOf,
+	lexer.TokenKindExtend,
 	lexer.TokenKindRightBrace,
 }
 
@@ -86,63 +91,91 @@ func (p *Parser) parseMessageValue() {
 		p.addLeafNode(false)
 		p.next()
 		p.parseEnum()
+	case lexer.TokenKindExtend:
+		p.addLeafNode(false)
+		p.next()
+		p.parseExtend()
 	default:
-		hasDot := false
-		hasModifier := false
-		var modifierIdx uint32
-		if curr == lexer.TokenKindOptional || curr == lexer.TokenKindRepeated || curr == lexer.TokenKindRequired {
-			hasModifier = true
-			modifierIdx = p.currTok
-			curr = p.next()
-		}
+		p.parseLabeledField(curr, messageScopeExpected)
+	}
+}
+
+// parseLabeledField parses a field with an optional label, the
+// kinds in expected are reported when there is no field.
+func (p *Parser) parseLabeledField(curr lexer.TokenKind, expected []lexer.TokenKind) {
+	hasDot := false
+	hasModifier := false
+	var modifierIdx, dotIdx uint32
+	if curr == lexer.TokenKindOptional || curr == lexer.TokenKindRepeated || curr == lexer.TokenKindRequired {
+		hasModifier = true
+		modifierIdx = p.currTok
+		curr = p.next()
+	}
 
-		if curr == lexer.TokenKindDot {
-			hasDot = true
-			curr = p.next()
+	if curr == lexer.TokenKindDot {
+		hasDot = true
+		dotIdx = p.currTok
+		curr = p.next()
+	}
+
+	if curr.IsIdentifier() {
+		p.pushState(stateMessageFieldFinish)
+		if hasModifier {
+			// the modifier is added before pushing the
+			// type states so that it is not part of the type
+			p.addNode(modifierIdx, stateStackEntry{
+				tokIdx:       modifierIdx,
+				subtreeStart: uint32(len(p.tree)),
+			})
+		}
+		p.pushState(stateMessageFieldAssign)
+		p.pushState(stateFullIdentifierRoot)
+		if hasDot {
+			p.addNode(dotIdx, stateStackEntry{
+				tokIdx:       dotIdx,
+				subtreeStart: uint32(len(p.tree)),
+			})
 		}
+		return
+	}
+
+	if hasModifier {
+		// we try to create a coherent parse tree
+		// even though we know there is an error
 
-		if curr.IsIdentifier() {
-			p.pushState(stateMessageFieldFinish)
-			p.pushState(stateMessageFieldAssign)
-			p.pushState(stateFullIdentifierRoot)
-			if hasModifier {
-				p.addNode(modifierIdx, stateStackEntry{
-					tokIdx:       modifierIdx,
-					subtreeStart: uint32(len(p.tree)),
-				})
-			}
-			if hasDot {
-				p.addLeafNode(false)
-			}
-			break
-		} else if hasModifier {
-			// we try to create a coherent parse tree
-			// even though we know there is an error
-
-			// add all the tokens between modifierIdx
-			// and currTok
-			for i := modifierIdx; i <= p.currTok; i++ {
-				p.addNode(i, stateStackEntry{
-					tokIdx:       i,
-					subtreeStart: uint32(len(p.tree)),
-				})
-			}
-			nbElements := p.currTok - modifierIdx + 1
-			p.expectedCurr(messageScopeExpected...)
-			p.skipPastLikelyEnd(p.currTok)
-
-			// after skip, we can now add the token
-			// we skipped to
-			p.addNode(p.currTok, stateStackEntry{
-				tokIdx:       p.currTok,
-				subtreeStart: uint32(len(p.tree)) - nbElements,
-				hasError:     true,
+		// add all the tokens between modifierIdx
+		// and currTok, the latter only if it is
+		// not left for later
+		end := p.currTok + 1
+		if isLeftForLater(curr) {
+			end--
+		}
+		for i := modifierIdx; i < end; i++ {
+			p.addNode(i, stateStackEntry{
+				tokIdx:       i,
+				subtreeStart: uint32(len(p.tree)),
 			})
-			break
 		}
-		p.expectedCurr(messageScopeExpected...)
-		p.skipPastLikelyEnd(p.currTok)
+		nbElements := end - modifierIdx
+		p.expectedCurr(expected...)
+		tokIdx := p.skipPastLikelyEnd(p.currTok)
+
+		// after skip, we can now add the token
+		// we skipped to
+		state := stateStackEntry{
+			tokIdx:       tokIdx,
+			subtreeStart: uint32(len(p.tree)) - nbElements,
+			hasError:     true,
+		}
+		if tokIdx < end { // nothing more was skipped
+			p.addMissingNode(lexer.TokenKindSemicolon, state)
+		} else {
+			p.addRecoveredNode(tokIdx, state, lexer.TokenKindSemicolon)
+		}
+		return
 	}
+	p.expectedCurr(expected...)
+	p.skipStatement()
 }
 
 func (p *Parser) parseMessageFinish() {
@@ -153,9 +186,15 @@ func (p *Parser) parseMessageFinish() {
 
 	if !state.hasError {
 		p.next()
+	} else if p.isMissing() {
+		p.expectedCurr(lexer.TokenKindRightBrace)
+		p.addMissingNode(lexer.TokenKindRightBrace, state)
+		return
 	} else {
 		p.expectedCurr(lexer.TokenKindRightBrace)
 		tokIdx = p.skipPastLikelyEnd(tokIdx)
+		p.addRecoveredNode(tokIdx, state, lexer.TokenKindRightBrace)
+		return
 	}
 
 	p.addNode(tokIdx, state)
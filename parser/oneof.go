@@ -12,7 +12,7 @@ func (p *Parser) parseOneofBlock() {
 	p.popState()
 
 	hasError := p.curr() != lexer.TokenKindLeftBrace
-	p.addLeafNode(hasError)
+	p.addExpectedLeaf(hasError, lexer.TokenKindLeftBrace)
 
 	if !hasError {
 		p.next()
@@ -42,8 +42,10 @@ func (p *Parser) parseOneofValue() {
 		p.parseOption()
 	default:
 		hasDot := false
+		var dotIdx uint32
 		if curr == lexer.TokenKindDot {
 			hasDot = true
+			dotIdx = p.currTok
 			curr = p.next()
 		}
 
@@ -52,13 +54,16 @@ func (p *Parser) parseOneofValue() {
 			p.pushState(stateMessageFieldAssign)
 			p.pushState(stateFullIdentifierRoot)
 			if hasDot {
-				p.addLeafNode(false)
+				p.addNode(dotIdx, stateStackEntry{
+					tokIdx:       dotIdx,
+					subtreeStart: uint32(len(p.tree)),
+				})
 			}
 			break
 		}
 
 		p.expectedCurr(messageScopeExpected...)
-		p.skipPastLikelyEnd(p.currTok)
+		p.skipStatement()
 	}
 }
 
@@ -70,9 +75,15 @@ func (p *Parser) parseOneofFinish() {
 
 	if !state.hasError {
 		p.next()
+	} else if p.isMissing() {
+		p.expectedCurr(lexer.TokenKindRightBrace)
+		p.addMissingNode(lexer.TokenKindRightBrace, state)
+		return
 	} else {
 		p.expectedCurr(lexer.TokenKindRightBrace)
 		tokIdx = p.skipPastLikelyEnd(tokIdx)
+		p.addRecoveredNode(tokIdx, state, lexer.TokenKindRightBrace)
+		return
 	}
 
 	p.addNode(tokIdx, state)
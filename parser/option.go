@@ -19,7 +19,7 @@ func (p *Parser) parseOptionName() {
 	hasError := curr != lexer.TokenKindIdentifier &&
 		curr != lexer.TokenKindLeftParen &&
 		!curr.IsIdentifier()
-	p.addLeafNode(hasError)
+	p.addExpectedLeaf(hasError, lexer.TokenKindIdentifier)
 
 	switch curr {
 	case lexer.TokenKindIdentifier:
@@ -40,6 +40,7 @@ func (p *Parser) parseOptionName() {
 		}
 
 		p.expectedCurr(lexer.TokenKindIdentifier, lexer.TokenKindLeftParen)
+		p.nextAfterErrorLeaf()
 	}
 
 	if optionNameRest := p.topState(); optionNameRest.st == stateOptionNameRest {
@@ -75,7 +76,8 @@ func (p *Parser) parseOptionNameParenFinish() {
 	} else {
 		p.expectedCurr(lexer.TokenKindRightParen)
 		tokIdx = p.skipPastLikelyEnd(tokIdx)
-		state.subtreeStart--
+		p.addRecoveredNode(tokIdx, state, lexer.TokenKindRightParen)
+		return
 	}
 
 	p.addNode(tokIdx, state)
@@ -103,7 +105,7 @@ func (p *Parser) parseOptionAssign() {
 	hasError := curr != lexer.TokenKindEqual
 
 	if hasError {
-		p.addLeafNode(hasError)
+		p.addExpectedLeaf(hasError, lexer.TokenKindEqual)
 		p.expectedCurr(lexer.TokenKindEqual)
 		p.skipPastLikelyEnd(p.currTok)
 		return
@@ -124,7 +126,7 @@ func (p *Parser) parseOptionAssign() {
 			return
 		}
 
-		p.addLeafNode(true)
+		p.addExpectedLeaf(true, lexer.TokenKindIdentifier)
 		p.expectedCurr(constantTypes...)
 		p.skipPastLikelyEnd(p.currTok)
 	}
@@ -138,9 +140,15 @@ func (p *Parser) parseOptionFinish() {
 
 	if !state.hasError {
 		p.next()
+	} else if p.isMissing() {
+		p.expectedCurr(lexer.TokenKindSemicolon)
+		p.addMissingNode(lexer.TokenKindSemicolon, state)
+		return
 	} else {
 		p.expectedCurr(lexer.TokenKindSemicolon)
 		tokIdx = p.skipPastLikelyEnd(tokIdx)
+		p.addRecoveredNode(tokIdx, state, lexer.TokenKindSemicolon)
+		return
 	}
 
 	p.addNode(tokIdx, state)
@@ -15,9 +15,15 @@ func (p *Parser) parsePackageFinish() {
 
 	if !state.hasError {
 		p.next()
+	} else if p.isMissing() {
+		p.expectedCurr(lexer.TokenKindSemicolon)
+		p.addMissingNode(lexer.TokenKindSemicolon, state)
+		return
 	} else {
 		p.expectedCurr(lexer.TokenKindSemicolon)
 		tokIdx = p.skipPastLikelyEnd(tokIdx)
+		p.addRecoveredNode(tokIdx, state, lexer.TokenKindSemicolon)
+		return
 	}
 
 	p.addNode(tokIdx, state)
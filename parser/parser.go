@@ -1,11 +1,15 @@
 package parser
 
 import (
+	"slices"
+
 	"github.com/Clement-Jean/protein/lexer"
+	"github.com/Clement-Jean/protein/source"
 )
 
 type Parser struct {
 	toks    *lexer.TokenizedBuffer
+	file    *source.File
 	tree    ParseTree
 	stack   []stateStackEntry
 	errs    []error
@@ -13,13 +17,23 @@ type Parser struct {
 }
 
 func New(toks *lexer.TokenizedBuffer) *Parser {
-	if len(toks.TokenInfos) <= 0 {
-		panic("we need at least one token to be able to create a parser")
-	}
+	return NewWithTree(toks, nil)
+}
+
+// NewWithTree creates a Parser which reuses the memory of tree.
+// tree must not be used for anything else after.
+func NewWithTree(toks *lexer.TokenizedBuffer, tree ParseTree) *Parser {
+	return NewForFile(nil, toks, tree)
+}
 
+// NewForFile creates a Parser reporting the error positions in file.
+// Without a file, the positions are the offsets plus one, as if the
+// file was the only one in a source.FileSet.
+func NewForFile(file *source.File, toks *lexer.TokenizedBuffer, tree ParseTree) *Parser {
 	return &Parser{
 		toks: toks,
-		tree: make([]Node, 0, len(toks.TokenInfos)),
+		file: file,
+		tree: slices.Grow(tree[:0], len(toks.TokenInfos)),
 	}
 }
 
@@ -97,10 +111,19 @@ func (p *Parser) expectedCurr(kind ...lexer.TokenKind) {
 	p.error(&ExpectedError{
 		Expected: kind,
 		Got:      p.curr(),
-		TokIdx:   p.currTok,
+		Pos:      p.pos(p.currTok),
 	})
 }
 
+func (p *Parser) pos(tokIdx uint32) source.Pos {
+	tokIdx = min(tokIdx, uint32(len(p.toks.TokenInfos))-1)
+	offset := p.toks.TokenInfos[tokIdx].Offset
+	if p.file == nil {
+		return source.Pos(offset + 1)
+	}
+	return p.file.Pos(offset)
+}
+
 func (p *Parser) parseEnderState() {
 	state := p.popState()
 	top := p.topState()
@@ -108,6 +131,18 @@ func (p *Parser) parseEnderState() {
 	p.addNode(state.tokIdx, top)
 }
 
+var topLevelExpected = []lexer.TokenKind{
+	lexer.TokenKindSyntax,
+	lexer.TokenKindEdition,
+	lexer.TokenKindImport,
+	lexer.TokenKindPackage,
+	lexer.TokenKindOption,
+	lexer.TokenKindMessage,
+	lexer.TokenKindEnum,
+	lexer.TokenKindService,
+	lexer.TokenKindExtend,
+}
+
 func (p *Parser) parseTopLevel() {
 	curr := p.curr()
 
@@ -117,11 +152,23 @@ func (p *Parser) parseTopLevel() {
 		return
 	}
 
+	isEmpty := curr == lexer.TokenKindComment || curr == lexer.TokenKindSemicolon
+	if !isEmpty && !slices.Contains(topLevelExpected, curr) {
+		p.addLeafNode(true)
+		p.expectedCurr(topLevelExpected...)
+		p.next()
+		p.skipPastLikelyEnd(p.currTok)
+		if curr := p.curr(); curr == lexer.TokenKindSemicolon || curr == lexer.TokenKindRightBrace {
+			p.next()
+		}
+		return
+	}
+
 	p.addLeafNode(false)
 	p.next()
 
 	switch curr {
-	case lexer.TokenKindComment:
+	case lexer.TokenKindComment, lexer.TokenKindSemicolon:
 		return
 	case lexer.TokenKindSyntax:
 		p.parseSyntax()
@@ -139,10 +186,19 @@ func (p *Parser) parseTopLevel() {
 		p.parseEnum()
 	case lexer.TokenKindService:
 		p.parseService()
+	case lexer.TokenKindExtend:
+		p.parseExtend()
 	}
 }
 
+// Parse returns the tree of the tokens. It fails with ErrNoTokens
+// when the buffer is empty, the lexer always adds BOF and EOF.
 func (p *Parser) Parse() (ParseTree, []error) {
+	if len(p.toks.TokenInfos) == 0 {
+		p.error(ErrNoTokens)
+		return p.tree, p.errs
+	}
+
 	p.pushState(stateTopLevel)
 	p.addLeafNode(false)
 	p.next()
@@ -242,6 +298,14 @@ func (p *Parser) Parse() (ParseTree, []error) {
 		case stateOneofFinish:
 			p.parseOneofFinish()
 
+		// EXTENDS
+		case stateExtendBlock:
+			p.parseExtendBlock()
+		case stateExtendValue:
+			p.parseExtendValue()
+		case stateExtendFinish:
+			p.parseExtendFinish()
+
 		// ENUMS
 		case stateEnumBlock:
 			p.parseEnumBlock()
@@ -283,5 +347,12 @@ func (p *Parser) Parse() (ParseTree, []error) {
 			p.parseEnderState()
 		}
 	}
+
+	p.tree.propagateErrors()
+	if debug {
+		if err := p.tree.Verify(p.toks); err != nil {
+			panic(err)
+		}
+	}
 	return p.tree, p.errs
 }
@@ -9,11 +9,13 @@ import (
 	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/Clement-Jean/protein/lexer"
 	"github.com/Clement-Jean/protein/parser"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 )
 
 var headersRegexp = *regexp.MustCompile(
@@ -42,7 +44,7 @@ type ParseTestCase struct {
 	expectedErrs []error
 }
 
-func parseTestContent(t *testing.T, filename string) (tests []ParseTestCase) {
+func parseTestContent(t testing.TB, filename string) (tests []ParseTestCase) {
 	testPrefix := strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename))
 	filePath := filepath.Join(basepath, "testdata", filename)
 	b, err := os.ReadFile(filePath)
@@ -108,6 +110,9 @@ func runParseTestCase(t *testing.T, tests []ParseTestCase) {
 
 			p := parser.New(tb)
 			pt, errs := p.Parse()
+			if err := pt.Verify(tb); err != nil {
+				t.Error(err)
+			}
 
 			buf := new(bytes.Buffer)
 			pt.Print(buf, nil, tb)
@@ -137,6 +142,7 @@ var testFiles = []string{
 	"oneof.txt",
 	"reserved.txt",
 	"extensions.txt",
+	"extend.txt",
 	"enum.txt",
 	"service.txt",
 	"rpc.txt",
@@ -149,3 +155,61 @@ func TestParser(t *testing.T) {
 		runParseTestCase(t, subTests)
 	}
 }
+
+func FuzzParser(f *testing.F) {
+	for _, file := range corpusFiles(f) {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(string(b))
+	}
+	for _, file := range testFiles {
+		for _, test := range parseTestContent(f, file) {
+			f.Add(test.input)
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		l, err := lexer.NewFromReader(strings.NewReader(s))
+		if err != nil {
+			t.Skip(err)
+		}
+		toks, _ := l.Lex()
+
+		done := make(chan struct{})
+		var (
+			tree parser.ParseTree
+			errs []error
+		)
+		go func() {
+			defer close(done)
+			tree, errs = parser.New(toks).Parse()
+		}()
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("the parser did not terminate")
+		}
+
+		if err := tree.Verify(toks); err != nil {
+			t.Fatal(err)
+		}
+		for _, err := range errs {
+			if err.Error() == "" {
+				t.Fatal("empty error message")
+			}
+		}
+	})
+}
+
+func TestParseEmpty(t *testing.T) {
+	tree, errs := parser.New(&lexer.TokenizedBuffer{}).Parse()
+
+	if len(tree) != 0 {
+		t.Errorf("expected an empty tree, got %v", tree)
+	}
+	if diff := cmp.Diff([]error{parser.ErrNoTokens}, errs, cmpopts.EquateErrors()); diff != "" {
+		t.Errorf("errors mismatch (-want +got):\n%s", diff)
+	}
+}
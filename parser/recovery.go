@@ -72,3 +72,115 @@ func (p *Parser) skipPastLikelyEnd(idx uint32) uint32 {
 
 	return p.currTok - 1
 }
+
+// skipStatement skips the unexpected statement starting at the
+// current token. Unlike skipPastLikelyEnd, it always moves forward so
+// that a scope cannot loop on a token it does not handle.
+func (p *Parser) skipStatement() {
+	start := p.currTok
+	p.skipPastLikelyEnd(start)
+	if p.currTok == start {
+		p.next()
+	}
+}
+
+// isMissing reports whether the expected token is likely missing
+// rather than replaced by the current one. This is the case when the
+// next token, the comments excluded, closes a scope, ends the file or
+// is on a new line. Adding a missing node instead of skipping keeps
+// the next statements intact.
+func (p *Parser) isMissing() bool {
+	next := p.currTok
+	for next < uint32(len(p.toks.TokenInfos)) && p.toks.TokenInfos[next].Kind == lexer.TokenKindComment {
+		next++
+	}
+	if next >= uint32(len(p.toks.TokenInfos)) {
+		return true
+	}
+
+	switch p.toks.TokenInfos[next].Kind {
+	case lexer.TokenKindEOF, lexer.TokenKindRightBrace:
+		return true
+	}
+	if p.currTok == 0 {
+		return false
+	}
+
+	prev := p.toks.TokenInfos[p.currTok-1]
+	curr := p.toks.TokenInfos[next]
+	return p.toks.FindLineIndex(prev.Offset) != p.toks.FindLineIndex(curr.Offset)
+}
+
+func (p *Parser) addMissingLeaf(kind lexer.TokenKind) {
+	p.tree = append(p.tree, Node{
+		TokIdx:      p.currTok,
+		SubtreeSize: 1,
+		HasError:    true,
+		Missing:     kind,
+	})
+}
+
+func (p *Parser) addMissingNode(kind lexer.TokenKind, state stateStackEntry) {
+	p.tree = append(p.tree, Node{
+		TokIdx:      p.currTok,
+		SubtreeSize: uint32(len(p.tree)) - state.subtreeStart + 1,
+		HasError:    true,
+		Missing:     kind,
+	})
+}
+
+// addExpectedLeaf adds the leaf of the current token, expected to be
+// of kind expected when hasError. When the token is left for later,
+// the leaf is the missing expected token.
+func (p *Parser) addExpectedLeaf(hasError bool, expected lexer.TokenKind) {
+	if !hasError {
+		p.addLeafNode(false)
+		return
+	}
+
+	if isLeftForLater(p.curr()) {
+		p.addMissingLeaf(expected)
+	} else {
+		p.addLeafNode(true)
+	}
+}
+
+// isLeftForLater reports whether an unexpected token of kind is left
+// to the nodes consuming it rather than taken by an error node. These
+// are the tokens ending a statement or a scope, or starting its next
+// part.
+func isLeftForLater(kind lexer.TokenKind) bool {
+	switch kind {
+	case lexer.TokenKindEOF, lexer.TokenKindSemicolon, lexer.TokenKindComma,
+		lexer.TokenKindEqual, lexer.TokenKindLeftBrace,
+		lexer.TokenKindRightBrace, lexer.TokenKindRightSquare,
+		lexer.TokenKindRightParen, lexer.TokenKindRightAngle:
+		return true
+	}
+	return false
+}
+
+// nextAfterErrorLeaf moves past the token of the last leaf when it is
+// not a missing token, no other node should take it.
+func (p *Parser) nextAfterErrorLeaf() lexer.TokenKind {
+	if p.tree[len(p.tree)-1].IsMissing() {
+		return p.curr()
+	}
+	return p.next()
+}
+
+// addRecoveredNode adds the node of tokIdx, returned by
+// skipPastLikelyEnd. When nothing could be skipped, the current token
+// is taken if it is the expected one, otherwise it is left to the node
+// consuming it and the node is the missing expected token.
+func (p *Parser) addRecoveredNode(tokIdx uint32, state stateStackEntry, expected lexer.TokenKind) {
+	switch {
+	case tokIdx != p.currTok || p.currTok >= uint32(len(p.toks.TokenInfos)):
+		p.addNode(tokIdx, state)
+	case p.curr() == expected:
+		p.addNode(tokIdx, state)
+		p.next()
+	default:
+		p.addMissingNode(expected, state)
+	}
+}
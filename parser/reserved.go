@@ -13,11 +13,11 @@ func (p *Parser) parseReserved() {
 	default:
 		p.expectedCurr(lexer.TokenKindInt, lexer.TokenKindStr)
 		tokIdx := p.skipPastLikelyEnd(p.currTok)
-		p.addNode(tokIdx, stateStackEntry{
+		p.addRecoveredNode(tokIdx, stateStackEntry{
 			tokIdx:       tokIdx,
 			subtreeStart: uint32(len(p.tree)) - 1,
 			hasError:     true,
-		})
+		}, lexer.TokenKindSemicolon)
 	}
 }
 
@@ -26,7 +26,7 @@ func (p *Parser) parseReservedName() {
 
 	curr := p.curr()
 	hasError := curr != lexer.TokenKindStr
-	p.addLeafNode(hasError)
+	p.addExpectedLeaf(hasError, lexer.TokenKindStr)
 
 	if !hasError {
 		curr = p.next()
@@ -48,7 +48,7 @@ func (p *Parser) parseReservedRange() {
 
 	curr := p.curr()
 	hasError := curr != lexer.TokenKindInt && curr != lexer.TokenKindMax
-	p.addLeafNode(hasError)
+	p.addExpectedLeaf(hasError, lexer.TokenKindInt)
 
 	if !hasError {
 		curr = p.next()
@@ -81,9 +81,15 @@ func (p *Parser) parseReservedFinish() {
 
 	if !state.hasError {
 		p.next()
+	} else if p.isMissing() {
+		p.expectedCurr(lexer.TokenKindSemicolon)
+		p.addMissingNode(lexer.TokenKindSemicolon, state)
+		return
 	} else {
 		p.expectedCurr(lexer.TokenKindSemicolon)
 		tokIdx = p.skipPastLikelyEnd(tokIdx)
+		p.addRecoveredNode(tokIdx, state, lexer.TokenKindSemicolon)
+		return
 	}
 
 	p.addNode(tokIdx, state)
@@ -1,10 +1,6 @@
 package parser
 
-import (
-	"math"
-
-	"github.com/Clement-Jean/protein/lexer"
-)
+import "github.com/Clement-Jean/protein/lexer"
 
 func (p *Parser) parseRPCDefinition() {
 	p.popState()
@@ -22,23 +18,24 @@ func (p *Parser) parseRPCReqRes() {
 		p.popState()
 		p.expectedCurr(lexer.TokenKindLeftParen)
 		tokIdx := p.skipPastLikelyEnd(p.currTok)
-		p.addNode(tokIdx, stateStackEntry{
+		p.addRecoveredNode(tokIdx, stateStackEntry{
 			tokIdx:       tokIdx,
 			subtreeStart: state.subtreeStart + 1,
 			hasError:     true,
-		})
+		}, lexer.TokenKindLeftParen)
 		return
 	}
 	p.addLeafNode(hasError)
 	curr = p.next()
 
 	p.pushState(stateRPCReqResFinish)
-	p.pushState(stateFullIdentifierRoot)
 
 	if curr == lexer.TokenKindStream {
 		p.addLeafNode(hasError)
 		p.next()
 	}
+
+	p.pushState(stateFullIdentifierRoot)
 }
 
 func (p *Parser) parseRPCReqResFinish() {
@@ -50,11 +47,11 @@ func (p *Parser) parseRPCReqResFinish() {
 		p.popState()
 		p.expectedCurr(lexer.TokenKindRightParen)
 		tokIdx := p.skipPastLikelyEnd(p.currTok)
-		p.addNode(tokIdx, stateStackEntry{
+		p.addRecoveredNode(tokIdx, stateStackEntry{
 			tokIdx:       tokIdx,
 			subtreeStart: state.subtreeStart + 1,
 			hasError:     true,
-		})
+		}, lexer.TokenKindRightParen)
 		return
 	}
 	p.addNode(p.currTok, state)
@@ -72,11 +69,11 @@ func (p *Parser) parseRPCReqResFinish() {
 		p.popState()
 		p.expectedCurr(lexer.TokenKindReturns)
 		tokIdx := p.skipPastLikelyEnd(p.currTok)
-		p.addNode(tokIdx, stateStackEntry{
+		p.addRecoveredNode(tokIdx, stateStackEntry{
 			tokIdx:       tokIdx,
-			subtreeStart: state.subtreeStart + 1,
+			subtreeStart: state.subtreeStart, // the ) is already in the tree
 			hasError:     true,
-		})
+		}, lexer.TokenKindReturns)
 		return
 	}
 	p.next()
@@ -90,14 +87,16 @@ func (p *Parser) parseRPCFinish() {
 
 	if p.topState().st == stateServiceValue { // coming back after rpc option
 		if curr == lexer.TokenKindSemicolon {
-			p.addNode(state.tokIdx, state)
+			p.addNode(p.currTok, state)
 			p.next()
 			return
 		}
 
+		// the ; is optional after a block
 		p.tree = append(p.tree, Node{
-			TokIdx:      math.MaxUint32,
+			TokIdx:      p.currTok,
 			SubtreeSize: uint32(len(p.tree)) - state.subtreeStart + 1,
+			Missing:     lexer.TokenKindSemicolon,
 		})
 		return
 	}
@@ -105,15 +104,20 @@ func (p *Parser) parseRPCFinish() {
 	hasError := curr != lexer.TokenKindSemicolon &&
 		curr != lexer.TokenKindLeftBrace
 
-	if hasError {
+	if hasError && p.isMissing() {
+		p.popState()
+		p.expectedCurr(lexer.TokenKindSemicolon, lexer.TokenKindLeftBrace)
+		p.addMissingNode(lexer.TokenKindSemicolon, state)
+		return
+	} else if hasError {
 		p.popState()
 		p.expectedCurr(lexer.TokenKindSemicolon, lexer.TokenKindLeftBrace)
 		tokIdx := p.skipPastLikelyEnd(p.currTok)
-		p.addNode(tokIdx, stateStackEntry{
+		p.addRecoveredNode(tokIdx, stateStackEntry{
 			tokIdx:       tokIdx,
 			subtreeStart: state.subtreeStart + 1,
 			hasError:     true,
-		})
+		}, lexer.TokenKindSemicolon)
 		return
 	}
 
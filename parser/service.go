@@ -12,7 +12,7 @@ func (p *Parser) parseServiceBlock() {
 	p.popState()
 
 	hasError := p.curr() != lexer.TokenKindLeftBrace
-	p.addLeafNode(hasError)
+	p.addExpectedLeaf(hasError, lexer.TokenKindLeftBrace)
 
 	if !hasError {
 		p.next()
@@ -47,7 +47,7 @@ func (p *Parser) parseServiceValue() {
 		p.pushState(stateRPCDefinition)
 	default:
 		p.expectedCurr(serviceScopeExpected...)
-		p.skipPastLikelyEnd(p.currTok)
+		p.skipStatement()
 	}
 }
 
@@ -59,9 +59,15 @@ func (p *Parser) parseServiceFinish() {
 
 	if !state.hasError {
 		p.next()
+	} else if p.isMissing() {
+		p.expectedCurr(lexer.TokenKindRightBrace)
+		p.addMissingNode(lexer.TokenKindRightBrace, state)
+		return
 	} else {
 		p.expectedCurr(lexer.TokenKindRightBrace)
 		tokIdx = p.skipPastLikelyEnd(tokIdx)
+		p.addRecoveredNode(tokIdx, state, lexer.TokenKindRightBrace)
+		return
 	}
 
 	p.addNode(tokIdx, state)
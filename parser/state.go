@@ -63,6 +63,11 @@ const (
 	stateOneofValue
 	stateOneofFinish
 
+	// EXTENDS
+	stateExtendBlock
+	stateExtendValue
+	stateExtendFinish
+
 	// ENUMS
 	stateEnumBlock
 	stateEnumValue
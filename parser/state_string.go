@@ -48,26 +48,29 @@ func _() {
 	_ = x[stateOneofBlock-37]
 	_ = x[stateOneofValue-38]
 	_ = x[stateOneofFinish-39]
-	_ = x[stateEnumBlock-40]
-	_ = x[stateEnumValue-41]
-	_ = x[stateEnumFinish-42]
-	_ = x[stateServiceBlock-43]
-	_ = x[stateServiceValue-44]
-	_ = x[stateServiceFinish-45]
-	_ = x[stateRPCDefinition-46]
-	_ = x[stateRPCReqRes-47]
-	_ = x[stateRPCReqResFinish-48]
-	_ = x[stateRPCValue-49]
-	_ = x[stateRPCFinish-50]
-	_ = x[stateIdentifier-51]
-	_ = x[stateFullIdentifierRoot-52]
-	_ = x[stateFullIdentifierRest-53]
-	_ = x[stateEnder-54]
+	_ = x[stateExtendBlock-40]
+	_ = x[stateExtendValue-41]
+	_ = x[stateExtendFinish-42]
+	_ = x[stateEnumBlock-43]
+	_ = x[stateEnumValue-44]
+	_ = x[stateEnumFinish-45]
+	_ = x[stateServiceBlock-46]
+	_ = x[stateServiceValue-47]
+	_ = x[stateServiceFinish-48]
+	_ = x[stateRPCDefinition-49]
+	_ = x[stateRPCReqRes-50]
+	_ = x[stateRPCReqResFinish-51]
+	_ = x[stateRPCValue-52]
+	_ = x[stateRPCFinish-53]
+	_ = x[stateIdentifier-54]
+	_ = x[stateFullIdentifierRoot-55]
+	_ = x[stateFullIdentifierRest-56]
+	_ = x[stateEnder-57]
 }
 
-const _state_name = "stateTopLevelstateSyntaxAssignstateSyntaxFinishstateEditionAssignstateEditionFinishstateImportValuestateImportFinishstatePackageFinishstateOptionNamestateOptionNameReststateOptionNameParenFinishstateOptionAssignstateOptionEqualstateOptionFinishstateTextFieldValuestateTextFieldAssignstateTextFieldNamestateTextFieldExtensionNamestateTextFieldExtensionNameFinishstateTextMessageValuestateTextMessageInsertSemicolonstateTextMessageFinishRightBracestateTextMessageFinishRightAnglestateTextListValuestateTextListFinishstateMessageBlockstateMessageFieldAssignstateMessageFieldOptionstateMessageFieldOptionAssignstateMessageFieldOptionFinishstateMessageFieldFinishstateMessageMapKeyValuestateMessageValuestateMessageFinishstateReservedRangestateReservedNamestateReservedFinishstateOneofBlockstateOneofValuestateOneofFinishstateEnumBlockstateEnumValuestateEnumFinishstateServiceBlockstateServiceValuestateServiceFinishstateRPCDefinitionstateRPCReqResstateRPCReqResFinishstateRPCValuestateRPCFinishstateIdentifierstateFullIdentifierRootstateFullIdentifierReststateEnder"
+const _state_name = "stateTopLevelstateSyntaxAssignstateSyntaxFinishstateEditionAssignstateEditionFinishstateImportValuestateImportFinishstatePackageFinishstateOptionNamestateOptionNameReststateOptionNameParenFinishstateOptionAssignstateOptionEqualstateOptionFinishstateTextFieldValuestateTextFieldAssignstateTextFieldNamestateTextFieldExtensionNamestateTextFieldExtensionNameFinishstateTextMessageValuestateTextMessageInsertSemicolonstateTextMessageFinishRightBracestateTextMessageFinishRightAnglestateTextListValuestateTextListFinishstateMessageBlockstateMessageFieldAssignstateMessageFieldOptionstateMessageFieldOptionAssignstateMessageFieldOptionFinishstateMessageFieldFinishstateMessageMapKeyValuestateMessageValuestateMessageFinishstateReservedRangestateReservedNamestateReservedFinishstateOneofBlockstateOneofValuestateOneofFinishstateExtendBlockstateExtendValuestateExtendFinishstateEnumBlockstateEnumValuestateEnumFinishstateServiceBlockstateServiceValuestateServiceFinishstateRPCDefinitionstateRPCReqResstateRPCReqResFinishstateRPCValuestateRPCFinishstateIdentifierstateFullIdentifierRootstateFullIdentifierReststateEnder"
 
-var _state_index = [...]uint16{0, 13, 30, 47, 65, 83, 99, 116, 134, 149, 168, 194, 211, 227, 244, 263, 283, 301, 328, 361, 382, 413, 445, 477, 495, 514, 531, 554, 577, 606, 635, 658, 681, 698, 716, 734, 751, 770, 785, 800, 816, 830, 844, 859, 876, 893, 911, 929, 943, 963, 976, 990, 1005, 1028, 1051, 1061}
+var _state_index = [...]uint16{0, 13, 30, 47, 65, 83, 99, 116, 134, 149, 168, 194, 211, 227, 244, 263, 283, 301, 328, 361, 382, 413, 445, 477, 495, 514, 531, 554, 577, 606, 635, 658, 681, 698, 716, 734, 751, 770, 785, 800, 816, 832, 848, 865, 879, 893, 908, 925, 942, 960, 978, 992, 1012, 1025, 1039, 1054, 1077, 1100, 1110}
 
 func (i state) String() string {
 	if i >= state(len(_state_index)-1) {
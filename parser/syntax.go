@@ -12,17 +12,23 @@ func (p *Parser) parseSyntaxAssign() {
 
 	curr := p.curr()
 	hasError := curr != lexer.TokenKindEqual
-	p.addLeafNode(hasError)
 
-	if hasError {
+	if curr == lexer.TokenKindStr {
 		p.expectedCurr(lexer.TokenKindEqual)
-		p.skipPastLikelyEnd(p.currTok)
-		return
+		p.addMissingLeaf(lexer.TokenKindEqual)
+	} else {
+		p.addExpectedLeaf(hasError, lexer.TokenKindEqual)
+
+		if hasError {
+			p.expectedCurr(lexer.TokenKindEqual)
+			p.skipPastLikelyEnd(p.currTok)
+			return
+		}
+		curr = p.next()
 	}
-	curr = p.next()
 
 	hasError = curr != lexer.TokenKindStr
-	p.addLeafNode(hasError)
+	p.addExpectedLeaf(hasError, lexer.TokenKindStr)
 
 	if !hasError {
 		p.next()
@@ -40,9 +46,15 @@ func (p *Parser) parseSyntaxFinish() {
 
 	if !state.hasError {
 		p.next()
+	} else if p.isMissing() {
+		p.expectedCurr(lexer.TokenKindSemicolon)
+		p.addMissingNode(lexer.TokenKindSemicolon, state)
+		return
 	} else {
 		p.expectedCurr(lexer.TokenKindSemicolon)
 		tokIdx = p.skipPastLikelyEnd(tokIdx)
+		p.addRecoveredNode(tokIdx, state, lexer.TokenKindSemicolon)
+		return
 	}
 
 	p.addNode(tokIdx, state)
@@ -18,7 +18,7 @@ func (p *Parser) parseTextFieldName() {
 	hasError := curr != lexer.TokenKindIdentifier &&
 		curr != lexer.TokenKindLeftSquare &&
 		!curr.IsIdentifier()
-	p.addLeafNode(hasError)
+	p.addExpectedLeaf(hasError, lexer.TokenKindIdentifier)
 
 	switch curr {
 	case lexer.TokenKindIdentifier:
@@ -63,6 +63,12 @@ func (p *Parser) parseTextFieldExtensionNameFinish() {
 		p.expectedCurr(lexer.TokenKindRightSquare)
 		tokIdx = p.currTok
 		p.skipTo(lexer.TokenKindComma, lexer.TokenKindSemicolon, lexer.TokenKindRightBrace)
+
+		if tokIdx == p.currTok {
+			// the token is left for the text message
+			p.addMissingNode(lexer.TokenKindRightSquare, state)
+			return
+		}
 	}
 
 	p.addNode(tokIdx, state)
@@ -74,7 +80,7 @@ func (p *Parser) parseTextFieldAssign() {
 	curr := p.curr()
 	if curr != lexer.TokenKindColon {
 		if curr != lexer.TokenKindLeftBrace && curr != lexer.TokenKindLeftAngle {
-			p.addLeafNode(true)
+			p.addExpectedLeaf(true, lexer.TokenKindColon)
 			p.expectedCurr(lexer.TokenKindLeftBrace, lexer.TokenKindLeftAngle)
 			p.skipTo(lexer.TokenKindComma, lexer.TokenKindSemicolon, lexer.TokenKindRightBrace)
 			return
@@ -115,7 +121,7 @@ func (p *Parser) parseTextFieldValue() {
 		p.next()
 		return
 	} else {
-		p.addLeafNode(true)
+		p.addExpectedLeaf(true, lexer.TokenKindIdentifier)
 		p.expectedCurr(append(constantTypes, lexer.TokenKindLeftSquare)...)
 		p.skipTo(lexer.TokenKindComma, lexer.TokenKindSemicolon, lexer.TokenKindRightBrace)
 	}
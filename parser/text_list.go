@@ -24,10 +24,13 @@ func (p *Parser) parseTextListValue() {
 		p.next()
 	default:
 		if curr != lexer.TokenKindComma {
+			if p.topState().st == stateEnder {
+				p.popState() // the comma has no value
+			}
 			state := p.popState()
 			p.expectedCurr(lexer.TokenKindRightSquare)
-			p.skipPastLikelyEnd(p.currTok)
-			p.addNode(p.currTok, state)
+			tokIdx := p.skipPastLikelyEnd(p.currTok)
+			p.addRecoveredNode(tokIdx, state, lexer.TokenKindRightSquare)
 			return
 		}
 	}
@@ -44,6 +47,8 @@ func (p *Parser) parseTextListFinish() {
 	} else {
 		p.expectedCurr(lexer.TokenKindRightSquare)
 		tokIdx = p.skipPastLikelyEnd(tokIdx)
+		p.addRecoveredNode(tokIdx, state, lexer.TokenKindRightSquare)
+		return
 	}
 
 	p.addNode(tokIdx, state)
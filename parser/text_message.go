@@ -1,10 +1,6 @@
 package parser
 
-import (
-	"math"
-
-	"github.com/Clement-Jean/protein/lexer"
-)
+import "github.com/Clement-Jean/protein/lexer"
 
 func (p *Parser) parseTextMessage() {
 	switch p.curr() {
@@ -37,8 +33,9 @@ func (p *Parser) parseTextMessageInsertSemicolon() {
 	top := p.topState()
 	top.subtreeStart++
 	p.tree = append(p.tree, Node{
-		TokIdx:      math.MaxUint32,
+		TokIdx:      p.currTok,
 		SubtreeSize: uint32(len(p.tree)) - top.subtreeStart + 1,
+		Missing:     lexer.TokenKindSemicolon,
 	})
 }
 
@@ -0,0 +1,227 @@
+package parser
+
+import (
+	"iter"
+	"slices"
+
+	"github.com/Clement-Jean/protein/lexer"
+)
+
+// WalkAction tells Walk how to continue after entering a node.
+type WalkAction uint8
+
+const (
+	// WalkContinue visits the children of the node.
+	WalkContinue WalkAction = iota
+	// WalkSkip does not visit the children of the node.
+	WalkSkip
+	// WalkStop ends the walk.
+	WalkStop
+)
+
+func (pt ParseTree) size(idx int) int {
+	return max(int(pt[idx].SubtreeSize), 1)
+}
+
+// start returns the index of the first node in the subtree of idx.
+func (pt ParseTree) start(idx int) int {
+	return idx - pt.size(idx) + 1
+}
+
+// between returns the roots of the subtrees in (start, end], from
+// the last one to the first one.
+func (pt ParseTree) between(start, end int) []int {
+	var idxs []int
+	for i := end; i > start; i -= pt.size(i) {
+		idxs = append(idxs, i)
+	}
+	return idxs
+}
+
+func (pt ParseTree) inOrder(start, end int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		idxs := pt.between(start, end)
+		for _, idx := range slices.Backward(idxs) {
+			if !yield(idx) {
+				return
+			}
+		}
+	}
+}
+
+// Roots returns the roots of the tree in the order of the source.
+func (pt ParseTree) Roots() iter.Seq[int] {
+	return pt.inOrder(-1, len(pt)-1)
+}
+
+// Children returns the children of the node idx in the order of the
+// source.
+func (pt ParseTree) Children(idx int) iter.Seq[int] {
+	return pt.inOrder(pt.start(idx)-1, idx-1)
+}
+
+// Postorder returns the nodes with the children before their parent.
+// This is the order in which the nodes are stored.
+func (pt ParseTree) Postorder() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i := range pt {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+// Preorder returns the nodes with the parents before their children.
+func (pt ParseTree) Preorder() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		pt.Walk(func(idx int) WalkAction {
+			if !yield(idx) {
+				return WalkStop
+			}
+			return WalkContinue
+		}, nil)
+	}
+}
+
+// Walk visits the tree in preorder. enter is called before the
+// children of a node are visited and its result controls the rest of
+// the walk. exit, if not nil, is called after the children, even when
+// they are skipped, unless the walk is stopped.
+func (pt ParseTree) Walk(enter func(idx int) WalkAction, exit func(idx int)) {
+	// the entries are the nodes to enter, or to exit when negative
+	stack := pt.between(-1, len(pt)-1)
+	for len(stack) != 0 {
+		idx := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if idx < 0 {
+			exit(-idx - 1)
+			continue
+		}
+
+		action := enter(idx)
+		if action == WalkStop {
+			return
+		}
+		if exit != nil {
+			stack = append(stack, -idx-1)
+		}
+		if action == WalkContinue {
+			stack = append(stack, pt.between(pt.start(idx)-1, idx-1)...)
+		}
+	}
+}
+
+// Navigator finds the parent and the siblings of nodes. The table of
+// the parents is built on the first lookup.
+type Navigator struct {
+	tree    ParseTree
+	parents []int32
+	spans   []span
+}
+
+// Navigator returns a Navigator for pt.
+func (pt ParseTree) Navigator() *Navigator {
+	return &Navigator{tree: pt}
+}
+
+// Parent returns the parent of the node idx, or false for a root.
+func (n *Navigator) Parent(idx int) (int, bool) {
+	if n.parents == nil {
+		n.parents = make([]int32, len(n.tree))
+		for i := range n.tree {
+			n.parents[i] = -1
+		}
+		for i := range n.tree {
+			for _, child := range n.tree.between(n.tree.start(i)-1, i-1) {
+				n.parents[child] = int32(i)
+			}
+		}
+	}
+
+	parent := n.parents[idx]
+	return int(parent), parent != -1
+}
+
+// bounds returns the range (start, end] containing idx and its
+// siblings.
+func (n *Navigator) bounds(idx int) (start, end int) {
+	parent, ok := n.Parent(idx)
+	if !ok {
+		return -1, len(n.tree) - 1
+	}
+	return n.tree.start(parent) - 1, parent - 1
+}
+
+// NextSibling returns the sibling following the node idx in the
+// source, or false if idx is the last one.
+func (n *Navigator) NextSibling(idx int) (int, bool) {
+	_, end := n.bounds(idx)
+	next := -1
+	for i := end; i > idx; i -= n.tree.size(i) {
+		next = i
+	}
+	return next, next != -1
+}
+
+// PrevSibling returns the sibling preceding the node idx in the
+// source, or false if idx is the first one.
+func (n *Navigator) PrevSibling(idx int) (int, bool) {
+	start, _ := n.bounds(idx)
+	prev := n.tree.start(idx) - 1
+	return prev, prev > start
+}
+
+// NodeAt returns the innermost node whose tokens cover offset.
+// The extent of a node goes from the start of its first token to the
+// end of its last one, the missing tokens excluded. The table of the
+// extents is built on the first call, so toks must not change between
+// calls.
+func (n *Navigator) NodeAt(offset uint32, toks *lexer.TokenizedBuffer) (int, bool) {
+	if n.spans == nil {
+		n.spans = n.tree.spans(toks)
+	}
+
+	found := -1
+	candidates := n.tree.between(-1, len(n.tree)-1)
+	for len(candidates) != 0 {
+		var next []int
+		for _, idx := range candidates {
+			if sp := n.spans[idx]; sp.start <= offset && offset < sp.end {
+				found = idx
+				next = n.tree.between(n.tree.start(idx)-1, idx-1)
+				break
+			}
+		}
+		candidates = next
+	}
+	return found, found != -1
+}
+
+type span struct{ start, end uint32 }
+
+// spans returns the extents of the nodes, empty for the subtrees
+// made of missing tokens only.
+func (pt ParseTree) spans(toks *lexer.TokenizedBuffer) []span {
+	spans := make([]span, len(pt))
+	for i, node := range pt {
+		sp := span{}
+		if !node.IsMissing() && node.TokIdx < uint32(len(toks.TokenInfos)) {
+			sp.start, sp.end = toks.TokenSpan(node.TokIdx)
+		}
+		for _, child := range pt.between(pt.start(i)-1, i-1) {
+			c := spans[child]
+			switch {
+			case c.start == c.end:
+			case sp.start == sp.end:
+				sp = c
+			default:
+				sp.start = min(sp.start, c.start)
+				sp.end = max(sp.end, c.end)
+			}
+		}
+		spans[i] = sp
+	}
+	return spans
+}
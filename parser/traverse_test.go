@@ -0,0 +1,196 @@
+package parser_test
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/Clement-Jean/protein/lexer"
+	"github.com/Clement-Jean/protein/parser"
+	"github.com/Clement-Jean/protein/source"
+)
+
+// parseTraverse parses the following tree:
+//
+//	0  BOF
+//	4  ;         (1 syntax, 2 =, 3 String)
+//	13 }         (5 message, 6 A, 7 {, 12 ;)
+//	12 ;         (8 int32, 11 =)
+//	11 =         (9 b, 10 1)
+//	14 EOF
+func parseTraverse(t *testing.T) (*source.Buffer, *lexer.TokenizedBuffer, parser.ParseTree) {
+	t.Helper()
+
+	src, err := source.NewFromReader(strings.NewReader("syntax = \"proto3\";\nmessage A {\n  int32 b = 1;\n}\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, err := lexer.NewFromSource(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	toks, _ := l.Lex()
+	tree, errs := parser.New(toks).Parse()
+	if len(errs) != 0 {
+		t.Fatal(errs)
+	}
+	return src, toks, tree
+}
+
+func TestTraverseIterators(t *testing.T) {
+	_, _, tree := parseTraverse(t)
+
+	tests := []struct {
+		name     string
+		got      []int
+		expected []int
+	}{
+		{"roots", slices.Collect(tree.Roots()), []int{0, 4, 13, 14}},
+		{"children of message", slices.Collect(tree.Children(13)), []int{5, 6, 7, 12}},
+		{"children of field", slices.Collect(tree.Children(12)), []int{8, 11}},
+		{"children of leaf", slices.Collect(tree.Children(5)), nil},
+		{"preorder", slices.Collect(tree.Preorder()), []int{0, 4, 1, 2, 3, 13, 5, 6, 7, 12, 8, 11, 9, 10, 14}},
+		{"postorder", slices.Collect(tree.Postorder()), []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if diff := cmp.Diff(test.expected, test.got); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWalk(t *testing.T) {
+	_, _, tree := parseTraverse(t)
+
+	tests := []struct {
+		name     string
+		actions  map[int]parser.WalkAction
+		expected []string
+	}{
+		{
+			name: "skip",
+			actions: map[int]parser.WalkAction{
+				4:  parser.WalkSkip,
+				12: parser.WalkSkip,
+			},
+			expected: []string{
+				"enter 0", "exit 0",
+				"enter 4", "exit 4",
+				"enter 13",
+				"enter 5", "exit 5",
+				"enter 6", "exit 6",
+				"enter 7", "exit 7",
+				"enter 12", "exit 12",
+				"exit 13",
+				"enter 14", "exit 14",
+			},
+		},
+		{
+			name:    "stop",
+			actions: map[int]parser.WalkAction{2: parser.WalkStop},
+			expected: []string{
+				"enter 0", "exit 0",
+				"enter 4",
+				"enter 1", "exit 1",
+				"enter 2",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var got []string
+			tree.Walk(func(idx int) parser.WalkAction {
+				got = append(got, fmt.Sprintf("enter %d", idx))
+				return test.actions[idx]
+			}, func(idx int) {
+				got = append(got, fmt.Sprintf("exit %d", idx))
+			})
+
+			if diff := cmp.Diff(test.expected, got); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNavigator(t *testing.T) {
+	_, _, tree := parseTraverse(t)
+	nav := tree.Navigator()
+
+	// -1 when there is no such node
+	orNone := func(idx int, ok bool) int {
+		if !ok {
+			return -1
+		}
+		return idx
+	}
+
+	tests := []struct {
+		idx      int
+		expected [3]int // parent, next and previous sibling
+	}{
+		{idx: 0, expected: [3]int{-1, 4, -1}},
+		{idx: 14, expected: [3]int{-1, -1, 13}},
+		{idx: 1, expected: [3]int{4, 2, -1}},
+		{idx: 7, expected: [3]int{13, 12, 6}},
+		{idx: 12, expected: [3]int{13, -1, 7}},
+		{idx: 11, expected: [3]int{12, -1, 8}},
+		{idx: 9, expected: [3]int{11, 10, -1}},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprint(test.idx), func(t *testing.T) {
+			got := [3]int{
+				orNone(nav.Parent(test.idx)),
+				orNone(nav.NextSibling(test.idx)),
+				orNone(nav.PrevSibling(test.idx)),
+			}
+
+			if diff := cmp.Diff(test.expected, got); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNodeAt(t *testing.T) {
+	_, toks, tree := parseTraverse(t)
+	nav := tree.Navigator()
+
+	tests := []struct {
+		name     string
+		offset   uint32
+		expected int // -1 when no node covers offset
+	}{
+		{"keyword", 0, 1},
+		{"inside keyword", 3, 1},
+		{"between tokens of a statement", 6, 4},
+		{"string", 12, 3},
+		{"between statements", 18, -1},
+		{"identifier", 27, 6},
+		{"inside a block", 30, 13},
+		{"parent token between children", 41, 11},
+		{"integer", 43, 10},
+		{"closing brace", 46, 13},
+		{"end of file", 47, -1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, ok := nav.NodeAt(test.offset, toks)
+			if !ok {
+				got = -1
+			}
+			if got != test.expected {
+				t.Errorf("expected %d, got %d", test.expected, got)
+			}
+		})
+	}
+}
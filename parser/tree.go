@@ -3,8 +3,6 @@ package parser
 import (
 	"fmt"
 	"io"
-	"iter"
-	"math"
 	"strings"
 
 	"github.com/Clement-Jean/protein/lexer"
@@ -14,42 +12,31 @@ import (
 type Node struct {
 	TokIdx      uint32
 	SubtreeSize uint32
-	HasError    bool
+	HasError    bool // the node or one of its descendants is an error
+
+	// Missing is the kind of the token assumed by the parser when it
+	// is not in the source (e.g. a forgotten ;). TokIdx is then the
+	// token in front of which the missing one would be. Missing nodes
+	// without error are tokens implied by the syntax (e.g. the end
+	// of an rpc with options).
+	Missing lexer.TokenKind
 }
 
-type ParseTree []Node
-
-func (pt *ParseTree) roots() iter.Seq[int] {
-	return func(yield func(int) bool) {
-		i := len(*pt) - 1
-		for i >= 0 {
-			if !yield(i) {
-				return
-			}
-			i -= int(math.Abs(float64((*pt)[i].SubtreeSize)))
-		}
-	}
+// IsMissing reports whether the token of n is not in the source.
+func (n Node) IsMissing() bool {
+	return n.Missing != lexer.TokenKindEOF
 }
 
-func (pt *ParseTree) children(rootIdx int) iter.Seq[int] {
-	return func(yield func(int) bool) {
-		end := rootIdx - int((*pt)[rootIdx].SubtreeSize)
-		i := rootIdx - 1
-		for i > end {
-			if !yield(i) {
-				return
-			}
-			i -= int(math.Abs(float64((*pt)[i].SubtreeSize)))
-		}
-	}
-}
+type ParseTree []Node
 
-func (pt *ParseTree) postorder() iter.Seq[int] {
-	return func(yield func(int) bool) {
-		for i := 0; i < len(*pt); i++ {
-			if !yield(i) {
-				return
-			}
+// propagateErrors sets HasError on the ancestors of the nodes with an
+// error. The children come before their parent so the flags only need
+// to go up one level.
+func (pt ParseTree) propagateErrors() {
+	for i := range pt {
+		start := i - int(pt[i].SubtreeSize) + 1
+		for child := i - 1; child >= start && !pt[i].HasError; child -= int(pt[child].SubtreeSize) {
+			pt[i].HasError = pt[child].HasError
 		}
 	}
 }
@@ -62,15 +49,13 @@ func (pt *ParseTree) printNode(out io.Writer, idx, depth int, toks *lexer.Tokeni
 
 	comment := ""
 
-	if node.TokIdx > uint32(len(toks.TokenInfos)) {
-		fmt.Fprint(out, "kind: <INSERT>")
+	if node.IsMissing() {
+		fmt.Fprintf(out, "kind: %s, missing: true", node.Missing)
 	} else {
 		kind := toks.TokenInfos[node.TokIdx].Kind
 
 		if s != nil && (kind == lexer.TokenKindIdentifier || kind == lexer.TokenKindStr) {
-			start := toks.TokenInfos[node.TokIdx].Offset
-			end := toks.TokenInfos[node.TokIdx+1].Offset
-			comment = fmt.Sprintf(" // %s", strings.TrimSpace(string(s.Range(start, end))))
+			comment = fmt.Sprintf(" // %s", toks.TokenText(s, node.TokIdx))
 		}
 
 		fmt.Fprintf(out, "kind: %s", kind)
@@ -88,6 +73,23 @@ func (pt *ParseTree) printNode(out io.Writer, idx, depth int, toks *lexer.Tokeni
 	return false
 }
 
+// InsertOffset returns the offset at which the missing token of the
+// node idx should be inserted: right after the previous token, the
+// comments excluded, or in front of the next token when there is
+// no previous one.
+func (pt ParseTree) InsertOffset(idx int, s *source.Buffer, toks *lexer.TokenizedBuffer) uint32 {
+	next := min(pt[idx].TokIdx, uint32(len(toks.TokenInfos))-1)
+	for tokIdx := next; tokIdx > 0; tokIdx-- {
+		prev := toks.TokenInfos[tokIdx-1]
+		if prev.Kind == lexer.TokenKindComment || prev.Kind == lexer.TokenKindBOF {
+			continue
+		}
+
+		return prev.End()
+	}
+	return toks.TokenInfos[next].Offset
+}
+
 func (pt *ParseTree) Print(out io.Writer, s *source.Buffer, toks *lexer.TokenizedBuffer) {
 	fmt.Fprintf(out, "parseTree = [\n")
 
@@ -96,7 +98,7 @@ func (pt *ParseTree) Print(out io.Writer, s *source.Buffer, toks *lexer.Tokenize
 		depth  int
 	}
 
-	for node := range pt.roots() {
+	for node := range pt.Roots() {
 		stack = append(stack, struct {
 			tokIdx int
 			depth  int
@@ -109,7 +111,7 @@ func (pt *ParseTree) Print(out io.Writer, s *source.Buffer, toks *lexer.Tokenize
 		idx, depth := top.tokIdx, top.depth
 		stack = stack[:len(stack)-1]
 
-		for child := range pt.children(idx) {
+		for child := range pt.Children(idx) {
 			indents[child] = depth + 1
 			stack = append(stack, struct {
 				tokIdx int
@@ -118,7 +120,7 @@ func (pt *ParseTree) Print(out io.Writer, s *source.Buffer, toks *lexer.Tokenize
 		}
 	}
 
-	for node := range pt.postorder() {
+	for node := range pt.Postorder() {
 		pt.printNode(out, node, indents[node], toks, s)
 	}
 
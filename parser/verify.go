@@ -0,0 +1,74 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/Clement-Jean/protein/lexer"
+)
+
+// Verify checks the structure of the tree built from toks:
+//   - the subtrees nest in their parents and the roots cover the tree,
+//   - the tokens are in toks and each of them is in at most one node,
+//   - the children of a node are in the order of their tokens,
+//   - the missing tokens not implied by the syntax are errors,
+//   - the nodes with an error in their subtree have an error.
+//
+// It returns an error describing the first violation found. With the
+// protein_debug build tag, Parse panics when its tree is not valid.
+func (pt ParseTree) Verify(toks *lexer.TokenizedBuffer) error {
+	nbToks := uint32(len(toks.TokenInfos))
+	seen := make([]bool, nbToks)
+	// spans are the first and last positions of the tokens in the
+	// subtrees. A token at idx is at 2*idx, a missing one in front
+	// of it is at 2*idx-1.
+	spans := make([][2]int64, len(pt))
+
+	for i, node := range pt {
+		// a subtree cannot start before the tree so the roots,
+		// from the last node, cover the whole tree
+		if node.SubtreeSize == 0 || node.SubtreeSize > uint32(i+1) {
+			return fmt.Errorf("node %d: invalid subtree size %d", i, node.SubtreeSize)
+		}
+
+		pos := 2 * int64(node.TokIdx)
+		if node.IsMissing() {
+			if node.TokIdx >= nbToks {
+				return fmt.Errorf("node %d: missing token before %d out of the %d tokens", i, node.TokIdx, nbToks)
+			}
+			if !node.HasError && node.Missing != lexer.TokenKindSemicolon {
+				return fmt.Errorf("node %d: missing %s without error", i, node.Missing)
+			}
+			pos--
+		} else {
+			if node.TokIdx >= nbToks {
+				return fmt.Errorf("node %d: token %d out of the %d tokens", i, node.TokIdx, nbToks)
+			}
+			if seen[node.TokIdx] {
+				return fmt.Errorf("node %d: token %d is already in the tree", i, node.TokIdx)
+			}
+			seen[node.TokIdx] = true
+		}
+
+		span := [2]int64{pos, pos}
+		start := i - int(node.SubtreeSize) + 1
+		next := int64(-1) // first position of the next child
+		for child := i - 1; child >= start; child -= int(pt[child].SubtreeSize) {
+			if child-int(pt[child].SubtreeSize)+1 < start {
+				return fmt.Errorf("node %d: child %d overflows the subtree", i, child)
+			}
+			if pt[child].HasError && !node.HasError {
+				return fmt.Errorf("node %d: child %d has an error but not its parent", i, child)
+			}
+
+			childSpan := spans[child]
+			if next != -1 && childSpan[1] > next {
+				return fmt.Errorf("node %d: child %d is not in the order of the tokens", i, child)
+			}
+			next = childSpan[0]
+			span[0] = min(span[0], childSpan[0])
+			span[1] = max(span[1], childSpan[1])
+		}
+		spans[i] = span
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package parser_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Clement-Jean/protein/lexer"
+	"github.com/Clement-Jean/protein/parser"
+)
+
+func TestVerify(t *testing.T) {
+	l, err := lexer.NewFromReader(strings.NewReader(`syntax = "proto3";`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	toks, _ := l.Lex()
+	// BOF, syntax, =, String, ;, EOF
+	valid, errs := parser.New(toks).Parse()
+	if len(errs) != 0 {
+		t.Fatal(errs)
+	}
+
+	tests := []struct {
+		name     string
+		corrupt  func(tree parser.ParseTree)
+		expected string // empty when valid
+	}{
+		{
+			name:    "valid",
+			corrupt: func(parser.ParseTree) {},
+		},
+		{
+			name:     "empty subtree",
+			corrupt:  func(tree parser.ParseTree) { tree[2].SubtreeSize = 0 },
+			expected: "node 2: invalid subtree size 0",
+		},
+		{
+			name:     "subtree before the tree",
+			corrupt:  func(tree parser.ParseTree) { tree[4].SubtreeSize = 6 },
+			expected: "node 4: invalid subtree size 6",
+		},
+		{
+			name:     "child overflow",
+			corrupt:  func(tree parser.ParseTree) { tree[3].SubtreeSize = 4 },
+			expected: "node 4: child 3 overflows the subtree",
+		},
+		{
+			name:     "token out of range",
+			corrupt:  func(tree parser.ParseTree) { tree[5].TokIdx = 6 },
+			expected: "node 5: token 6 out of the 6 tokens",
+		},
+		{
+			name:     "token twice",
+			corrupt:  func(tree parser.ParseTree) { tree[3].TokIdx = 2 },
+			expected: "node 3: token 2 is already in the tree",
+		},
+		{
+			name: "unordered children",
+			corrupt: func(tree parser.ParseTree) {
+				tree[2].TokIdx, tree[3].TokIdx = tree[3].TokIdx, tree[2].TokIdx
+			},
+			expected: "node 4: child 2 is not in the order of the tokens",
+		},
+		{
+			name:     "missing without error",
+			corrupt:  func(tree parser.ParseTree) { tree[3].Missing = lexer.TokenKindStr },
+			expected: "node 3: missing String without error",
+		},
+		{
+			name:     "error not propagated",
+			corrupt:  func(tree parser.ParseTree) { tree[2].HasError = true },
+			expected: "node 4: child 2 has an error but not its parent",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tree := slices.Clone(valid)
+			test.corrupt(tree)
+
+			err := tree.Verify(toks)
+			if test.expected == "" {
+				if err != nil {
+					t.Fatalf("expected a valid tree, got %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != test.expected {
+				t.Fatalf("expected %q, got %v", test.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,709 @@
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Clement-Jean/protein/ast"
+	"github.com/Clement-Jean/protein/features"
+	"github.com/Clement-Jean/protein/lexer"
+	"github.com/Clement-Jean/protein/loader"
+	"github.com/Clement-Jean/protein/options"
+	"github.com/Clement-Jean/protein/plugin/pluginpb"
+	"github.com/Clement-Jean/protein/symbols"
+	"github.com/Clement-Jean/protein/wellknown"
+)
+
+const descriptorPath = "google/protobuf/descriptor.proto"
+
+// maxNumber is the exclusive end of the message ranges ending with max.
+const maxNumber = 1 << 29
+
+// Request returns the CodeGeneratorRequest asking to generate files.
+// The descriptors of files and of their dependencies are in ProtoFile,
+// the dependencies first, and those of files are also in
+// SourceFileDescriptors. The parameter is omitted when empty.
+//
+// The options are decoded into the options messages of pluginpb, the
+// custom options are kept encoded in their unknown fields.
+func Request(parameter string, files ...*loader.File) (*pluginpb.CodeGeneratorRequest, error) {
+	var (
+		all  []*loader.File
+		seen = make(map[*loader.File]bool)
+		errs []error
+	)
+	var visit func(f *loader.File)
+	visit = func(f *loader.File) {
+		if seen[f] {
+			return
+		}
+		seen[f] = true
+		for _, imp := range f.Imports {
+			visit(imp)
+		}
+		all = append(all, f)
+	}
+	for _, f := range files {
+		visit(f)
+	}
+
+	asts := make([]*ast.File, 0, len(all))
+	for _, f := range all {
+		if len(f.Errs) != 0 || f.AST == nil {
+			errs = append(errs, fmt.Errorf("%s: %w", f.Path, errors.Join(f.Errs...)))
+			continue
+		}
+		asts = append(asts, f.AST)
+	}
+	if len(errs) != 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	table, err := newTable(asts)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &pluginpb.CodeGeneratorRequest{}
+	if parameter != "" {
+		req.Parameter = &parameter
+	}
+
+	descs := make(map[*loader.File]*pluginpb.FileDescriptorProto)
+	for _, f := range all {
+		desc, err := build(f, table)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		descs[f] = desc
+		req.ProtoFile = append(req.ProtoFile, desc)
+	}
+	if len(errs) != 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	for _, f := range files {
+		req.FileToGenerate = append(req.FileToGenerate, f.Path)
+		req.SourceFileDescriptors = append(req.SourceFileDescriptors, descs[f])
+	}
+	return req, nil
+}
+
+// newTable indexes files. The standard options are encoded with the
+// types of descriptor.proto, it is added when files do not import it.
+func newTable(files []*ast.File) (*symbols.Table, error) {
+	table, errs := symbols.New(files...)
+	if len(errs) != 0 {
+		return nil, errors.Join(errs...)
+	}
+	if _, ok := table.Lookup("google.protobuf.FileOptions"); ok {
+		return table, nil
+	}
+
+	l := loader.New(wellknown.FS)
+	defer l.Close()
+
+	desc, err := l.Load(descriptorPath)
+	if err != nil {
+		return nil, err
+	}
+	table, errs = symbols.New(append(slices.Clip(files), desc.AST)...)
+	if len(errs) != 0 {
+		return nil, errors.Join(errs...)
+	}
+	return table, nil
+}
+
+type builder struct {
+	path  string
+	file  *ast.File
+	table *symbols.Table
+	res   *features.Resolution
+	opts  map[*ast.Option]*options.Option
+	errs  []error
+}
+
+// build returns the descriptor of f, the types are fully-qualified.
+func build(f *loader.File, table *symbols.Table) (*pluginpb.FileDescriptorProto, error) {
+	b := &builder{
+		path:  f.Path,
+		file:  f.AST,
+		table: table,
+		opts:  make(map[*ast.Option]*options.Option),
+	}
+
+	var errs []error
+	b.res, errs = features.Resolve(f.AST)
+	for _, err := range errs {
+		b.errorf("%w", err)
+	}
+	opts, errs := options.Interpret(f.AST, table)
+	for _, err := range errs {
+		b.errorf("%w", err)
+	}
+	for _, opt := range opts {
+		b.opts[opt.Option] = opt
+	}
+	if len(b.errs) != 0 {
+		return nil, errors.Join(b.errs...)
+	}
+
+	desc := b.fileDescriptor()
+	if len(b.errs) != 0 {
+		return nil, errors.Join(b.errs...)
+	}
+	return desc, nil
+}
+
+func (b *builder) errorf(format string, args ...any) {
+	b.errs = append(b.errs, fmt.Errorf("%s: "+format, append([]any{b.path}, args...)...))
+}
+
+func ptr[T any](v T) *T { return &v }
+
+func join(scope, name string) string {
+	if scope == "" {
+		return name
+	}
+	return scope + "." + name
+}
+
+func (b *builder) fileDescriptor() *pluginpb.FileDescriptorProto {
+	file := b.file
+	desc := &pluginpb.FileDescriptorProto{Name: ptr(b.path)}
+
+	pkg := ""
+	if file.Package != nil {
+		pkg = file.Package.Name.String()
+		desc.Package = ptr(pkg)
+	}
+
+	for i, imp := range file.Imports {
+		path, err := imp.Path.String()
+		if err != nil {
+			b.errorf("invalid import path %s: %w", imp.Path.Text, err)
+			continue
+		}
+		desc.Dependency = append(desc.Dependency, path)
+		if imp.Public {
+			desc.PublicDependency = append(desc.PublicDependency, int32(i))
+		}
+		if imp.Weak {
+			desc.WeakDependency = append(desc.WeakDependency, int32(i))
+		}
+	}
+
+	// like protoc, the syntax is omitted for proto2
+	switch edition := b.res.Edition; {
+	case edition == features.EditionProto3:
+		desc.Syntax = ptr("proto3")
+	case edition.IsEditions():
+		desc.Syntax = ptr("editions")
+		desc.Edition = ptr(pluginpb.Edition_EDITION_2023)
+		if edition == features.Edition2024 {
+			desc.Edition = ptr(pluginpb.Edition(1001)) // EDITION_2024
+		}
+	}
+
+	for _, msg := range file.Messages {
+		desc.MessageType = append(desc.MessageType, b.message(pkg, msg))
+	}
+	for _, enum := range file.Enums {
+		desc.EnumType = append(desc.EnumType, b.enum(enum))
+	}
+	for _, service := range file.Services {
+		desc.Service = append(desc.Service, b.service(pkg, service))
+	}
+	for _, extend := range file.Extends {
+		desc.Extension = append(desc.Extension, b.extend(pkg, extend)...)
+	}
+	desc.Options = decodeOptions[pluginpb.FileOptions](b, file.Options)
+	return desc
+}
+
+func (b *builder) message(scope string, msg *ast.Message) *pluginpb.DescriptorProto {
+	name := join(scope, msg.Name.Text)
+	desc := &pluginpb.DescriptorProto{Name: ptr(msg.Name.Text)}
+
+	for _, oneof := range msg.Oneofs {
+		desc.OneofDecl = append(desc.OneofDecl, &pluginpb.OneofDescriptorProto{
+			Name:    ptr(oneof.Name.Text),
+			Options: decodeOptions[pluginpb.OneofOptions](b, oneof.Options),
+		})
+	}
+
+	// the map entries are nested types declared along with the
+	// messages, in the order of the declarations
+	type nested struct {
+		node int
+		desc *pluginpb.DescriptorProto
+	}
+	var types []nested
+	for _, m := range msg.Messages {
+		types = append(types, nested{m.Node, b.message(name, m)})
+	}
+
+	for _, field := range msg.Fields {
+		fd := b.field(name, field)
+		if fd == nil {
+			continue
+		}
+
+		switch {
+		case field.Map != nil:
+			entry := b.mapEntry(name, field)
+			fd.TypeName = ptr("." + join(name, *entry.Name))
+			types = append(types, nested{field.Node, entry})
+		case field.Oneof != nil:
+			fd.OneofIndex = ptr(int32(slices.Index(msg.Oneofs, field.Oneof)))
+		case field.Label == ast.LabelOptional && b.res.Edition == features.EditionProto3:
+			fd.Proto3Optional = ptr(true)
+			fd.OneofIndex = ptr(int32(len(desc.OneofDecl)))
+			desc.OneofDecl = append(desc.OneofDecl, &pluginpb.OneofDescriptorProto{
+				Name: ptr(syntheticOneof(msg, field)),
+			})
+		}
+		desc.Field = append(desc.Field, fd)
+	}
+
+	slices.SortStableFunc(types, func(a, b nested) int { return a.node - b.node })
+	for _, t := range types {
+		desc.NestedType = append(desc.NestedType, t.desc)
+	}
+	for _, enum := range msg.Enums {
+		desc.EnumType = append(desc.EnumType, b.enum(enum))
+	}
+	for _, extend := range msg.Extends {
+		desc.Extension = append(desc.Extension, b.extend(name, extend)...)
+	}
+
+	for _, ext := range msg.Extensions {
+		for _, r := range ext.Ranges {
+			start, end, ok := b.messageRange(r)
+			if ok {
+				desc.ExtensionRange = append(desc.ExtensionRange, &pluginpb.DescriptorProto_ExtensionRange{
+					Start: ptr(start),
+					End:   ptr(end),
+				})
+			}
+		}
+	}
+	for _, reserved := range msg.Reserved {
+		for _, r := range reserved.Ranges {
+			start, end, ok := b.messageRange(r)
+			if ok {
+				desc.ReservedRange = append(desc.ReservedRange, &pluginpb.DescriptorProto_ReservedRange{
+					Start: ptr(start),
+					End:   ptr(end),
+				})
+			}
+		}
+		desc.ReservedName = append(desc.ReservedName, b.reservedNames(reserved)...)
+	}
+
+	desc.Options = decodeOptions[pluginpb.MessageOptions](b, msg.Options)
+	return desc
+}
+
+// syntheticOneof returns the name of the oneof wrapping a proto3
+// optional field, it is prefixed with X until it is not used.
+func syntheticOneof(msg *ast.Message, field *ast.Field) string {
+	used := make(map[string]bool)
+	for _, f := range msg.Fields {
+		used[f.Name.Text] = true
+	}
+	for _, o := range msg.Oneofs {
+		used[o.Name.Text] = true
+	}
+	for _, m := range msg.Messages {
+		used[m.Name.Text] = true
+	}
+	for _, e := range msg.Enums {
+		used[e.Name.Text] = true
+	}
+
+	name := "_" + field.Name.Text
+	for used[name] {
+		name = "X" + name
+	}
+	return name
+}
+
+// messageRange returns the range r with an exclusive end.
+func (b *builder) messageRange(r ast.Range) (int32, int32, bool) {
+	start, ok := b.number(r.Start)
+	if !ok {
+		return 0, 0, false
+	}
+	if r.End.Kind == lexer.TokenKindMax {
+		return start, maxNumber, true
+	}
+	end, ok := b.number(r.End)
+	return start, end + 1, ok
+}
+
+func (b *builder) number(v ast.Value) (int32, bool) {
+	n, err := v.Int()
+	if err != nil || n < math.MinInt32 || n > math.MaxInt32 {
+		b.errorf("invalid number %s", v.Text)
+		return 0, false
+	}
+	return int32(n), true
+}
+
+func (b *builder) reservedNames(reserved *ast.Reserved) []string {
+	var names []string
+	for _, v := range reserved.Names {
+		name, err := v.String()
+		if err != nil {
+			b.errorf("invalid reserved name %s: %w", v.Text, err)
+			continue
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+var fieldTypes = map[lexer.TokenKind]pluginpb.FieldDescriptorProto_Type{
+	lexer.TokenKindTypeDouble:   pluginpb.FieldDescriptorProto_TYPE_DOUBLE,
+	lexer.TokenKindTypeFloat:    pluginpb.FieldDescriptorProto_TYPE_FLOAT,
+	lexer.TokenKindTypeInt64:    pluginpb.FieldDescriptorProto_TYPE_INT64,
+	lexer.TokenKindTypeUint64:   pluginpb.FieldDescriptorProto_TYPE_UINT64,
+	lexer.TokenKindTypeInt32:    pluginpb.FieldDescriptorProto_TYPE_INT32,
+	lexer.TokenKindTypeFixed64:  pluginpb.FieldDescriptorProto_TYPE_FIXED64,
+	lexer.TokenKindTypeFixed32:  pluginpb.FieldDescriptorProto_TYPE_FIXED32,
+	lexer.TokenKindTypeBool:     pluginpb.FieldDescriptorProto_TYPE_BOOL,
+	lexer.TokenKindTypeString:   pluginpb.FieldDescriptorProto_TYPE_STRING,
+	lexer.TokenKindTypeBytes:    pluginpb.FieldDescriptorProto_TYPE_BYTES,
+	lexer.TokenKindTypeUint32:   pluginpb.FieldDescriptorProto_TYPE_UINT32,
+	lexer.TokenKindTypeSfixed32: pluginpb.FieldDescriptorProto_TYPE_SFIXED32,
+	lexer.TokenKindTypeSfixed64: pluginpb.FieldDescriptorProto_TYPE_SFIXED64,
+	lexer.TokenKindTypeSint32:   pluginpb.FieldDescriptorProto_TYPE_SINT32,
+	lexer.TokenKindTypeSint64:   pluginpb.FieldDescriptorProto_TYPE_SINT64,
+}
+
+// field returns the descriptor of field, declared in scope. The map
+// fields are left without type name.
+func (b *builder) field(scope string, field *ast.Field) *pluginpb.FieldDescriptorProto {
+	number, ok := b.number(field.Number)
+	if !ok {
+		return nil
+	}
+
+	desc := &pluginpb.FieldDescriptorProto{
+		Name:     ptr(field.Name.Text),
+		Number:   ptr(number),
+		JsonName: ptr(jsonName(field.Name.Text)),
+	}
+
+	set := b.res.Field(field)
+	switch {
+	case field.Label == ast.LabelRepeated || field.Map != nil:
+		desc.Label = ptr(pluginpb.FieldDescriptorProto_LABEL_REPEATED)
+	case set.FieldPresence == features.FieldPresenceLegacyRequired:
+		desc.Label = ptr(pluginpb.FieldDescriptorProto_LABEL_REQUIRED)
+	default:
+		desc.Label = ptr(pluginpb.FieldDescriptorProto_LABEL_OPTIONAL)
+	}
+
+	if field.Map != nil {
+		desc.Type = ptr(pluginpb.FieldDescriptorProto_TYPE_MESSAGE)
+	} else if !b.fieldType(desc, scope, field.Type, set) {
+		return nil
+	}
+
+	for _, opt := range field.Options {
+		if len(opt.Name) != 1 || opt.Name[0].Extension {
+			continue
+		}
+		switch opt.Name[0].Name.String() {
+		case "json_name":
+			if s, err := opt.Value.String(); err == nil {
+				desc.JsonName = ptr(s)
+			}
+		case "default":
+			if def, ok := b.defaultValue(*desc.Type, opt.Value); ok {
+				desc.DefaultValue = ptr(def)
+			}
+		}
+	}
+
+	desc.Options = decodeOptions[pluginpb.FieldOptions](b, field.Options)
+	return desc
+}
+
+// fieldType sets the type and the type name of desc. The messages
+// with the delimited encoding are groups.
+func (b *builder) fieldType(desc *pluginpb.FieldDescriptorProto, scope string, typ ast.Type, set features.Set) bool {
+	if typ.IsScalar() {
+		t, ok := fieldTypes[typ.Scalar]
+		if !ok {
+			b.errorf("unknown type %s in %q", typ.Scalar, scope)
+		}
+		desc.Type = ptr(t)
+		return ok
+	}
+
+	sym, ok := b.table.Resolve(scope, typ.Name)
+	if !ok || !sym.Kind.IsType() {
+		b.errorf("unknown type %q in %q", typ.Name.String(), scope)
+		return false
+	}
+
+	desc.TypeName = ptr("." + sym.Name)
+	switch {
+	case sym.Kind == symbols.KindEnum:
+		desc.Type = ptr(pluginpb.FieldDescriptorProto_TYPE_ENUM)
+	case set.MessageEncoding == features.MessageEncodingDelimited:
+		desc.Type = ptr(pluginpb.FieldDescriptorProto_TYPE_GROUP)
+	default:
+		desc.Type = ptr(pluginpb.FieldDescriptorProto_TYPE_MESSAGE)
+	}
+	return true
+}
+
+// mapEntry returns the message generated for the map field, declared
+// in scope, with the key and value fields.
+func (b *builder) mapEntry(scope string, field *ast.Field) *pluginpb.DescriptorProto {
+	entry := &pluginpb.DescriptorProto{
+		Name:    ptr(mapEntryName(field.Name.Text)),
+		Options: &pluginpb.MessageOptions{MapEntry: ptr(true)},
+	}
+
+	for i, f := range []struct {
+		name string
+		typ  ast.Type
+	}{{"key", field.Map.Key}, {"value", field.Map.Value}} {
+		desc := &pluginpb.FieldDescriptorProto{
+			Name:     ptr(f.name),
+			Number:   ptr(int32(i + 1)),
+			Label:    ptr(pluginpb.FieldDescriptorProto_LABEL_OPTIONAL),
+			JsonName: ptr(f.name),
+		}
+		// the entries always use the length-prefixed encoding
+		if b.fieldType(desc, scope, f.typ, features.Set{}) {
+			entry.Field = append(entry.Field, desc)
+		}
+	}
+	return entry
+}
+
+func (b *builder) extend(scope string, extend *ast.Extend) []*pluginpb.FieldDescriptorProto {
+	sym, ok := b.table.Resolve(scope, extend.Extendee)
+	if !ok || sym.Kind != symbols.KindMessage {
+		b.errorf("unknown message %q in %q", extend.Extendee.String(), scope)
+		return nil
+	}
+
+	var descs []*pluginpb.FieldDescriptorProto
+	for _, field := range extend.Fields {
+		if desc := b.field(scope, field); desc != nil {
+			desc.Extendee = ptr("." + sym.Name)
+			descs = append(descs, desc)
+		}
+	}
+	return descs
+}
+
+func (b *builder) enum(enum *ast.Enum) *pluginpb.EnumDescriptorProto {
+	desc := &pluginpb.EnumDescriptorProto{Name: ptr(enum.Name.Text)}
+
+	for _, value := range enum.Values {
+		number, ok := b.number(value.Number)
+		if !ok {
+			continue
+		}
+		desc.Value = append(desc.Value, &pluginpb.EnumValueDescriptorProto{
+			Name:    ptr(value.Name.Text),
+			Number:  ptr(number),
+			Options: decodeOptions[pluginpb.EnumValueOptions](b, value.Options),
+		})
+	}
+
+	// unlike the messages, the ends are inclusive
+	for _, reserved := range enum.Reserved {
+		for _, r := range reserved.Ranges {
+			start, ok := b.number(r.Start)
+			if !ok {
+				continue
+			}
+			end := int32(math.MaxInt32)
+			if r.End.Kind != lexer.TokenKindMax {
+				if end, ok = b.number(r.End); !ok {
+					continue
+				}
+			}
+			desc.ReservedRange = append(desc.ReservedRange, &pluginpb.EnumDescriptorProto_EnumReservedRange{
+				Start: ptr(start),
+				End:   ptr(end),
+			})
+		}
+		desc.ReservedName = append(desc.ReservedName, b.reservedNames(reserved)...)
+	}
+
+	desc.Options = decodeOptions[pluginpb.EnumOptions](b, enum.Options)
+	return desc
+}
+
+func (b *builder) service(scope string, service *ast.Service) *pluginpb.ServiceDescriptorProto {
+	desc := &pluginpb.ServiceDescriptorProto{Name: ptr(service.Name.Text)}
+	name := join(scope, service.Name.Text)
+
+	for _, rpc := range service.RPCs {
+		method := &pluginpb.MethodDescriptorProto{
+			Name:    ptr(rpc.Name.Text),
+			Options: decodeOptions[pluginpb.MethodOptions](b, rpc.Options),
+		}
+		for _, t := range []struct {
+			name ast.FullIdent
+			dst  **string
+		}{{rpc.Input, &method.InputType}, {rpc.Output, &method.OutputType}} {
+			sym, ok := b.table.Resolve(name, t.name)
+			if !ok || sym.Kind != symbols.KindMessage {
+				b.errorf("unknown message %q in %q", t.name.String(), name)
+				continue
+			}
+			*t.dst = ptr("." + sym.Name)
+		}
+		// like protoc, the streaming flags are only set when true
+		if rpc.InputStream {
+			method.ClientStreaming = ptr(true)
+		}
+		if rpc.OutputStream {
+			method.ServerStreaming = ptr(true)
+		}
+		desc.Method = append(desc.Method, method)
+	}
+
+	desc.Options = decodeOptions[pluginpb.ServiceOptions](b, service.Options)
+	return desc
+}
+
+// defaultValue formats the default value v of a field of type typ
+// like protoc: the strings are unescaped, the bytes are C-escaped
+// and the enum values are named.
+func (b *builder) defaultValue(typ pluginpb.FieldDescriptorProto_Type, v ast.Value) (string, bool) {
+	var (
+		s   string
+		err error
+	)
+
+	switch typ {
+	case pluginpb.FieldDescriptorProto_TYPE_STRING:
+		s, err = v.String()
+	case pluginpb.FieldDescriptorProto_TYPE_BYTES:
+		s, err = v.String()
+		s = cEscape(s)
+	case pluginpb.FieldDescriptorProto_TYPE_BOOL:
+		var x bool
+		x, err = v.Bool()
+		s = strconv.FormatBool(x)
+	case pluginpb.FieldDescriptorProto_TYPE_FLOAT, pluginpb.FieldDescriptorProto_TYPE_DOUBLE:
+		var x float64
+		x, err = v.Float()
+		s = formatFloat(x, typ == pluginpb.FieldDescriptorProto_TYPE_FLOAT)
+	case pluginpb.FieldDescriptorProto_TYPE_UINT32, pluginpb.FieldDescriptorProto_TYPE_UINT64,
+		pluginpb.FieldDescriptorProto_TYPE_FIXED32, pluginpb.FieldDescriptorProto_TYPE_FIXED64:
+		var x uint64
+		x, err = v.Uint()
+		s = strconv.FormatUint(x, 10)
+	case pluginpb.FieldDescriptorProto_TYPE_ENUM:
+		s = v.Text
+	default:
+		var x int64
+		x, err = v.Int()
+		s = strconv.FormatInt(x, 10)
+	}
+
+	if err != nil {
+		b.errorf("invalid default value %s: %w", v.Text, err)
+		return "", false
+	}
+	return s, true
+}
+
+func formatFloat(f float64, single bool) string {
+	switch {
+	case math.IsInf(f, 1):
+		return "inf"
+	case math.IsInf(f, -1):
+		return "-inf"
+	case math.IsNaN(f):
+		return "nan"
+	case single:
+		return strconv.FormatFloat(f, 'g', -1, 32)
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// cEscape escapes s like the C string literals, the non printable
+// bytes are written in octal.
+func cEscape(s string) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\t':
+			sb.WriteString(`\t`)
+		case '"', '\'', '\\':
+			sb.WriteByte('\\')
+			sb.WriteByte(c)
+		default:
+			if c < 0x20 || c >= 0x7f {
+				fmt.Fprintf(&sb, `\%03o`, c)
+				continue
+			}
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
+
+// jsonName converts a field name like protoc: the underscores are
+// removed and the lowercase letters following them are capitalized.
+func jsonName(name string) string {
+	var sb strings.Builder
+	upper := false
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if c == '_' {
+			upper = true
+			continue
+		}
+		if upper && c >= 'a' && c <= 'z' {
+			c -= 'a' - 'A'
+		}
+		upper = false
+		sb.WriteByte(c)
+	}
+	return sb.String()
+}
+
+// mapEntryName returns the name of the message generated for a map
+// field, e.g. MyFieldEntry for my_field.
+func mapEntryName(name string) string {
+	var sb strings.Builder
+	upper := true
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if c == '_' {
+			upper = true
+			continue
+		}
+		if upper && c >= 'a' && c <= 'z' {
+			c -= 'a' - 'A'
+		}
+		upper = false
+		sb.WriteByte(c)
+	}
+	sb.WriteString("Entry")
+	return sb.String()
+}
@@ -0,0 +1,330 @@
+package plugin_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/Clement-Jean/protein/loader"
+	"github.com/Clement-Jean/protein/plugin"
+	"github.com/Clement-Jean/protein/plugin/pluginpb"
+	"github.com/Clement-Jean/protein/wire"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+func load(t *testing.T, fsys fstest.MapFS, paths ...string) []*loader.File {
+	t.Helper()
+
+	l := loader.New(fsys)
+	t.Cleanup(func() { l.Close() })
+
+	var files []*loader.File
+	for _, path := range paths {
+		f, err := l.Load(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, f)
+	}
+	return files
+}
+
+const (
+	optional = pluginpb.FieldDescriptorProto_LABEL_OPTIONAL
+	required = pluginpb.FieldDescriptorProto_LABEL_REQUIRED
+	repeated = pluginpb.FieldDescriptorProto_LABEL_REPEATED
+)
+
+func TestRequest(t *testing.T) {
+	fsys := fstest.MapFS{
+		"b.proto": {Data: []byte(`syntax = "proto2";
+package b;
+
+message B {
+  required int32 id = 1 [default = 0x10];
+  optional bytes data = 2 [default = "\001a\n"];
+  optional double ratio = 3 [default = -1.5];
+  optional Kind kind = 4 [default = ON];
+
+  enum Kind {
+    OFF = 0;
+    ON = 1;
+    reserved 2 to 5, 10 to max;
+    reserved "OLD";
+  }
+
+  extensions 100 to 199, 1000 to max;
+  reserved 10, 20 to 30;
+  reserved "old";
+}
+
+extend B {
+  optional string ext_name = 100;
+}
+`)},
+		"a.proto": {Data: []byte(`syntax = "proto3";
+package a;
+
+import public "b.proto";
+
+message A {
+  optional string first_name = 1;
+  map<string, Nested> items = 2;
+  oneof choice {
+    int32 x = 3;
+    string y = 4 [json_name = "why"];
+  }
+  message Nested {}
+  repeated Nested nested = 5;
+}
+
+enum E {
+  E_UNSPECIFIED = 0;
+}
+
+service S {
+  rpc Get (A) returns (stream b.B);
+  rpc Put (stream A.Nested) returns (A);
+}
+`)},
+	}
+
+	files := load(t, fsys, "a.proto")
+	got, err := plugin.Request("p", files...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := &pluginpb.FileDescriptorProto{
+		Name:    ptr("b.proto"),
+		Package: ptr("b"),
+		MessageType: []*pluginpb.DescriptorProto{{
+			Name: ptr("B"),
+			Field: []*pluginpb.FieldDescriptorProto{
+				{Name: ptr("id"), Number: ptr(int32(1)), Label: ptr(required), Type: ptr(pluginpb.FieldDescriptorProto_TYPE_INT32), DefaultValue: ptr("16"), JsonName: ptr("id")},
+				{Name: ptr("data"), Number: ptr(int32(2)), Label: ptr(optional), Type: ptr(pluginpb.FieldDescriptorProto_TYPE_BYTES), DefaultValue: ptr(`\001a\n`), JsonName: ptr("data")},
+				{Name: ptr("ratio"), Number: ptr(int32(3)), Label: ptr(optional), Type: ptr(pluginpb.FieldDescriptorProto_TYPE_DOUBLE), DefaultValue: ptr("-1.5"), JsonName: ptr("ratio")},
+				{Name: ptr("kind"), Number: ptr(int32(4)), Label: ptr(optional), Type: ptr(pluginpb.FieldDescriptorProto_TYPE_ENUM), TypeName: ptr(".b.B.Kind"), DefaultValue: ptr("ON"), JsonName: ptr("kind")},
+			},
+			EnumType: []*pluginpb.EnumDescriptorProto{{
+				Name: ptr("Kind"),
+				Value: []*pluginpb.EnumValueDescriptorProto{
+					{Name: ptr("OFF"), Number: ptr(int32(0))},
+					{Name: ptr("ON"), Number: ptr(int32(1))},
+				},
+				ReservedRange: []*pluginpb.EnumDescriptorProto_EnumReservedRange{
+					{Start: ptr(int32(2)), End: ptr(int32(5))},
+					{Start: ptr(int32(10)), End: ptr(int32(2147483647))},
+				},
+				ReservedName: []string{"OLD"},
+			}},
+			ExtensionRange: []*pluginpb.DescriptorProto_ExtensionRange{
+				{Start: ptr(int32(100)), End: ptr(int32(200))},
+				{Start: ptr(int32(1000)), End: ptr(int32(536870912))},
+			},
+			ReservedRange: []*pluginpb.DescriptorProto_ReservedRange{
+				{Start: ptr(int32(10)), End: ptr(int32(11))},
+				{Start: ptr(int32(20)), End: ptr(int32(31))},
+			},
+			ReservedName: []string{"old"},
+		}},
+		Extension: []*pluginpb.FieldDescriptorProto{
+			{Name: ptr("ext_name"), Number: ptr(int32(100)), Label: ptr(optional), Type: ptr(pluginpb.FieldDescriptorProto_TYPE_STRING), Extendee: ptr(".b.B"), JsonName: ptr("extName")},
+		},
+	}
+
+	a := &pluginpb.FileDescriptorProto{
+		Name:             ptr("a.proto"),
+		Package:          ptr("a"),
+		Dependency:       []string{"b.proto"},
+		PublicDependency: []int32{0},
+		MessageType: []*pluginpb.DescriptorProto{{
+			Name: ptr("A"),
+			Field: []*pluginpb.FieldDescriptorProto{
+				{Name: ptr("first_name"), Number: ptr(int32(1)), Label: ptr(optional), Type: ptr(pluginpb.FieldDescriptorProto_TYPE_STRING), OneofIndex: ptr(int32(1)), JsonName: ptr("firstName"), Proto3Optional: ptr(true)},
+				{Name: ptr("items"), Number: ptr(int32(2)), Label: ptr(repeated), Type: ptr(pluginpb.FieldDescriptorProto_TYPE_MESSAGE), TypeName: ptr(".a.A.ItemsEntry"), JsonName: ptr("items")},
+				{Name: ptr("x"), Number: ptr(int32(3)), Label: ptr(optional), Type: ptr(pluginpb.FieldDescriptorProto_TYPE_INT32), OneofIndex: ptr(int32(0)), JsonName: ptr("x")},
+				{Name: ptr("y"), Number: ptr(int32(4)), Label: ptr(optional), Type: ptr(pluginpb.FieldDescriptorProto_TYPE_STRING), OneofIndex: ptr(int32(0)), JsonName: ptr("why")},
+				{Name: ptr("nested"), Number: ptr(int32(5)), Label: ptr(repeated), Type: ptr(pluginpb.FieldDescriptorProto_TYPE_MESSAGE), TypeName: ptr(".a.A.Nested"), JsonName: ptr("nested")},
+			},
+			NestedType: []*pluginpb.DescriptorProto{
+				{
+					Name: ptr("ItemsEntry"),
+					Field: []*pluginpb.FieldDescriptorProto{
+						{Name: ptr("key"), Number: ptr(int32(1)), Label: ptr(optional), Type: ptr(pluginpb.FieldDescriptorProto_TYPE_STRING), JsonName: ptr("key")},
+						{Name: ptr("value"), Number: ptr(int32(2)), Label: ptr(optional), Type: ptr(pluginpb.FieldDescriptorProto_TYPE_MESSAGE), TypeName: ptr(".a.A.Nested"), JsonName: ptr("value")},
+					},
+					Options: &pluginpb.MessageOptions{MapEntry: ptr(true)},
+				},
+				{Name: ptr("Nested")},
+			},
+			OneofDecl: []*pluginpb.OneofDescriptorProto{{Name: ptr("choice")}, {Name: ptr("_first_name")}},
+		}},
+		EnumType: []*pluginpb.EnumDescriptorProto{{
+			Name:  ptr("E"),
+			Value: []*pluginpb.EnumValueDescriptorProto{{Name: ptr("E_UNSPECIFIED"), Number: ptr(int32(0))}},
+		}},
+		Service: []*pluginpb.ServiceDescriptorProto{{
+			Name: ptr("S"),
+			Method: []*pluginpb.MethodDescriptorProto{
+				{Name: ptr("Get"), InputType: ptr(".a.A"), OutputType: ptr(".b.B"), ServerStreaming: ptr(true)},
+				{Name: ptr("Put"), InputType: ptr(".a.A.Nested"), OutputType: ptr(".a.A"), ClientStreaming: ptr(true)},
+			},
+		}},
+		Syntax: ptr("proto3"),
+	}
+
+	expected := &pluginpb.CodeGeneratorRequest{
+		FileToGenerate:        []string{"a.proto"},
+		Parameter:             ptr("p"),
+		ProtoFile:             []*pluginpb.FileDescriptorProto{b, a},
+		SourceFileDescriptors: []*pluginpb.FileDescriptorProto{a},
+	}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+}
+
+func TestRequestOptions(t *testing.T) {
+	fsys := fstest.MapFS{
+		"o.proto": {Data: []byte(`edition = "2023";
+package o;
+
+import "google/protobuf/descriptor.proto";
+
+option go_package = "example.com/o";
+option optimize_for = CODE_SIZE;
+option features = { enum_type: CLOSED utf8_validation: NONE };
+
+extend google.protobuf.FieldOptions {
+  string tag = 1000;
+}
+
+message M {
+  option deprecated = true;
+
+  int32 id = 1 [features.field_presence = LEGACY_REQUIRED];
+  M child = 2 [features.message_encoding = DELIMITED, (tag) = "kept"];
+  repeated int32 ids = 3 [features.repeated_field_encoding = EXPANDED, deprecated = true];
+}
+
+enum Level {
+  option features.enum_type = OPEN;
+  LOW = 0 [deprecated = true];
+}
+`)},
+	}
+
+	files := load(t, fsys, "o.proto")
+	req, err := plugin.Request("", files...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.Parameter != nil {
+		t.Errorf("expected no parameter, got %q", *req.Parameter)
+	}
+
+	var names []string
+	for _, file := range req.ProtoFile {
+		names = append(names, *file.Name)
+	}
+	if diff := cmp.Diff([]string{"google/protobuf/descriptor.proto", "o.proto"}, names); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+
+	int32Type := pluginpb.FieldDescriptorProto_TYPE_INT32
+	expected := &pluginpb.FileDescriptorProto{
+		Name:       ptr("o.proto"),
+		Package:    ptr("o"),
+		Dependency: []string{"google/protobuf/descriptor.proto"},
+		MessageType: []*pluginpb.DescriptorProto{{
+			Name: ptr("M"),
+			Field: []*pluginpb.FieldDescriptorProto{
+				{
+					Name: ptr("id"), Number: ptr(int32(1)), Label: ptr(required), Type: ptr(int32Type), JsonName: ptr("id"),
+					Options: &pluginpb.FieldOptions{Features: &pluginpb.FeatureSet{FieldPresence: ptr(pluginpb.FeatureSet_LEGACY_REQUIRED)}},
+				},
+				{
+					Name: ptr("child"), Number: ptr(int32(2)), Label: ptr(optional), Type: ptr(pluginpb.FieldDescriptorProto_TYPE_GROUP), TypeName: ptr(".o.M"), JsonName: ptr("child"),
+					Options: &pluginpb.FieldOptions{
+						Features: &pluginpb.FeatureSet{MessageEncoding: ptr(pluginpb.FeatureSet_DELIMITED)},
+						// the custom options are kept encoded, like by protoc
+						XXX_unrecognized: wire.AppendString(wire.AppendTag(nil, 1000, wire.BytesType), "kept"),
+					},
+				},
+				{
+					Name: ptr("ids"), Number: ptr(int32(3)), Label: ptr(repeated), Type: ptr(int32Type), JsonName: ptr("ids"),
+					Options: &pluginpb.FieldOptions{Deprecated: ptr(true), Features: &pluginpb.FeatureSet{RepeatedFieldEncoding: ptr(pluginpb.FeatureSet_EXPANDED)}},
+				},
+			},
+			Options: &pluginpb.MessageOptions{Deprecated: ptr(true)},
+		}},
+		EnumType: []*pluginpb.EnumDescriptorProto{{
+			Name: ptr("Level"),
+			Value: []*pluginpb.EnumValueDescriptorProto{
+				{Name: ptr("LOW"), Number: ptr(int32(0)), Options: &pluginpb.EnumValueOptions{Deprecated: ptr(true)}},
+			},
+			Options: &pluginpb.EnumOptions{Features: &pluginpb.FeatureSet{EnumType: ptr(pluginpb.FeatureSet_OPEN)}},
+		}},
+		Extension: []*pluginpb.FieldDescriptorProto{
+			{Name: ptr("tag"), Number: ptr(int32(1000)), Label: ptr(optional), Type: ptr(pluginpb.FieldDescriptorProto_TYPE_STRING), Extendee: ptr(".google.protobuf.FieldOptions"), JsonName: ptr("tag")},
+		},
+		Options: &pluginpb.FileOptions{
+			GoPackage:   ptr("example.com/o"),
+			OptimizeFor: ptr(pluginpb.FileOptions_CODE_SIZE),
+			Features: &pluginpb.FeatureSet{
+				EnumType:       ptr(pluginpb.FeatureSet_CLOSED),
+				Utf8Validation: ptr(pluginpb.FeatureSet_NONE),
+			},
+		},
+		Syntax:  ptr("editions"),
+		Edition: ptr(pluginpb.Edition_EDITION_2023),
+	}
+	if diff := cmp.Diff(expected, req.ProtoFile[1]); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+}
+
+func TestRequestErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "syntax",
+			input:    `message A {`,
+			expected: `a.proto: expected "}", got end of file`,
+		},
+		{
+			name:     "missing import",
+			input:    `import "missing.proto";`,
+			expected: `a.proto: import "missing.proto": open missing.proto: file does not exist`,
+		},
+		{
+			name:     "unknown type",
+			input:    `message A { optional B b = 1; }`,
+			expected: `a.proto: unknown type "B" in "A"`,
+		},
+		{
+			name:     "option",
+			input:    `message A { option java_package = "a"; }`,
+			expected: `a.proto: option "java_package" is not allowed on a message, only on a file`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			files := load(t, fstest.MapFS{"a.proto": {Data: []byte(test.input)}}, "a.proto")
+			_, err := plugin.Request("", files...)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if got := err.Error(); got != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,261 @@
+package plugin
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/Clement-Jean/protein/ast"
+	"github.com/Clement-Jean/protein/lexer"
+	"github.com/Clement-Jean/protein/symbols"
+	"github.com/Clement-Jean/protein/wire"
+)
+
+// decodeOptions returns the options message T set by opts, or nil
+// when none of them was interpreted. The options are encoded in the
+// wire format and then decoded into T.
+func decodeOptions[T any, P interface {
+	*T
+	Unmarshal([]byte) error
+}](b *builder, opts []*ast.Option) P {
+	var (
+		data []byte
+		set  bool
+	)
+	for _, opt := range opts {
+		interpreted, ok := b.opts[opt]
+		if !ok {
+			continue
+		}
+
+		encoded, err := b.option(data, interpreted.Path, opt.Value)
+		if err != nil {
+			b.errorf("option %q: %w", opt.NameString(), err)
+			continue
+		}
+		data, set = encoded, true
+	}
+	if !set {
+		return nil
+	}
+
+	m := P(new(T))
+	if err := m.Unmarshal(data); err != nil {
+		b.errorf("invalid options: %w", err)
+	}
+	return m
+}
+
+// option appends the encoding of the option setting v at path. The
+// fields of the path are nested messages, except for the last one.
+func (b *builder) option(data []byte, path []*symbols.Symbol, v ast.Value) ([]byte, error) {
+	last := path[len(path)-1]
+	value, err := b.fieldValue(nil, last, v)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := len(path) - 2; i >= 0; i-- {
+		num, err := fieldNumber(path[i])
+		if err != nil {
+			return nil, err
+		}
+		msg := wire.AppendTag(nil, num, wire.BytesType)
+		value = wire.AppendBytes(msg, value)
+	}
+	return append(data, value...), nil
+}
+
+func fieldNumber(sym *symbols.Symbol) (wire.Number, error) {
+	field := sym.Decl.(*ast.Field)
+	n, err := field.Number.Int()
+	if err != nil || n < int64(wire.MinValidNumber) || n > int64(wire.MaxValidNumber) {
+		return 0, fmt.Errorf("invalid number %s for %q", field.Number.Text, sym.Name)
+	}
+	return wire.Number(n), nil
+}
+
+func parentScope(scope string) string {
+	if idx := strings.LastIndexByte(scope, '.'); idx != -1 {
+		return scope[:idx]
+	}
+	return ""
+}
+
+// fieldValue appends the encoding of the field (or extension) sym
+// set to v.
+func (b *builder) fieldValue(data []byte, sym *symbols.Symbol, v ast.Value) ([]byte, error) {
+	num, err := fieldNumber(sym)
+	if err != nil {
+		return nil, err
+	}
+
+	field := sym.Decl.(*ast.Field)
+	scope := parentScope(sym.Name)
+	if field.Map != nil {
+		return b.mapEntryValue(data, num, scope, field.Map, v)
+	}
+	return b.value(data, num, scope, field.Type, v)
+}
+
+// mapEntryValue appends the entry v, an aggregate of key and value.
+func (b *builder) mapEntryValue(data []byte, num wire.Number, scope string, m *ast.Map, v ast.Value) ([]byte, error) {
+	if !v.IsAggregate() {
+		return nil, fmt.Errorf("invalid map entry %s", v.Text)
+	}
+
+	var (
+		entry []byte
+		err   error
+	)
+	for _, tf := range b.file.TextFields(v) {
+		switch name := tf.Name.String(); {
+		case name == "key" && !tf.Extension && len(tf.Values) == 1:
+			entry, err = b.value(entry, 1, scope, m.Key, tf.Values[0])
+		case name == "value" && !tf.Extension && len(tf.Values) == 1:
+			entry, err = b.value(entry, 2, scope, m.Value, tf.Values[0])
+		default:
+			err = fmt.Errorf("unknown field %q in map entry", name)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	data = wire.AppendTag(data, num, wire.BytesType)
+	return wire.AppendBytes(data, entry), nil
+}
+
+// value appends the field num of type typ, resolved in scope, set to v.
+func (b *builder) value(data []byte, num wire.Number, scope string, typ ast.Type, v ast.Value) ([]byte, error) {
+	if typ.IsScalar() {
+		return scalar(data, num, typ.Scalar, v)
+	}
+
+	sym, ok := b.table.Resolve(scope, typ.Name)
+	if !ok {
+		return nil, fmt.Errorf("unknown type %q in %q", typ.Name.String(), scope)
+	}
+
+	switch decl := sym.Decl.(type) {
+	case *ast.Enum:
+		n, err := enumNumber(decl, v)
+		if err != nil {
+			return nil, err
+		}
+		data = wire.AppendTag(data, num, wire.VarintType)
+		return wire.AppendVarint(data, uint64(n)), nil
+	case *ast.Message:
+		msg, err := b.messageValue(sym, v)
+		if err != nil {
+			return nil, err
+		}
+		data = wire.AppendTag(data, num, wire.BytesType)
+		return wire.AppendBytes(data, msg), nil
+	}
+	return nil, fmt.Errorf("%q is not a type", sym.Name)
+}
+
+// messageValue returns the encoding of the fields of the aggregate
+// v, a text format value of the message msg.
+func (b *builder) messageValue(msg *symbols.Symbol, v ast.Value) ([]byte, error) {
+	if !v.IsAggregate() {
+		return nil, fmt.Errorf("invalid value %s for %q", v.Text, msg.Name)
+	}
+
+	var data []byte
+	for _, tf := range b.file.TextFields(v) {
+		var (
+			sym *symbols.Symbol
+			ok  bool
+		)
+		if tf.Extension {
+			sym, ok = b.table.ResolveExtension("", tf.Name)
+		} else {
+			sym, ok = b.table.Lookup(msg.Name + "." + tf.Name.String())
+			ok = ok && sym.Kind == symbols.KindField
+		}
+		if !ok {
+			return nil, fmt.Errorf("unknown field %q in %q", tf.Name.String(), msg.Name)
+		}
+
+		for _, value := range tf.Values {
+			var err error
+			if data, err = b.fieldValue(data, sym, value); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return data, nil
+}
+
+// enumNumber returns the number of the value of enum named v. In the
+// text format, it can also be written as a number.
+func enumNumber(enum *ast.Enum, v ast.Value) (int64, error) {
+	if v.Kind == lexer.TokenKindInt {
+		return v.Int()
+	}
+	for _, value := range enum.Values {
+		if value.Name.Text == v.Text {
+			return value.Number.Int()
+		}
+	}
+	return 0, fmt.Errorf("enum %q has no value named %q", enum.Name.Text, v.Text)
+}
+
+func scalar(data []byte, num wire.Number, kind lexer.TokenKind, v ast.Value) ([]byte, error) {
+	var err error
+
+	switch kind {
+	case lexer.TokenKindTypeInt32, lexer.TokenKindTypeInt64:
+		var i int64
+		i, err = v.Int()
+		data = wire.AppendVarint(wire.AppendTag(data, num, wire.VarintType), uint64(i))
+	case lexer.TokenKindTypeUint32, lexer.TokenKindTypeUint64:
+		var u uint64
+		u, err = v.Uint()
+		data = wire.AppendVarint(wire.AppendTag(data, num, wire.VarintType), u)
+	case lexer.TokenKindTypeSint32, lexer.TokenKindTypeSint64:
+		var i int64
+		i, err = v.Int()
+		data = wire.AppendVarint(wire.AppendTag(data, num, wire.VarintType), wire.EncodeZigZag(i))
+	case lexer.TokenKindTypeBool:
+		var x bool
+		x, err = v.Bool()
+		data = wire.AppendVarint(wire.AppendTag(data, num, wire.VarintType), wire.EncodeBool(x))
+	case lexer.TokenKindTypeFixed32:
+		var u uint64
+		u, err = v.Uint()
+		data = wire.AppendFixed32(wire.AppendTag(data, num, wire.Fixed32Type), uint32(u))
+	case lexer.TokenKindTypeSfixed32:
+		var i int64
+		i, err = v.Int()
+		data = wire.AppendFixed32(wire.AppendTag(data, num, wire.Fixed32Type), uint32(i))
+	case lexer.TokenKindTypeFloat:
+		var f float64
+		f, err = v.Float()
+		data = wire.AppendFixed32(wire.AppendTag(data, num, wire.Fixed32Type), math.Float32bits(float32(f)))
+	case lexer.TokenKindTypeFixed64:
+		var u uint64
+		u, err = v.Uint()
+		data = wire.AppendFixed64(wire.AppendTag(data, num, wire.Fixed64Type), u)
+	case lexer.TokenKindTypeSfixed64:
+		var i int64
+		i, err = v.Int()
+		data = wire.AppendFixed64(wire.AppendTag(data, num, wire.Fixed64Type), uint64(i))
+	case lexer.TokenKindTypeDouble:
+		var f float64
+		f, err = v.Float()
+		data = wire.AppendFixed64(wire.AppendTag(data, num, wire.Fixed64Type), math.Float64bits(f))
+	case lexer.TokenKindTypeString, lexer.TokenKindTypeBytes:
+		var s string
+		s, err = v.String()
+		data = wire.AppendString(wire.AppendTag(data, num, wire.BytesType), s)
+	default:
+		err = fmt.Errorf("unknown type %s", kind)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid value %s: %w", v.Text, err)
+	}
+	return data, nil
+}
@@ -0,0 +1,143 @@
+package plugin
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"iter"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/Clement-Jean/protein/plugin/pluginpb"
+)
+
+// Output collects the files generated by plugins. Like with protoc,
+// the content of a file without name is appended to the previous file
+// of the response and the content with an insertion point is inserted
+// into a file generated before, by the same response or by a previous
+// one. The zero value is ready to use.
+type Output struct {
+	names []string
+	files map[string][]byte
+}
+
+type chunk struct {
+	name, point string
+	content     []byte
+}
+
+// Add adds the files of resp. Nothing is added when one of them is
+// invalid.
+func (o *Output) Add(resp *pluginpb.CodeGeneratorResponse) error {
+	var chunks []*chunk
+	for _, f := range resp.File {
+		c := &chunk{}
+		if f.Name != nil {
+			c.name = *f.Name
+		}
+		if f.InsertionPoint != nil {
+			c.point = *f.InsertionPoint
+		}
+		if f.Content != nil {
+			c.content = []byte(*f.Content)
+		}
+
+		if c.name == "" {
+			if c.point != "" {
+				return fmt.Errorf("insertion point %q has no file name", c.point)
+			}
+			if len(chunks) == 0 {
+				return fmt.Errorf("the first file of the response has no name")
+			}
+			prev := chunks[len(chunks)-1]
+			prev.content = append(prev.content, c.content...)
+			continue
+		}
+		if !fs.ValidPath(c.name) || c.name == "." {
+			return fmt.Errorf("invalid file name %q, it must be relative and not contain . or .. elements", c.name)
+		}
+		chunks = append(chunks, c)
+	}
+
+	// the chunks are applied to a copy so that o is left unchanged
+	// when one of them fails, the contents are never modified in place
+	next := &Output{names: slices.Clone(o.names), files: maps.Clone(o.files)}
+	if next.files == nil {
+		next.files = make(map[string][]byte)
+	}
+	for _, c := range chunks {
+		if c.point != "" {
+			if err := next.insert(c); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, ok := next.files[c.name]; ok {
+			return fmt.Errorf("%q was already generated", c.name)
+		}
+		next.names = append(next.names, c.name)
+		next.files[c.name] = c.content
+	}
+	*o = *next
+	return nil
+}
+
+// insert inserts the content of c above the line containing the
+// insertion point, every line is indented like that line.
+func (o *Output) insert(c *chunk) error {
+	content, ok := o.files[c.name]
+	if !ok {
+		return fmt.Errorf("cannot insert into %q, it was not generated", c.name)
+	}
+
+	marker := []byte("@@protoc_insertion_point(" + c.point + ")")
+	idx := bytes.Index(content, marker)
+	if idx == -1 {
+		return fmt.Errorf("insertion point %q not found in %q", c.point, c.name)
+	}
+	start := bytes.LastIndexByte(content[:idx], '\n') + 1
+	line := content[start:]
+	indent := line[:len(line)-len(bytes.TrimLeft(line, " \t"))]
+
+	var text []byte
+	for _, line := range bytes.SplitAfter(c.content, []byte("\n")) {
+		if len(bytes.TrimSpace(line)) != 0 {
+			text = append(text, indent...)
+		}
+		text = append(text, line...)
+	}
+	if len(text) != 0 && text[len(text)-1] != '\n' {
+		text = append(text, '\n')
+	}
+
+	o.files[c.name] = slices.Concat(content[:start], text, content[start:])
+	return nil
+}
+
+// Files returns the names and the contents of the files, in the order
+// in which they were generated.
+func (o *Output) Files() iter.Seq2[string, []byte] {
+	return func(yield func(string, []byte) bool) {
+		for _, name := range o.names {
+			if !yield(name, o.files[name]) {
+				return
+			}
+		}
+	}
+}
+
+// Write writes the files under dir, creating the directories.
+func (o *Output) Write(dir string) error {
+	for name, content := range o.Files() {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, content, 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
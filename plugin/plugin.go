@@ -0,0 +1,107 @@
+// Package plugin runs the protoc plugins (protoc-gen-*) on the files
+// parsed by protein. Request describes the files like protoc does,
+// Run executes a plugin with the request on its standard input and
+// Output writes the files of the responses.
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/Clement-Jean/protein/plugin/pluginpb"
+)
+
+// Run executes the plugin at path with req on its standard input and
+// returns the response written on its standard output. A path without
+// separator is looked up in the PATH (e.g. protoc-gen-go). The error
+// reported in the response and the files using features the plugin
+// does not support (proto3 optional fields, editions) are errors.
+func Run(ctx context.Context, path string, req *pluginpb.CodeGeneratorRequest) (*pluginpb.CodeGeneratorResponse, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, path)
+	cmd.Stdin = bytes.NewReader(req.Marshal())
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s: %w: %s", path, err, msg)
+		}
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	resp := &pluginpb.CodeGeneratorResponse{}
+	if err := resp.Unmarshal(stdout.Bytes()); err != nil {
+		return nil, fmt.Errorf("%s: invalid response: %w", path, err)
+	}
+	if resp.Error != nil {
+		return nil, fmt.Errorf("%s: %s", path, *resp.Error)
+	}
+	if err := supports(resp, req); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return resp, nil
+}
+
+// supports checks that the plugin which responded resp supports the
+// features used by the files generated for req.
+func supports(resp *pluginpb.CodeGeneratorResponse, req *pluginpb.CodeGeneratorRequest) error {
+	var features uint64
+	if resp.SupportedFeatures != nil {
+		features = *resp.SupportedFeatures
+	}
+	supported := func(f pluginpb.CodeGeneratorResponse_Feature) bool {
+		return features&uint64(f) != 0
+	}
+
+	var errs []error
+	for _, file := range req.SourceFileDescriptors {
+		var name string
+		if file.Name != nil {
+			name = *file.Name
+		}
+		switch {
+		case file.Edition != nil:
+			edition := *file.Edition
+			switch {
+			case !supported(pluginpb.CodeGeneratorResponse_FEATURE_SUPPORTS_EDITIONS):
+				errs = append(errs, fmt.Errorf("%s is an editions file, but the plugin does not support editions", name))
+			case resp.MinimumEdition != nil && int32(edition) < *resp.MinimumEdition:
+				errs = append(errs, fmt.Errorf("%s is in edition %s, which is earlier than the minimum supported edition %s", name, edition, pluginpb.Edition(*resp.MinimumEdition)))
+			case resp.MaximumEdition != nil && int32(edition) > *resp.MaximumEdition:
+				errs = append(errs, fmt.Errorf("%s is in edition %s, which is later than the maximum supported edition %s", name, edition, pluginpb.Edition(*resp.MaximumEdition)))
+			}
+		case hasProto3Optional(file) && !supported(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL):
+			errs = append(errs, fmt.Errorf("%s contains proto3 optional fields, but the plugin does not support them", name))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func hasProto3Optional(file *pluginpb.FileDescriptorProto) bool {
+	var inMessage func(msg *pluginpb.DescriptorProto) bool
+	inMessage = func(msg *pluginpb.DescriptorProto) bool {
+		for _, field := range msg.Field {
+			if field.Proto3Optional != nil && *field.Proto3Optional {
+				return true
+			}
+		}
+		for _, nested := range msg.NestedType {
+			if inMessage(nested) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, msg := range file.MessageType {
+		if inMessage(msg) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,308 @@
+package plugin_test
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/Clement-Jean/protein/plugin"
+	"github.com/Clement-Jean/protein/plugin/pluginpb"
+)
+
+// buildFakePlugin builds testdata/fakeplugin and returns its path.
+func buildFakePlugin(t *testing.T) string {
+	t.Helper()
+
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go is needed to build the fake plugin")
+	}
+
+	path := filepath.Join(t.TempDir(), "protoc-gen-fake")
+	out, err := exec.Command(goBin, "build", "-o", path, "./testdata/fakeplugin").CombinedOutput()
+	if err != nil {
+		t.Fatalf("%v: %s", err, out)
+	}
+	return path
+}
+
+func TestRun(t *testing.T) {
+	path := buildFakePlugin(t)
+	fsys := fstest.MapFS{
+		"a.proto": {Data: []byte(`syntax = "proto3";
+message A {
+  optional int32 x = 1;
+  string y = 2;
+}
+`)},
+		"e.proto": {Data: []byte(`edition = "2023";
+message E {
+  int32 z = 1;
+}
+`)},
+	}
+
+	tests := []struct {
+		name      string
+		file      string
+		parameter string
+		expected  map[string]string
+		err       string
+	}{
+		{
+			name:      "proto3",
+			file:      "a.proto",
+			parameter: "p",
+			expected: map[string]string{
+				"a.txt": `// a.proto (p)
+message A {
+  x = 1;
+  y = 2;
+  // @@protoc_insertion_point(message)
+}
+// end
+// @@protoc_insertion_point(end)
+`,
+			},
+		},
+		{
+			name: "editions",
+			file: "e.proto",
+			expected: map[string]string{
+				"e.txt": `// e.proto ()
+message E {
+  z = 1;
+  // @@protoc_insertion_point(message)
+}
+// end
+// @@protoc_insertion_point(end)
+`,
+			},
+		},
+		{
+			name:      "error",
+			file:      "a.proto",
+			parameter: "error",
+			err:       "fake error",
+		},
+		{
+			name:      "crash",
+			file:      "a.proto",
+			parameter: "crash",
+			err:       "exit status 1: fake crash",
+		},
+		{
+			name:      "garbage",
+			file:      "a.proto",
+			parameter: "garbage",
+			err:       "invalid response: unexpected end of input",
+		},
+		{
+			name:      "proto3 optional not supported",
+			file:      "a.proto",
+			parameter: "legacy",
+			err:       "a.proto contains proto3 optional fields, but the plugin does not support them",
+		},
+		{
+			name:      "editions not supported",
+			file:      "e.proto",
+			parameter: "legacy",
+			err:       "e.proto is an editions file, but the plugin does not support editions",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req, err := plugin.Request(test.parameter, load(t, fsys, test.file)...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := plugin.Run(context.Background(), path, req)
+			if test.err != "" {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				if got := strings.TrimPrefix(err.Error(), path+": "); got != test.err {
+					t.Errorf("expected %q, got %q", test.err, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var out plugin.Output
+			if err := out.Add(resp); err != nil {
+				t.Fatal(err)
+			}
+
+			dir := t.TempDir()
+			if err := out.Write(dir); err != nil {
+				t.Fatal(err)
+			}
+			got := make(map[string]string)
+			for name := range test.expected {
+				content, err := os.ReadFile(filepath.Join(dir, name))
+				if err != nil {
+					t.Fatal(err)
+				}
+				got[name] = string(content)
+			}
+			if diff := cmp.Diff(test.expected, got); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func file(name, point, content string) *pluginpb.CodeGeneratorResponse_File {
+	f := &pluginpb.CodeGeneratorResponse_File{Content: &content}
+	if name != "" {
+		f.Name = &name
+	}
+	if point != "" {
+		f.InsertionPoint = &point
+	}
+	return f
+}
+
+func TestOutput(t *testing.T) {
+	const generated = `package a
+
+type A struct {
+	// @@protoc_insertion_point(fields)
+}
+
+// @@protoc_insertion_point(end)
+`
+
+	tests := []struct {
+		name      string
+		responses [][]*pluginpb.CodeGeneratorResponse_File
+		expected  map[string]string
+		err       string
+	}{
+		{
+			name: "files",
+			responses: [][]*pluginpb.CodeGeneratorResponse_File{{
+				file("a.go", "", "package a\n"),
+				file("dir/b.go", "", "package "),
+				file("", "", "dir\n"),
+			}},
+			expected: map[string]string{
+				"a.go":     "package a\n",
+				"dir/b.go": "package dir\n",
+			},
+		},
+		{
+			name: "insertion points",
+			responses: [][]*pluginpb.CodeGeneratorResponse_File{
+				{
+					file("a.go", "", generated),
+					file("a.go", "end", "func f() {}\n"),
+				},
+				{
+					file("a.go", "fields", "X int\n\nY int"),
+					file("a.go", "fields", "Z "),
+					file("", "", "int\n"),
+					file("a.go", "end", "func g() {}\n"),
+				},
+			},
+			expected: map[string]string{
+				"a.go": `package a
+
+type A struct {
+	X int
+
+	Y int
+	Z int
+	// @@protoc_insertion_point(fields)
+}
+
+func f() {}
+func g() {}
+// @@protoc_insertion_point(end)
+`,
+			},
+		},
+		{
+			// the files of a failed response are not added
+			name: "missing insertion point",
+			responses: [][]*pluginpb.CodeGeneratorResponse_File{
+				{file("a.go", "", generated)},
+				{
+					file("a.go", "end", "func f() {}\n"),
+					file("b.go", "", "package a\n"),
+					file("a.go", "other", ""),
+				},
+			},
+			expected: map[string]string{"a.go": generated},
+			err:      `insertion point "other" not found in "a.go"`,
+		},
+		{
+			name:      "missing file",
+			responses: [][]*pluginpb.CodeGeneratorResponse_File{{file("a.go", "end", "")}},
+			err:       `cannot insert into "a.go", it was not generated`,
+		},
+		{
+			name: "generated twice",
+			responses: [][]*pluginpb.CodeGeneratorResponse_File{
+				{file("a.go", "", "package a\n")},
+				{file("b.go", "", "package a\n"), file("a.go", "", "")},
+			},
+			expected: map[string]string{"a.go": "package a\n"},
+			err:      `"a.go" was already generated`,
+		},
+		{
+			name:      "no name",
+			responses: [][]*pluginpb.CodeGeneratorResponse_File{{file("", "", "package a\n")}},
+			err:       "the first file of the response has no name",
+		},
+		{
+			name:      "absolute name",
+			responses: [][]*pluginpb.CodeGeneratorResponse_File{{file("/a.go", "", "")}},
+			err:       `invalid file name "/a.go", it must be relative and not contain . or .. elements`,
+		},
+		{
+			name:      "parent name",
+			responses: [][]*pluginpb.CodeGeneratorResponse_File{{file("../a.go", "", "")}},
+			err:       `invalid file name "../a.go", it must be relative and not contain . or .. elements`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				out plugin.Output
+				err error
+			)
+			for _, files := range test.responses {
+				if err = out.Add(&pluginpb.CodeGeneratorResponse{File: files}); err != nil {
+					break
+				}
+			}
+			switch {
+			case test.err != "" && (err == nil || err.Error() != test.err):
+				t.Fatalf("expected %q, got %v", test.err, err)
+			case test.err == "" && err != nil:
+				t.Fatal(err)
+			}
+
+			got := make(map[string]string)
+			for name, content := range out.Files() {
+				got[name] = string(content)
+			}
+			if diff := cmp.Diff(test.expected, got, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,4 @@
+// Package pluginpb contains the messages of plugin.proto and of
+// descriptor.proto, generated by the gogen package. Run go test
+// -update in the plugin package to regenerate them.
+package pluginpb
@@ -0,0 +1,59 @@
+package plugin_test
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"testing"
+
+	"github.com/Clement-Jean/protein/ast"
+	"github.com/Clement-Jean/protein/gogen"
+	"github.com/Clement-Jean/protein/loader"
+	"github.com/Clement-Jean/protein/symbols"
+	"github.com/Clement-Jean/protein/wellknown"
+)
+
+var update = flag.Bool("update", false, "update the pluginpb package")
+
+func TestPluginpb(t *testing.T) {
+	l := loader.New(wellknown.FS)
+	defer l.Close()
+
+	var files []*ast.File
+	for _, path := range []string{"google/protobuf/descriptor.proto", "google/protobuf/compiler/plugin.proto"} {
+		f, err := l.Load(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(f.Errs) != 0 {
+			t.Fatal(f.Errs)
+		}
+		files = append(files, f.AST)
+	}
+
+	table, errs := symbols.New(files...)
+	if len(errs) != 0 {
+		t.Fatal(errs)
+	}
+
+	src, err := gogen.Generate("pluginpb", table, files...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const golden = "pluginpb/pluginpb.pb.go"
+	if *update {
+		if err := os.WriteFile(golden, src, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	expected, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(expected, src) {
+		t.Errorf("%s is out of date, run go test -update", golden)
+	}
+}
@@ -0,0 +1,86 @@
+// Command fakeplugin is a protoc plugin for the tests. For each file to
+// generate, it writes the messages and their fields in a .txt file and
+// inserts a line at the end. The parameter changes its behavior:
+// "error" responds with an error, "crash" exits with an error,
+// "garbage" writes an invalid response and "legacy" does not support
+// proto3 optional fields and editions.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Clement-Jean/protein/plugin/pluginpb"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+func main() {
+	in, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	req := &pluginpb.CodeGeneratorRequest{}
+	if err := req.Unmarshal(in); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	var param string
+	if req.Parameter != nil {
+		param = *req.Parameter
+	}
+
+	resp := &pluginpb.CodeGeneratorResponse{
+		SupportedFeatures: ptr(uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL | pluginpb.CodeGeneratorResponse_FEATURE_SUPPORTS_EDITIONS)),
+		MinimumEdition:    ptr(int32(pluginpb.Edition_EDITION_PROTO2)),
+		MaximumEdition:    ptr(int32(pluginpb.Edition_EDITION_2023)),
+	}
+	switch param {
+	case "error":
+		resp.Error = ptr("fake error")
+	case "crash":
+		fmt.Fprintln(os.Stderr, "fake crash")
+		os.Exit(1)
+	case "garbage":
+		os.Stdout.Write([]byte{0xff})
+		return
+	case "legacy":
+		resp.SupportedFeatures = nil
+	}
+
+	for _, name := range req.FileToGenerate {
+		for _, file := range req.ProtoFile {
+			if *file.Name != name {
+				continue
+			}
+
+			out := strings.TrimSuffix(name, ".proto") + ".txt"
+			var b strings.Builder
+			fmt.Fprintf(&b, "// %s (%s)\n", name, param)
+			for _, msg := range file.MessageType {
+				fmt.Fprintf(&b, "message %s {\n", *msg.Name)
+				for _, field := range msg.Field {
+					fmt.Fprintf(&b, "  %s = %d;\n", *field.Name, *field.Number)
+				}
+				b.WriteString("  // @@protoc_insertion_point(message)\n}\n")
+			}
+
+			// the end of the file is written as a second chunk
+			resp.File = append(resp.File,
+				&pluginpb.CodeGeneratorResponse_File{Name: ptr(out), Content: ptr(b.String())},
+				&pluginpb.CodeGeneratorResponse_File{Content: ptr("// @@protoc_insertion_point(end)\n")},
+				&pluginpb.CodeGeneratorResponse_File{Name: ptr(out), InsertionPoint: ptr("end"), Content: ptr("// end")},
+			)
+		}
+	}
+
+	if _, err := os.Stdout.Write(resp.Marshal()); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,50 @@
+package rewrite
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+)
+
+// Edit replaces the bytes in [Start, End) of the original source
+// by NewText. Insertions have Start == End.
+type Edit struct {
+	Start   uint32
+	End     uint32
+	NewText string
+}
+
+func sortEdits(edits []Edit) {
+	slices.SortStableFunc(edits, func(a, b Edit) int {
+		if a.Start != b.Start {
+			return int(a.Start) - int(b.Start)
+		}
+		return int(a.End) - int(b.End)
+	})
+}
+
+// Apply applies edits to src and returns the new content.
+// The edits are expressed against src and must not overlap.
+func Apply(src []byte, edits []Edit) ([]byte, error) {
+	edits = slices.Clone(edits)
+	sortEdits(edits)
+
+	var out bytes.Buffer
+	out.Grow(len(src))
+
+	var last uint32
+	for _, edit := range edits {
+		if edit.Start > edit.End || edit.End > uint32(len(src)) {
+			return nil, fmt.Errorf("invalid edit range [%d, %d)", edit.Start, edit.End)
+		}
+		if edit.Start < last {
+			return nil, fmt.Errorf("overlapping edit at offset %d", edit.Start)
+		}
+
+		out.Write(src[last:edit.Start])
+		out.WriteString(edit.NewText)
+		last = edit.End
+	}
+	out.Write(src[last:])
+	return out.Bytes(), nil
+}
@@ -0,0 +1,461 @@
+package rewrite
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Clement-Jean/protein/ast"
+	"github.com/Clement-Jean/protein/lexer"
+	"github.com/Clement-Jean/protein/symbols"
+)
+
+const defaultIndent = "  "
+
+type reference struct {
+	scope string
+	name  ast.FullIdent
+}
+
+// Rewriter records edits against the source of a file. The
+// declarations are designated by their fully-qualified name
+// without leading dot (e.g. pkg.Outer.Inner).
+type Rewriter struct {
+	file  *ast.File
+	edits []Edit
+	table *symbols.Table
+	refs  []reference
+}
+
+func New(file *ast.File) *Rewriter {
+	// only the first definition of a duplicate is in the table
+	table, _ := symbols.New(file)
+	r := &Rewriter{file: file, table: table}
+
+	var pkg string
+	if file.Package != nil {
+		pkg = file.Package.Name.String()
+	}
+	r.indexExtends(pkg, file.Extends)
+
+	for sym := range table.Symbols() {
+		scope := parentScope(sym.Name)
+		switch decl := sym.Decl.(type) {
+		case *ast.Message:
+			r.indexExtends(sym.Name, decl.Extends)
+		case *ast.Field:
+			switch {
+			case decl.Map != nil && !decl.Map.Value.IsScalar():
+				r.refs = append(r.refs, reference{scope: scope, name: decl.Map.Value.Name})
+			case decl.Map == nil && !decl.Type.IsScalar():
+				r.refs = append(r.refs, reference{scope: scope, name: decl.Type.Name})
+			}
+		case *ast.RPC:
+			r.refs = append(r.refs,
+				reference{scope: scope, name: decl.Input},
+				reference{scope: scope, name: decl.Output},
+			)
+		}
+	}
+	return r
+}
+
+// indexExtends adds the extended messages, the types of the
+// extensions are indexed with the other fields.
+func (r *Rewriter) indexExtends(scope string, extends []*ast.Extend) {
+	for _, extend := range extends {
+		r.refs = append(r.refs, reference{scope: scope, name: extend.Extendee})
+	}
+}
+
+func parentScope(scope string) string {
+	if idx := strings.LastIndexByte(scope, '.'); idx != -1 {
+		return scope[:idx]
+	}
+	return ""
+}
+
+// decl returns the declaration named name, nil when there is none.
+func (r *Rewriter) decl(name string) any {
+	if sym, ok := r.table.Lookup(name); ok {
+		return sym.Decl
+	}
+	return nil
+}
+
+func (r *Rewriter) replaceToken(tok ast.Token, text string) {
+	start, end := r.file.TokenSpan(tok.Idx)
+	r.edits = append(r.edits, Edit{Start: start, End: end, NewText: text})
+}
+
+func (r *Rewriter) insert(offset uint32, text string) {
+	r.edits = append(r.edits, Edit{Start: offset, End: offset, NewText: text})
+}
+
+func (r *Rewriter) message(name string) (*ast.Message, error) {
+	if msg, ok := r.decl(name).(*ast.Message); ok {
+		return msg, nil
+	}
+	return nil, fmt.Errorf("message %q not found", name)
+}
+
+func (r *Rewriter) renameType(name string, decl ast.Token, newName string) {
+	r.replaceToken(decl, newName)
+
+	target := strings.Split(name, ".")
+	k := len(target) - 1
+
+	for _, ref := range r.refs {
+		sym, ok := r.table.Resolve(ref.scope, ref.name)
+		if !ok {
+			continue
+		}
+
+		resolved := sym.Name
+		if resolved != name && !strings.HasPrefix(resolved, name+".") {
+			continue
+		}
+
+		// the reference is a suffix of the resolved name
+		offset := strings.Count(resolved, ".") + 1 - len(ref.name.Parts)
+		if k >= offset {
+			r.replaceToken(ref.name.Parts[k-offset], newName)
+		}
+	}
+}
+
+// RenameMessage renames a message and all the references to it
+// (or to its nested types) in the file.
+func (r *Rewriter) RenameMessage(name, newName string) error {
+	msg, err := r.message(name)
+	if err != nil {
+		return err
+	}
+
+	r.renameType(name, msg.Name, newName)
+	return nil
+}
+
+// RenameEnum renames an enum and all the references to it in the file.
+func (r *Rewriter) RenameEnum(name, newName string) error {
+	enum, ok := r.decl(name).(*ast.Enum)
+	if !ok {
+		return fmt.Errorf("enum %q not found", name)
+	}
+
+	r.renameType(name, enum.Name, newName)
+	return nil
+}
+
+func findField(msg *ast.Message, name string) *ast.Field {
+	for _, field := range msg.Fields {
+		if field.Name.Text == name {
+			return field
+		}
+	}
+	return nil
+}
+
+func (r *Rewriter) RenumberField(message, field string, number int32) error {
+	msg, err := r.message(message)
+	if err != nil {
+		return err
+	}
+
+	f := findField(msg, field)
+	if f == nil {
+		return fmt.Errorf("field %q not found in %q", field, message)
+	}
+
+	r.replaceToken(f.Number.Token, strconv.Itoa(int(number)))
+	return nil
+}
+
+// AddOption adds an option to the file (empty target), to a message,
+// an enum, a service or a field (pkg.Message.field).
+func (r *Rewriter) AddOption(target, name, value string) error {
+	stmt := fmt.Sprintf("option %s = %s;", name, value)
+
+	if target == "" {
+		r.addFileStatement(stmt)
+		return nil
+	}
+
+	switch decl := r.decl(target).(type) {
+	case *ast.Message:
+		r.insertBlockStart(decl.Name, stmt)
+		return nil
+	case *ast.Enum:
+		r.insertBlockStart(decl.Name, stmt)
+		return nil
+	case *ast.Service:
+		r.insertBlockStart(decl.Name, stmt)
+		return nil
+	}
+
+	msg, err := r.message(parentScope(target))
+	if err != nil {
+		return fmt.Errorf("%q not found", target)
+	}
+
+	fieldName := target[strings.LastIndexByte(target, '.')+1:]
+	field := findField(msg, fieldName)
+	if field == nil {
+		return fmt.Errorf("%q not found", target)
+	}
+
+	opt := fmt.Sprintf("%s = %s", name, value)
+	toks := r.file.Toks.TokenInfos
+	for i := field.Number.Idx + 1; i < uint32(len(toks)); i++ {
+		switch toks[i].Kind {
+		case lexer.TokenKindRightSquare:
+			r.insert(toks[i].Offset, ", "+opt)
+			return nil
+		case lexer.TokenKindSemicolon:
+			_, end := r.file.TokenSpan(field.Number.Idx)
+			r.insert(end, " ["+opt+"]")
+			return nil
+		}
+	}
+	return fmt.Errorf("%q is incomplete", target)
+}
+
+// InsertField adds a field declaration (e.g. "string name = 3;")
+// at the end of a message.
+func (r *Rewriter) InsertField(message, decl string) error {
+	msg, err := r.message(message)
+	if err != nil {
+		return err
+	}
+
+	src := r.file.Src.Bytes()
+	closeTok := r.file.Tree[msg.Node].TokIdx
+	if r.file.Tree[msg.Node].IsMissing() ||
+		closeTok >= uint32(len(r.file.Toks.TokenInfos)) ||
+		r.file.Toks.TokenInfos[closeTok].Kind != lexer.TokenKindRightBrace {
+		return fmt.Errorf("message %q is incomplete", message)
+	}
+
+	closeOffset := r.file.Toks.TokenInfos[closeTok].Offset
+	if start := lineStart(src, closeOffset); isBlank(src[start:closeOffset]) {
+		// } is on its own line
+		r.insert(start, r.memberIndent(msg.Name)+decl+"\n")
+		return nil
+	}
+
+	openTok := r.openBrace(msg.Name)
+	if openTok+1 == closeTok { // {}
+		parent := indentAt(src, closeOffset)
+		r.insert(closeOffset, "\n"+parent+defaultIndent+decl+"\n"+parent)
+		return nil
+	}
+
+	r.insert(closeOffset, decl+" ")
+	return nil
+}
+
+func (r *Rewriter) DeleteRPC(service, rpc string) error {
+	s, ok := r.decl(service).(*ast.Service)
+	if !ok {
+		return fmt.Errorf("service %q not found", service)
+	}
+
+	for _, m := range s.RPCs {
+		if m.Name.Text != rpc {
+			continue
+		}
+
+		r.edits = append(r.edits, DeleteNode(r.file, m.Node))
+		return nil
+	}
+	return fmt.Errorf("rpc %q not found in %q", rpc, service)
+}
+
+// DeleteNode returns the edit removing the subtree rooted at node.
+// The lines are removed when the subtree is alone on them, along with
+// the comment lines right above (its leading comments).
+func DeleteNode(file *ast.File, node int) Edit {
+	src := file.Src.Bytes()
+	start, end := file.NodeSpan(node)
+	lineBegin := lineStart(src, start)
+	lineEnd := skipSpaces(src, end)
+
+	if isBlank(src[lineBegin:start]) && (lineEnd == uint32(len(src)) || src[lineEnd] == '\n') {
+		return Edit{Start: leadingComments(file, lineBegin), End: min(lineEnd+1, uint32(len(src)))}
+	}
+	return Edit{Start: start, End: lineEnd}
+}
+
+// leadingComments returns the start of the comments alone on their
+// lines right above the line starting at lineBegin, or lineBegin if
+// there are none. A blank line detaches the comments above it.
+func leadingComments(file *ast.File, lineBegin uint32) uint32 {
+	src := file.Src.Bytes()
+	toks := file.Toks.TokenInfos
+	idx, _ := slices.BinarySearchFunc(toks, lineBegin, func(tok lexer.TokenInfo, offset uint32) int {
+		return cmp.Compare(tok.Offset, offset)
+	})
+
+	for idx--; idx >= 0 && toks[idx].Kind == lexer.TokenKindComment; idx-- {
+		begin := lineStart(src, toks[idx].Offset)
+		after := skipSpaces(src, toks[idx].End())
+		if !isBlank(src[begin:toks[idx].Offset]) || after+1 != lineBegin || src[after] != '\n' {
+			break
+		}
+		lineBegin = begin
+	}
+	return lineBegin
+}
+
+// DeleteToken returns the edit removing the token at tokIdx
+// and the spaces following it.
+func DeleteToken(file *ast.File, tokIdx uint32) Edit {
+	start, end := file.TokenSpan(tokIdx)
+	return Edit{Start: start, End: skipSpaces(file.Src.Bytes(), end)}
+}
+
+// Edits returns the recorded edits sorted by offset.
+func (r *Rewriter) Edits() []Edit {
+	sortEdits(r.edits)
+	return r.edits
+}
+
+// Bytes returns the source with all the recorded edits applied.
+func (r *Rewriter) Bytes() ([]byte, error) {
+	return Apply(r.file.Src.Bytes(), r.edits)
+}
+
+func (r *Rewriter) addFileStatement(stmt string) {
+	var last *uint32
+	extend := func(node int) {
+		_, end := r.file.NodeSpan(node)
+		if last == nil || end > *last {
+			last = &end
+		}
+	}
+
+	if r.file.Syntax != nil {
+		extend(r.file.Syntax.Node)
+	}
+	if r.file.Edition != nil {
+		extend(r.file.Edition.Node)
+	}
+	if r.file.Package != nil {
+		extend(r.file.Package.Node)
+	}
+	for _, imp := range r.file.Imports {
+		extend(imp.Node)
+	}
+	for _, opt := range r.file.Options {
+		extend(opt.Node)
+	}
+
+	if last == nil {
+		r.insert(0, stmt+"\n")
+		return
+	}
+
+	src := r.file.Src.Bytes()
+	end := lineEnd(src, *last)
+	if end == uint32(len(src)) {
+		r.insert(end, "\n"+stmt)
+		return
+	}
+	r.insert(end+1, stmt+"\n")
+}
+
+func (r *Rewriter) openBrace(name ast.Token) uint32 {
+	toks := r.file.Toks.TokenInfos
+	i := name.Idx + 1
+	for i < uint32(len(toks)) && toks[i].Kind != lexer.TokenKindLeftBrace {
+		i++
+	}
+	return i
+}
+
+// memberIndent returns the indentation of the first member of a
+// block or the indentation of the block plus a default indent.
+func (r *Rewriter) memberIndent(name ast.Token) string {
+	src := r.file.Src.Bytes()
+	toks := r.file.Toks.TokenInfos
+	openTok := r.openBrace(name)
+	if openTok+1 < uint32(len(toks)) {
+		next := toks[openTok+1]
+		if next.Kind != lexer.TokenKindRightBrace && next.Kind != lexer.TokenKindEOF &&
+			lineStart(src, next.Offset) != lineStart(src, toks[name.Idx].Offset) {
+			return indentAt(src, next.Offset)
+		}
+	}
+	return indentAt(src, toks[name.Idx].Offset) + defaultIndent
+}
+
+func (r *Rewriter) insertBlockStart(name ast.Token, stmt string) {
+	src := r.file.Src.Bytes()
+	toks := r.file.Toks.TokenInfos
+	openTok := r.openBrace(name)
+	if openTok >= uint32(len(toks)) {
+		return
+	}
+
+	_, pos := r.file.TokenSpan(openTok)
+	next := openTok + 1
+	if next < uint32(len(toks)) && toks[next].Kind == lexer.TokenKindComment &&
+		lineStart(src, toks[next].Offset) == lineStart(src, pos) {
+		// keep the comment on the line of the brace
+		_, pos = r.file.TokenSpan(next)
+		next++
+	}
+
+	switch {
+	case next >= uint32(len(toks)):
+		return
+	case lineStart(src, toks[next].Offset) != lineStart(src, pos):
+		r.insert(pos, "\n"+r.memberIndent(name)+stmt)
+	case toks[next].Kind == lexer.TokenKindRightBrace:
+		parent := indentAt(src, pos)
+		r.insert(pos, "\n"+parent+defaultIndent+stmt+"\n"+parent)
+	default:
+		r.insert(pos, " "+stmt)
+	}
+}
+
+func lineStart(src []byte, offset uint32) uint32 {
+	for offset > 0 && src[offset-1] != '\n' {
+		offset--
+	}
+	return offset
+}
+
+func lineEnd(src []byte, offset uint32) uint32 {
+	for offset < uint32(len(src)) && src[offset] != '\n' {
+		offset++
+	}
+	return offset
+}
+
+func indentAt(src []byte, offset uint32) string {
+	start := lineStart(src, offset)
+	end := start
+	for end < uint32(len(src)) && (src[end] == ' ' || src[end] == '\t') {
+		end++
+	}
+	return string(src[start:end])
+}
+
+func skipSpaces(src []byte, offset uint32) uint32 {
+	for offset < uint32(len(src)) && (src[offset] == ' ' || src[offset] == '\t' || src[offset] == '\r') {
+		offset++
+	}
+	return offset
+}
+
+func isBlank(b []byte) bool {
+	for _, c := range b {
+		if c != ' ' && c != '\t' {
+			return false
+		}
+	}
+	return true
+}
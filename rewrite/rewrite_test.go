@@ -0,0 +1,374 @@
+package rewrite_test
+
+import (
+	"testing"
+
+	"github.com/Clement-Jean/protein/internal/asttest"
+	"github.com/Clement-Jean/protein/rewrite"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var rewriteTests = []struct {
+	name     string
+	input    string
+	rewrite  func(r *rewrite.Rewriter) error
+	expected string
+}{
+	{
+		name: "rename_message",
+		input: `package pkg;
+
+// Foo is documented
+message Foo {
+  message Inner {}
+  Foo self = 1; // comment kept
+  Foo.Inner inner = 2;
+  .pkg.Foo abs = 3;
+  map<string, Foo> m = 4;
+}
+
+message Bar {
+  pkg.Foo foo = 1;
+  Foo.Inner inner = 2;
+}
+
+service S {
+  rpc Get (Foo) returns (stream pkg.Foo);
+}
+`,
+		rewrite: func(r *rewrite.Rewriter) error {
+			return r.RenameMessage("pkg.Foo", "Baz")
+		},
+		expected: `package pkg;
+
+// Foo is documented
+message Baz {
+  message Inner {}
+  Baz self = 1; // comment kept
+  Baz.Inner inner = 2;
+  .pkg.Baz abs = 3;
+  map<string, Baz> m = 4;
+}
+
+message Bar {
+  pkg.Baz foo = 1;
+  Baz.Inner inner = 2;
+}
+
+service S {
+  rpc Get (Baz) returns (stream pkg.Baz);
+}
+`,
+	},
+	{
+		name: "rename_extended_message",
+		input: `syntax = "proto2";
+package pkg;
+
+message Foo {
+  extensions 100 to 200;
+}
+
+extend Foo {}
+extend pkg.Foo {
+  optional Foo foo = 100;
+}
+
+message Bar {
+  extend .pkg.Foo {
+    repeated Foo foos = 101;
+  }
+}
+`,
+		rewrite: func(r *rewrite.Rewriter) error {
+			return r.RenameMessage("pkg.Foo", "Baz")
+		},
+		expected: `syntax = "proto2";
+package pkg;
+
+message Baz {
+  extensions 100 to 200;
+}
+
+extend Baz {}
+extend pkg.Baz {
+  optional Baz foo = 100;
+}
+
+message Bar {
+  extend .pkg.Baz {
+    repeated Baz foos = 101;
+  }
+}
+`,
+	},
+	{
+		name: "rename_nested_message",
+		input: `message Outer {
+  message Inner {}
+  Inner a = 1;
+}
+message Inner {}
+message Other {
+  Inner b = 1;
+  Outer.Inner c = 2;
+}
+`,
+		rewrite: func(r *rewrite.Rewriter) error {
+			return r.RenameMessage("Outer.Inner", "Nested")
+		},
+		expected: `message Outer {
+  message Nested {}
+  Nested a = 1;
+}
+message Inner {}
+message Other {
+  Inner b = 1;
+  Outer.Nested c = 2;
+}
+`,
+	},
+	{
+		name: "rename_enum",
+		input: `enum Kind { KIND_UNSPECIFIED = 0; }
+message M { Kind k = 1; }
+`,
+		rewrite: func(r *rewrite.Rewriter) error {
+			return r.RenameEnum("Kind", "Type")
+		},
+		expected: `enum Type { KIND_UNSPECIFIED = 0; }
+message M { Type k = 1; }
+`,
+	},
+	{
+		name: "renumber_field",
+		input: `message M {
+  oneof o {
+    string a = 1;
+  }
+  int32 b = 2 [deprecated = true];
+}
+`,
+		rewrite: func(r *rewrite.Rewriter) error {
+			if err := r.RenumberField("M", "a", 10); err != nil {
+				return err
+			}
+			return r.RenumberField("M", "b", 20)
+		},
+		expected: `message M {
+  oneof o {
+    string a = 10;
+  }
+  int32 b = 20 [deprecated = true];
+}
+`,
+	},
+	{
+		name: "add_file_option",
+		input: `syntax = "proto3"; // trailing comment
+package pkg;
+
+message M {}
+`,
+		rewrite: func(r *rewrite.Rewriter) error {
+			return r.AddOption("", "go_package", `"example.com/pkg"`)
+		},
+		expected: `syntax = "proto3"; // trailing comment
+package pkg;
+option go_package = "example.com/pkg";
+
+message M {}
+`,
+	},
+	{
+		name:  "add_file_option_no_header",
+		input: `message M {}`,
+		rewrite: func(r *rewrite.Rewriter) error {
+			return r.AddOption("", "java_multiple_files", "true")
+		},
+		expected: `option java_multiple_files = true;
+message M {}`,
+	},
+	{
+		name: "add_message_option",
+		input: `message M {
+    int32 a = 1;
+}
+message Empty {}
+message Inline { int32 a = 1; }
+`,
+		rewrite: func(r *rewrite.Rewriter) error {
+			for _, name := range []string{"M", "Empty", "Inline"} {
+				if err := r.AddOption(name, "deprecated", "true"); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		expected: `message M {
+    option deprecated = true;
+    int32 a = 1;
+}
+message Empty {
+  option deprecated = true;
+}
+message Inline { option deprecated = true; int32 a = 1; }
+`,
+	},
+	{
+		name: "add_field_option",
+		input: `message M {
+  int32 a = 1;
+  int32 b = 2 [packed = true];
+}
+`,
+		rewrite: func(r *rewrite.Rewriter) error {
+			if err := r.AddOption("M.a", "deprecated", "true"); err != nil {
+				return err
+			}
+			return r.AddOption("M.b", "deprecated", "true")
+		},
+		expected: `message M {
+  int32 a = 1 [deprecated = true];
+  int32 b = 2 [packed = true, deprecated = true];
+}
+`,
+	},
+	{
+		name: "add_enum_and_service_option",
+		input: `enum E {
+  A = 0;
+}
+service S {
+  rpc Get (E) returns (E);
+}
+`,
+		rewrite: func(r *rewrite.Rewriter) error {
+			if err := r.AddOption("E", "allow_alias", "true"); err != nil {
+				return err
+			}
+			return r.AddOption("S", "deprecated", "true")
+		},
+		expected: `enum E {
+  option allow_alias = true;
+  A = 0;
+}
+service S {
+  option deprecated = true;
+  rpc Get (E) returns (E);
+}
+`,
+	},
+	{
+		name: "insert_field",
+		input: `message M {
+  int32 a = 1; // last
+}
+message Empty {}
+message Inline { int32 a = 1; }
+`,
+		rewrite: func(r *rewrite.Rewriter) error {
+			for _, name := range []string{"M", "Empty", "Inline"} {
+				if err := r.InsertField(name, "string b = 2;"); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		expected: `message M {
+  int32 a = 1; // last
+  string b = 2;
+}
+message Empty {
+  string b = 2;
+}
+message Inline { int32 a = 1; string b = 2; }
+`,
+	},
+	{
+		name: "delete_rpc",
+		input: `service S {
+  // detached comment
+
+  // Get comment
+  rpc Get (M) returns (M);
+  // List comment
+  /* on two
+     lines */
+  rpc List (M) returns (M) {
+    option deprecated = true;
+  }
+  rpc Put (M) returns (M) {} // Put comment
+  rpc Delete (M) returns (M) {}
+}
+service Inline { rpc A (M) returns (M); rpc B (M) returns (M); }
+`,
+		rewrite: func(r *rewrite.Rewriter) error {
+			for _, rpc := range []string{"Get", "List", "Delete"} {
+				if err := r.DeleteRPC("S", rpc); err != nil {
+					return err
+				}
+			}
+			return r.DeleteRPC("Inline", "A")
+		},
+		expected: `service S {
+  // detached comment
+
+  rpc Put (M) returns (M) {} // Put comment
+}
+service Inline { rpc B (M) returns (M); }
+`,
+	},
+}
+
+func TestRewrite(t *testing.T) {
+	for _, test := range rewriteTests {
+		t.Run(test.name, func(t *testing.T) {
+			r := rewrite.New(asttest.NewFile(t, test.input))
+			if err := test.rewrite(r); err != nil {
+				t.Fatal(err)
+			}
+
+			out, err := r.Bytes()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(test.expected, string(out)); diff != "" {
+				t.Errorf("%s mismatch (-want +got):\n%s", t.Name(), diff)
+			}
+		})
+	}
+}
+
+func TestRewriteNotFound(t *testing.T) {
+	r := rewrite.New(asttest.NewFile(t, "message M { int32 a = 1; }\nservice S {}"))
+
+	errs := []error{
+		r.RenameMessage("N", "O"),
+		r.RenameEnum("M", "O"),
+		r.RenumberField("M", "b", 2),
+		r.AddOption("M.b", "deprecated", "true"),
+		r.DeleteRPC("S", "Get"),
+	}
+	for i, err := range errs {
+		if err == nil {
+			t.Errorf("expected error for operation %d", i)
+		}
+	}
+
+	if len(r.Edits()) != 0 {
+		t.Fatalf("expected no edits, got %v", r.Edits())
+	}
+}
+
+func TestApplyOverlap(t *testing.T) {
+	_, err := rewrite.Apply([]byte("message M {}"), []rewrite.Edit{
+		{Start: 0, End: 7, NewText: "enum"},
+		{Start: 5, End: 9, NewText: "x"},
+	})
+	if err == nil {
+		t.Fatal("expected overlap error")
+	}
+}
@@ -0,0 +1,163 @@
+package source
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+type Encoding uint8
+
+const (
+	EncodingUTF8 Encoding = iota
+	EncodingUTF8BOM
+	EncodingUTF16LE
+	EncodingUTF16BE
+)
+
+func (e Encoding) String() string {
+	switch e {
+	case EncodingUTF8BOM:
+		return "UTF-8 with BOM"
+	case EncodingUTF16LE:
+		return "UTF-16LE"
+	case EncodingUTF16BE:
+		return "UTF-16BE"
+	}
+	return "UTF-8"
+}
+
+var (
+	bomUTF8    = []byte{0xEF, 0xBB, 0xBF}
+	bomUTF16LE = []byte{0xFF, 0xFE}
+	bomUTF16BE = []byte{0xFE, 0xFF}
+)
+
+var errOddUTF16 = errors.New("invalid UTF-16 content: odd number of bytes")
+
+// InvalidUTF8Error is a sequence of bytes starting at Offset
+// which is not valid UTF-8.
+type InvalidUTF8Error struct {
+	Offset uint32
+	Len    uint32
+}
+
+func (e *InvalidUTF8Error) Error() string {
+	return fmt.Sprintf("invalid UTF-8 at offset %d", e.Offset)
+}
+
+func detectEncoding(data []byte) Encoding {
+	switch {
+	case bytes.HasPrefix(data, bomUTF8):
+		return EncodingUTF8BOM
+	case bytes.HasPrefix(data, bomUTF16LE):
+		return EncodingUTF16LE
+	case bytes.HasPrefix(data, bomUTF16BE):
+		return EncodingUTF16BE
+	}
+	return EncodingUTF8
+}
+
+// transcode converts UTF-16 content (without BOM) into UTF-8.
+func transcode(data []byte, enc Encoding) ([]byte, error) {
+	if len(data)%2 != 0 {
+		return nil, errOddUTF16
+	}
+
+	units := make([]uint16, len(data)/2)
+	for i := range units {
+		lo, hi := data[2*i], data[2*i+1]
+		if enc == EncodingUTF16BE {
+			lo, hi = hi, lo
+		}
+		units[i] = uint16(lo) | uint16(hi)<<8
+	}
+
+	out := make([]byte, 0, len(data)/2)
+	for _, r := range utf16.Decode(units) {
+		out = utf8.AppendRune(out, r)
+	}
+	return out, nil
+}
+
+// Encode converts the UTF-8 content data, as returned by Bytes, back
+// into e. The UTF-16 content starts with its BOM.
+func (e Encoding) Encode(data []byte) []byte {
+	var out []byte
+	switch e {
+	case EncodingUTF16LE:
+		out = slices.Clone(bomUTF16LE)
+	case EncodingUTF16BE:
+		out = slices.Clone(bomUTF16BE)
+	default:
+		return data
+	}
+
+	for _, u := range utf16.Encode([]rune(string(data))) {
+		if e == EncodingUTF16BE {
+			out = append(out, byte(u>>8), byte(u))
+		} else {
+			out = append(out, byte(u), byte(u>>8))
+		}
+	}
+	return out
+}
+
+// normalize detects the encoding of the buffer and transcodes
+// UTF-16 content into UTF-8 (without BOM).
+func (b *Buffer) normalize() error {
+	b.encoding = detectEncoding(b.data)
+	if b.encoding != EncodingUTF16LE && b.encoding != EncodingUTF16BE {
+		return nil
+	}
+
+	data, err := transcode(b.data[2:], b.encoding)
+	// the original content is not needed anymore
+	if cerr := b.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = checkSize(int64(len(data)))
+	}
+	if err != nil {
+		return err
+	}
+
+	b.data = data
+	return nil
+}
+
+// Encoding returns the encoding in which the source was written.
+// UTF-16 sources are transcoded, the offsets are in the UTF-8 content.
+func (b *Buffer) Encoding() Encoding {
+	return b.encoding
+}
+
+// Validate reports the invalid UTF-8 sequences of the buffer.
+func (b *Buffer) Validate() []error {
+	var errs []error
+	var last *InvalidUTF8Error
+
+	data := b.data
+	for i := 0; i < len(data); {
+		if data[i] < utf8.RuneSelf {
+			i++
+			continue
+		}
+
+		r, size := utf8.DecodeRune(data[i:])
+		if r == utf8.RuneError && size == 1 {
+			if last != nil && last.Offset+last.Len == uint32(i) {
+				last.Len++
+			} else {
+				last = &InvalidUTF8Error{Offset: uint32(i), Len: 1}
+				errs = append(errs, last)
+			}
+		}
+		i += size
+	}
+	return errs
+}
@@ -0,0 +1,138 @@
+package source_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/Clement-Jean/protein/source"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func utf16Bytes(s string, bigEndian bool) []byte {
+	var b []byte
+	if bigEndian {
+		b = []byte{0xFE, 0xFF}
+	} else {
+		b = []byte{0xFF, 0xFE}
+	}
+
+	for _, u := range utf16.Encode([]rune(s)) {
+		if bigEndian {
+			b = append(b, byte(u>>8), byte(u))
+		} else {
+			b = append(b, byte(u), byte(u>>8))
+		}
+	}
+	return b
+}
+
+func TestEncoding(t *testing.T) {
+	const content = "syntax = \"proto3\"; // é 😀\r\n"
+
+	tests := []struct {
+		name     string
+		input    []byte
+		encoding source.Encoding
+		expected string
+	}{
+		{"utf8", []byte(content), source.EncodingUTF8, content},
+		{"utf8_bom", append([]byte{0xEF, 0xBB, 0xBF}, content...), source.EncodingUTF8BOM, "\xEF\xBB\xBF" + content},
+		{"utf16le", utf16Bytes(content, false), source.EncodingUTF16LE, content},
+		{"utf16be", utf16Bytes(content, true), source.EncodingUTF16BE, content},
+		{"utf16_empty", []byte{0xFF, 0xFE}, source.EncodingUTF16LE, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b, err := source.NewFromReader(bytes.NewReader(test.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if b.Encoding() != test.encoding {
+				t.Errorf("expected %s, got %s", test.encoding, b.Encoding())
+			}
+			if diff := cmp.Diff(test.expected, string(b.Bytes())); diff != "" {
+				t.Errorf("%s mismatch (-want +got):\n%s", t.Name(), diff)
+			}
+		})
+	}
+}
+
+func TestEncode(t *testing.T) {
+	const content = "syntax = \"proto3\"; // é 😀\r\n"
+
+	tests := []struct {
+		name  string
+		input []byte
+	}{
+		{"utf8", []byte(content)},
+		{"utf8_bom", append([]byte{0xEF, 0xBB, 0xBF}, content...)},
+		{"utf16le", utf16Bytes(content, false)},
+		{"utf16be", utf16Bytes(content, true)},
+		{"utf16_empty", []byte{0xFF, 0xFE}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b, err := source.NewFromReader(bytes.NewReader(test.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(test.input, b.Encoding().Encode(b.Bytes())); diff != "" {
+				t.Errorf("%s mismatch (-want +got):\n%s", t.Name(), diff)
+			}
+		})
+	}
+}
+
+func TestEncodingOddUTF16(t *testing.T) {
+	if _, err := source.NewFromReader(bytes.NewReader([]byte{0xFF, 0xFE, 'a'})); err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestEncodingMmaped(t *testing.T) {
+	content := strings.Repeat("message A {}\n", os.Getpagesize())
+	filename := filepath.Join(t.TempDir(), "test.proto")
+	if err := os.WriteFile(filename, utf16Bytes(content, false), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := source.NewFromFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Close()
+
+	// the transcoded content is on the heap
+	if b.Mmaped() {
+		t.Error("expected a heap buffer")
+	}
+	if string(b.Bytes()) != content {
+		t.Error("content mismatch")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	b, err := source.NewFromReader(strings.NewReader("a\xffb é \xc3\x28 \xe2\x82\x28\x28\xfe\xfe"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []error{
+		&source.InvalidUTF8Error{Offset: 1, Len: 1},
+		&source.InvalidUTF8Error{Offset: 7, Len: 1},
+		&source.InvalidUTF8Error{Offset: 10, Len: 2},
+		&source.InvalidUTF8Error{Offset: 14, Len: 2},
+	}
+	if diff := cmp.Diff(expected, b.Validate()); diff != "" {
+		t.Errorf("Validate mismatch (-want +got):\n%s", diff)
+	}
+}
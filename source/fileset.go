@@ -0,0 +1,199 @@
+package source
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+)
+
+// Pos is a position in a FileSet. It identifies both a file and an
+// offset in that file.
+type Pos uint32
+
+// NoPos is the zero Pos. It is not part of any file.
+const NoPos Pos = 0
+
+func (p Pos) IsValid() bool {
+	return p != NoPos
+}
+
+// Position is the human readable form of a Pos. Line and Column
+// are 1-based and columns are in bytes.
+type Position struct {
+	Filename string
+	Offset   uint32
+	Line     int
+	Column   int
+}
+
+func (p Position) IsValid() bool {
+	return p.Line > 0
+}
+
+// String returns file:line:column, line:column when there is no
+// filename, or - when the position is not valid.
+func (p Position) String() string {
+	if !p.IsValid() {
+		return "-"
+	}
+	if p.Filename == "" {
+		return fmt.Sprintf("%d:%d", p.Line, p.Column)
+	}
+	return fmt.Sprintf("%s:%d:%d", p.Filename, p.Line, p.Column)
+}
+
+// File is a file registered in a FileSet. Its positions go from Base
+// to Base+Size, the end of file included.
+type File struct {
+	name  string
+	base  uint32
+	size  uint32
+	lines []uint32 // offsets of the line starts
+}
+
+func (f *File) Name() string {
+	return f.name
+}
+
+func (f *File) Base() Pos {
+	return Pos(f.base)
+}
+
+func (f *File) Size() uint32 {
+	return f.size
+}
+
+func (f *File) LineCount() int {
+	return len(f.lines)
+}
+
+// Pos returns the Pos of offset. It panics if offset is out of
+// the file.
+func (f *File) Pos(offset uint32) Pos {
+	if offset > f.size {
+		panic(fmt.Sprintf("offset %d out of %s (size %d)", offset, f.name, f.size))
+	}
+	return Pos(f.base + offset)
+}
+
+// Offset returns the offset of pos. It panics if pos is not in
+// the file.
+func (f *File) Offset(pos Pos) uint32 {
+	if uint32(pos) < f.base || uint32(pos) > f.base+f.size {
+		panic(fmt.Sprintf("pos %d out of %s", pos, f.name))
+	}
+	return uint32(pos) - f.base
+}
+
+// Position returns the line and column of pos.
+func (f *File) Position(pos Pos) Position {
+	offset := f.Offset(pos)
+	line, found := slices.BinarySearch(f.lines, offset)
+	if !found {
+		line--
+	}
+
+	return Position{
+		Filename: f.name,
+		Offset:   offset,
+		Line:     line + 1,
+		Column:   int(offset-f.lines[line]) + 1,
+	}
+}
+
+// FileSet gives each of its files a range of positions so that a
+// single Pos identifies a file and an offset. A FileSet is safe for
+// concurrent use.
+type FileSet struct {
+	mu    sync.RWMutex
+	base  uint32
+	files []*File // sorted by base
+	last  *File   // cache for the consecutive lookups in a file
+}
+
+func NewFileSet() *FileSet {
+	return &FileSet{base: 1} // 0 is NoPos
+}
+
+// AddFile registers src under name. The line starts are computed
+// from the content so the buffer can be closed afterwards. It fails
+// when the positions of the set cannot cover the file.
+func (s *FileSet) AddFile(name string, src *Buffer) (*File, error) {
+	lines := []uint32{0}
+	data := src.Bytes()
+	for offset := 0; ; {
+		idx := bytes.IndexByte(data[offset:], '\n')
+		if idx == -1 {
+			break
+		}
+		offset += idx + 1
+		lines = append(lines, uint32(offset))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f := &File{name: name, base: s.base, size: src.Len(), lines: lines}
+	// +1 for the end of file position
+	if uint64(s.base)+uint64(f.size)+1 > math.MaxUint32 {
+		return nil, fmt.Errorf("%s: the file set has no positions left for %d bytes", name, f.size)
+	}
+	s.base += f.size + 1
+	s.files = append(s.files, f)
+	return f, nil
+}
+
+// File returns the file containing pos or nil if there is none.
+func (s *FileSet) File(pos Pos) *File {
+	if !pos.IsValid() {
+		return nil
+	}
+
+	s.mu.RLock()
+	f := s.last
+	s.mu.RUnlock()
+	if f != nil && uint32(pos) >= f.base && uint32(pos) <= f.base+f.size {
+		return f
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	idx, found := slices.BinarySearchFunc(s.files, uint32(pos), func(f *File, pos uint32) int {
+		if f.base < pos {
+			return -1
+		} else if f.base > pos {
+			return 1
+		}
+		return 0
+	})
+	if !found {
+		idx--
+	}
+	if idx < 0 || uint32(pos) > s.files[idx].base+s.files[idx].size {
+		return nil
+	}
+
+	s.last = s.files[idx]
+	return s.last
+}
+
+// Position returns the filename, line and column of pos. The result
+// is not valid if pos is not in the set.
+func (s *FileSet) Position(pos Pos) Position {
+	f := s.File(pos)
+	if f == nil {
+		return Position{}
+	}
+	return f.Position(pos)
+}
+
+// Files returns the files in the order they were added.
+func (s *FileSet) Files() []*File {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return slices.Clone(s.files)
+}
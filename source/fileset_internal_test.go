@@ -0,0 +1,26 @@
+package source
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestFileSetOverflow(t *testing.T) {
+	src, err := NewFromReader(strings.NewReader("message M {}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fset := NewFileSet()
+	fset.base = math.MaxUint32 - src.Len() - 1
+	if _, err := fset.AddFile("a.proto", src); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fset.AddFile("b.proto", src); err == nil {
+		t.Fatal("expected an error, the positions wrap around")
+	}
+	if len(fset.Files()) != 1 {
+		t.Fatalf("expected 1 file, got %d", len(fset.Files()))
+	}
+}
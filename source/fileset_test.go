@@ -0,0 +1,97 @@
+package source_test
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Clement-Jean/protein/source"
+)
+
+func newBuffer(t *testing.T, content string) *source.Buffer {
+	t.Helper()
+
+	b, err := source.NewFromReader(strings.NewReader(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func addFile(t *testing.T, fset *source.FileSet, name, content string) *source.File {
+	t.Helper()
+
+	f, err := fset.AddFile(name, newBuffer(t, content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func TestFileSet(t *testing.T) {
+	fset := source.NewFileSet()
+	a := addFile(t, fset, "a.proto", "syntax = \"proto3\";\r\nmessage A {}\n")
+	empty := addFile(t, fset, "empty.proto", "")
+	b := addFile(t, fset, "b.proto", "\n\nmessage B {}")
+
+	tests := []struct {
+		pos      source.Pos
+		expected string
+	}{
+		{source.NoPos, "-"},
+		{a.Pos(0), "a.proto:1:1"},
+		{a.Pos(18), "a.proto:1:19"},
+		{a.Pos(20), "a.proto:2:1"},
+		{a.Pos(28), "a.proto:2:9"},
+		{a.Pos(a.Size()), "a.proto:3:1"},
+		{empty.Pos(0), "empty.proto:1:1"},
+		{b.Pos(1), "b.proto:2:1"},
+		{b.Pos(10), "b.proto:3:9"},
+		{b.Pos(b.Size()) + 1, "-"},
+	}
+
+	for _, test := range tests {
+		if got := fset.Position(test.pos).String(); got != test.expected {
+			t.Errorf("pos %d: expected %s, got %s", test.pos, test.expected, got)
+		}
+	}
+
+	if f := fset.File(b.Pos(3)); f != b {
+		t.Errorf("expected b.proto, got %v", f)
+	}
+	if offset := b.Offset(b.Pos(3)); offset != 3 {
+		t.Errorf("expected offset 3, got %d", offset)
+	}
+	if a.LineCount() != 3 || b.LineCount() != 3 {
+		t.Errorf("expected 3 lines, got %d and %d", a.LineCount(), b.LineCount())
+	}
+}
+
+func TestFileSetConcurrent(t *testing.T) {
+	fset := source.NewFileSet()
+
+	var wg sync.WaitGroup
+	files := make([]*source.File, 8)
+	for i := range files {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			f, err := fset.AddFile("f.proto", newBuffer(t, "message M {}\n"))
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			files[i] = f
+			for offset := range files[i].Size() {
+				if f := fset.File(files[i].Pos(offset)); f != files[i] {
+					t.Errorf("expected file %d", i)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(fset.Files()) != len(files) {
+		t.Errorf("expected %d files, got %d", len(files), len(fset.Files()))
+	}
+}
@@ -0,0 +1,13 @@
+//go:build !unix
+
+package source
+
+import "os"
+
+func mmap(f *os.File, size int) ([]byte, error) {
+	return nil, errMmapUnsupported
+}
+
+func munmap(data []byte) error {
+	return nil
+}
@@ -0,0 +1,75 @@
+package source
+
+import (
+	"bytes"
+	"io/fs"
+	"path"
+	"sync"
+	"time"
+)
+
+// Overlay is a file system whose files override the ones of an
+// underlying file system (e.g. the unsaved buffers of an editor).
+// It is safe for concurrent use.
+type Overlay struct {
+	base fs.FS
+
+	mu    sync.RWMutex
+	files map[string][]byte
+}
+
+func NewOverlay(base fs.FS) *Overlay {
+	return &Overlay{
+		base:  base,
+		files: make(map[string][]byte),
+	}
+}
+
+// Set overrides the content of the file called name. The content
+// must not be modified after.
+func (o *Overlay) Set(name string, content []byte) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.files[name] = content
+}
+
+// Delete removes the override of the file called name.
+func (o *Overlay) Delete(name string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	delete(o.files, name)
+}
+
+func (o *Overlay) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+
+	o.mu.RLock()
+	content, ok := o.files[name]
+	o.mu.RUnlock()
+
+	if ok {
+		return &overlayFile{Reader: bytes.NewReader(content), name: name, size: int64(len(content))}, nil
+	}
+	if o.base == nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return o.base.Open(name)
+}
+
+type overlayFile struct {
+	*bytes.Reader
+	name string
+	size int64
+}
+
+func (f *overlayFile) Stat() (fs.FileInfo, error) { return f, nil }
+func (f *overlayFile) Close() error               { return nil }
+
+func (f *overlayFile) Name() string       { return path.Base(f.name) }
+func (f *overlayFile) Size() int64        { return f.size }
+func (f *overlayFile) Mode() fs.FileMode  { return 0444 }
+func (f *overlayFile) ModTime() time.Time { return time.Time{} }
+func (f *overlayFile) IsDir() bool        { return false }
+func (f *overlayFile) Sys() any           { return nil }
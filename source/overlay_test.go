@@ -0,0 +1,59 @@
+package source_test
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/Clement-Jean/protein/source"
+)
+
+func TestOverlay(t *testing.T) {
+	overlay := source.NewOverlay(fstest.MapFS{
+		"a.proto":     {Data: []byte("disk a")},
+		"dir/b.proto": {Data: []byte("disk b")},
+	})
+	overlay.Set("dir/b.proto", []byte("overlay b"))
+	overlay.Set("c.proto", []byte("overlay c"))
+
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"a.proto", "disk a"},
+		{"dir/b.proto", "overlay b"},
+		{"c.proto", "overlay c"},
+	}
+
+	for _, test := range tests {
+		b, err := source.NewFromFS(overlay, test.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b.Bytes()) != test.expected {
+			t.Errorf("%s: expected %q, got %q", test.name, test.expected, b.Bytes())
+		}
+	}
+
+	overlay.Delete("dir/b.proto")
+	b, err := source.NewFromFS(overlay, "dir/b.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b.Bytes()) != "disk b" {
+		t.Errorf("expected the disk content after Delete, got %q", b.Bytes())
+	}
+
+	if _, err := source.NewFromFS(overlay, "missing.proto"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected %v, got %v", fs.ErrNotExist, err)
+	}
+
+	fi, err := fs.Stat(overlay, "c.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi.Name() != "c.proto" || fi.Size() != 9 {
+		t.Errorf("unexpected file info %s %d", fi.Name(), fi.Size())
+	}
+}
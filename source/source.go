@@ -1,20 +1,24 @@
 package source
 
 import (
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"math"
 	"os"
-	"unsafe"
-
-	"golang.org/x/exp/mmap"
 )
 
+// Buffer is the content of a source. Buffers created from files
+// can be memory mapped and must be closed to release the mapping.
 type Buffer struct {
-	data   []byte
-	mmaped bool
+	data     []byte
+	mmaped   bool
+	encoding Encoding
 }
 
+var errMmapUnsupported = errors.New("mmap is not supported")
+
 func shouldUseMmap(size int64) bool {
 	return size > 4*4096 || int(size) >= os.Getpagesize()
 }
@@ -26,31 +30,78 @@ func NewFromFile(filename string) (*Buffer, error) {
 	}
 	defer f.Close()
 
+	return newFromOSFile(f)
+}
+
+func newFromOSFile(f *os.File) (*Buffer, error) {
 	fi, err := f.Stat()
 	if err != nil {
 		return nil, err
 	}
 
 	size := fi.Size()
-	if size >= math.MaxUint32 {
-		return nil, fmt.Errorf("file is over the 2GiB input limit (%d bytes)", size)
+	if err := checkSize(size); err != nil {
+		return nil, err
 	}
 
 	if shouldUseMmap(size) {
-		m, err := mmap.Open(filename)
-		if err != nil {
+		data, err := mmap(f, int(size))
+		if err == nil {
+			return newBuffer(data, true)
+		}
+		if err != errMmapUnsupported {
 			return nil, err
 		}
-		sb := (*Buffer)(unsafe.Pointer(m))
-		sb.mmaped = true
-		return sb, nil
 	}
 
 	data, err := io.ReadAll(f)
 	if err != nil {
 		return nil, err
 	}
-	return &Buffer{data: data}, nil
+	return newBuffer(data, false)
+}
+
+func newBuffer(data []byte, mmaped bool) (*Buffer, error) {
+	b := &Buffer{data: data, mmaped: mmaped}
+	if err := b.normalize(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func checkSize(size int64) error {
+	if size >= math.MaxUint32 {
+		return fmt.Errorf("file is over the 2GiB input limit (%d bytes)", size)
+	}
+	return nil
+}
+
+// NewFromFS reads filename from fsys. The files of the operating
+// system (e.g. from os.DirFS) can be memory mapped.
+func NewFromFS(fsys fs.FS, filename string) (*Buffer, error) {
+	f, err := fsys.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if osFile, ok := f.(*os.File); ok {
+		return newFromOSFile(osFile)
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if err := checkSize(fi.Size()); err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil, err
+	}
+	return newBuffer(data, false)
 }
 
 func NewFromReader(r io.Reader) (*Buffer, error) {
@@ -58,7 +109,25 @@ func NewFromReader(r io.Reader) (*Buffer, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &Buffer{data: data}, nil
+	return newBuffer(data, false)
+}
+
+// Close releases the memory mapping of the buffer, if any. The buffer
+// and the slices returned by its methods must not be used after.
+func (b *Buffer) Close() error {
+	data := b.data
+	b.data = nil
+
+	if !b.mmaped {
+		return nil
+	}
+	b.mmaped = false
+	return munmap(data)
+}
+
+// Mmaped reports whether the content is memory mapped.
+func (b *Buffer) Mmaped() bool {
+	return b.mmaped
 }
 
 func (b *Buffer) Len() uint32 {
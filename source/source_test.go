@@ -0,0 +1,82 @@
+package source_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/Clement-Jean/protein/source"
+)
+
+func TestNewFromFile(t *testing.T) {
+	tests := []struct {
+		name   string
+		size   int
+		mmaped bool
+	}{
+		{"empty", 0, false},
+		{"below_threshold", 100, false},
+		{"above_threshold", 8 * os.Getpagesize(), runtime.GOOS != "windows" && runtime.GOOS != "js" && runtime.GOOS != "wasip1"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			content := bytes.Repeat([]byte{'a'}, test.size)
+			dir := t.TempDir()
+			filename := filepath.Join(dir, "test.proto")
+			if err := os.WriteFile(filename, content, 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			fsBuffer, err := source.NewFromFS(os.DirFS(dir), "test.proto")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if fsBuffer.Mmaped() != test.mmaped || !bytes.Equal(fsBuffer.Bytes(), content) {
+				t.Errorf("expected the same buffer through os.DirFS")
+			}
+			fsBuffer.Close()
+
+			b, err := source.NewFromFile(filename)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if b.Mmaped() != test.mmaped {
+				t.Errorf("expected mmaped to be %t", test.mmaped)
+			}
+			if !bytes.Equal(b.Bytes(), content) {
+				t.Errorf("content mismatch")
+			}
+
+			if err := b.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if b.Mmaped() || b.Len() != 0 {
+				t.Errorf("expected the buffer to be released")
+			}
+
+			// closing twice is a no-op
+			if err := b.Close(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestNewFromReaderClose(t *testing.T) {
+	b, err := source.NewFromReader(strings.NewReader("syntax = \"proto3\";"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b.Mmaped() {
+		t.Error("expected a heap buffer")
+	}
+	if err := b.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,342 @@
+// Package symbols indexes the declarations of proto files by their
+// fully-qualified names.
+package symbols
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+
+	"github.com/Clement-Jean/protein/ast"
+)
+
+type Kind uint8
+
+const (
+	KindMessage Kind = iota
+	KindField
+	KindOneof
+	KindEnum
+	KindEnumValue
+	KindService
+	KindRPC
+	KindExtension
+)
+
+var kindNames = [...]string{
+	KindMessage:   "message",
+	KindField:     "field",
+	KindOneof:     "oneof",
+	KindEnum:      "enum",
+	KindEnumValue: "enum value",
+	KindService:   "service",
+	KindRPC:       "rpc",
+	KindExtension: "extension",
+}
+
+func (k Kind) String() string {
+	return kindNames[k]
+}
+
+// IsType reports whether the symbols of kind k can be field types.
+func (k Kind) IsType() bool {
+	return k == KindMessage || k == KindEnum
+}
+
+func (k Kind) isScope() bool {
+	return k == KindMessage || k == KindEnum || k == KindService
+}
+
+// Symbol is a declaration. Node is its root node in the tree of File
+// and Ident is the token naming it.
+type Symbol struct {
+	Name    string // fully-qualified without leading dot (e.g. pkg.Outer.Inner)
+	Package string
+	Kind    Kind
+	File    *ast.File
+	Node    int
+	Ident   ast.Token
+
+	// Decl is the declaration: *ast.Message, *ast.Field (for the
+	// fields and the extensions), *ast.Oneof, *ast.Enum,
+	// *ast.EnumValue, *ast.Service or *ast.RPC.
+	Decl any
+}
+
+// DuplicateError is a symbol defined more than once. Previous is
+// the first definition, or nil when the name is a package.
+type DuplicateError struct {
+	Symbol   *Symbol
+	Previous *Symbol
+}
+
+func (e *DuplicateError) Error() string {
+	switch {
+	case e.Previous == nil:
+		return fmt.Sprintf("%q is already defined as a package", e.Symbol.Name)
+	case e.Symbol.Name != e.Previous.Name:
+		// enum values are siblings of their enum (C++ scoping rules)
+		value := e.Symbol
+		if e.Previous.Kind == KindEnumValue && value.Kind != KindEnumValue {
+			value = e.Previous
+		}
+		return fmt.Sprintf(
+			"%q is already defined in %q, enum values are siblings of their enum and must be unique in its scope",
+			e.Symbol.Ident.Text, parentScope(parentScope(value.Name)),
+		)
+	}
+	return fmt.Sprintf("%q is already defined", e.Symbol.Name)
+}
+
+// Table maps the fully-qualified names to their declarations. Enum
+// values are named after their enum (e.g. pkg.Enum.VALUE).
+type Table struct {
+	symbols  map[string]*Symbol
+	siblings map[string]*Symbol  // enum values by name in the scope of their enum
+	packages map[string]struct{} // packages and their prefixes
+	order    []*Symbol
+}
+
+// New indexes the declarations of files. The symbols defined more
+// than once are reported as *DuplicateError, only their first
+// definition is in the table.
+func New(files ...*ast.File) (*Table, []error) {
+	t := &Table{
+		symbols:  make(map[string]*Symbol),
+		siblings: make(map[string]*Symbol),
+		packages: make(map[string]struct{}),
+	}
+
+	for _, file := range files {
+		if pkg := packageName(file); pkg != "" {
+			parts := strings.Split(pkg, ".")
+			for i := range parts {
+				t.packages[strings.Join(parts[:i+1], ".")] = struct{}{}
+			}
+		}
+	}
+
+	var errs []error
+	for _, file := range files {
+		b := builder{table: t, file: file, pkg: packageName(file)}
+		for _, msg := range file.Messages {
+			b.message(b.pkg, msg)
+		}
+		for _, enum := range file.Enums {
+			b.enum(b.pkg, enum)
+		}
+		for _, service := range file.Services {
+			b.service(service)
+		}
+		for _, extend := range file.Extends {
+			b.extend(b.pkg, extend)
+		}
+		errs = append(errs, b.errs...)
+	}
+	return t, errs
+}
+
+func packageName(file *ast.File) string {
+	if file.Package == nil {
+		return ""
+	}
+	return file.Package.Name.String()
+}
+
+func join(scope, name string) string {
+	if scope == "" {
+		return name
+	}
+	return scope + "." + name
+}
+
+func parentScope(scope string) string {
+	if idx := strings.LastIndexByte(scope, '.'); idx != -1 {
+		return scope[:idx]
+	}
+	return ""
+}
+
+type builder struct {
+	table *Table
+	file  *ast.File
+	pkg   string
+	errs  []error
+}
+
+func (b *builder) add(scope string, kind Kind, node int, ident ast.Token, decl any) (string, bool) {
+	if ident.Missing || ident.Text == "" {
+		return "", false
+	}
+
+	sym := &Symbol{
+		Name:    join(scope, ident.Text),
+		Package: b.pkg,
+		Kind:    kind,
+		File:    b.file,
+		Node:    node,
+		Ident:   ident,
+		Decl:    decl,
+	}
+
+	t := b.table
+	if _, ok := t.packages[sym.Name]; ok {
+		b.errs = append(b.errs, &DuplicateError{Symbol: sym})
+		return sym.Name, false
+	}
+	if prev, ok := t.symbols[sym.Name]; ok {
+		b.errs = append(b.errs, &DuplicateError{Symbol: sym, Previous: prev})
+		return sym.Name, false
+	}
+	if prev, ok := t.siblings[sym.Name]; ok {
+		b.errs = append(b.errs, &DuplicateError{Symbol: sym, Previous: prev})
+		return sym.Name, false
+	}
+
+	if kind == KindEnumValue {
+		sibling := join(parentScope(scope), ident.Text)
+		prev, ok := t.siblings[sibling]
+		if !ok {
+			prev, ok = t.symbols[sibling]
+		}
+		if ok {
+			b.errs = append(b.errs, &DuplicateError{Symbol: sym, Previous: prev})
+			return sym.Name, false
+		}
+		t.siblings[sibling] = sym
+	}
+
+	t.symbols[sym.Name] = sym
+	t.order = append(t.order, sym)
+	return sym.Name, true
+}
+
+func (b *builder) message(scope string, msg *ast.Message) {
+	name, ok := b.add(scope, KindMessage, msg.Node, msg.Name, msg)
+	if !ok {
+		// the members of a duplicate are not indexed either
+		return
+	}
+
+	for _, field := range msg.Fields {
+		b.add(name, KindField, field.Node, field.Name, field)
+	}
+	for _, oneof := range msg.Oneofs {
+		b.add(name, KindOneof, oneof.Node, oneof.Name, oneof)
+	}
+	for _, nested := range msg.Messages {
+		b.message(name, nested)
+	}
+	for _, enum := range msg.Enums {
+		b.enum(name, enum)
+	}
+	for _, extend := range msg.Extends {
+		b.extend(name, extend)
+	}
+}
+
+func (b *builder) enum(scope string, enum *ast.Enum) {
+	name, ok := b.add(scope, KindEnum, enum.Node, enum.Name, enum)
+	if !ok {
+		return
+	}
+
+	for _, value := range enum.Values {
+		b.add(name, KindEnumValue, value.Node, value.Name, value)
+	}
+}
+
+// extend adds the extensions of extend, they are in the scope of
+// the extend block and not of the extended message.
+func (b *builder) extend(scope string, extend *ast.Extend) {
+	for _, field := range extend.Fields {
+		b.add(scope, KindExtension, field.Node, field.Name, field)
+	}
+}
+
+func (b *builder) service(service *ast.Service) {
+	name, ok := b.add(b.pkg, KindService, service.Node, service.Name, service)
+	if !ok {
+		return
+	}
+
+	for _, rpc := range service.RPCs {
+		b.add(name, KindRPC, rpc.Node, rpc.Name, rpc)
+	}
+}
+
+// Lookup returns the symbol named name, with or without leading dot.
+func (t *Table) Lookup(name string) (*Symbol, bool) {
+	sym, ok := t.symbols[strings.TrimPrefix(name, ".")]
+	return sym, ok
+}
+
+// IsPackage reports whether name is a package or a prefix of one.
+func (t *Table) IsPackage(name string) bool {
+	_, ok := t.packages[strings.TrimPrefix(name, ".")]
+	return ok
+}
+
+// Symbols returns the symbols in the order of their declarations.
+func (t *Table) Symbols() iter.Seq[*Symbol] {
+	return func(yield func(*Symbol) bool) {
+		for _, sym := range t.order {
+			if !yield(sym) {
+				return
+			}
+		}
+	}
+}
+
+// Resolve returns the message or enum referenced by name in scope,
+// a fully-qualified name without leading dot.
+func (t *Table) Resolve(scope string, name ast.FullIdent) (*Symbol, bool) {
+	return t.resolve(scope, name, Kind.IsType)
+}
+
+// ResolveExtension returns the extension referenced by name in scope,
+// e.g. in the name of a custom option.
+func (t *Table) ResolveExtension(scope string, name ast.FullIdent) (*Symbol, bool) {
+	return t.resolve(scope, name, func(k Kind) bool { return k == KindExtension })
+}
+
+// resolve follows the protobuf scoping rules: the first part of a
+// relative name is looked up from the innermost scope outward and
+// the rest of the name must be found in the first scope defining it.
+// The first part of a qualified name must be a scope (a package, a
+// message, an enum or a service). The symbol found must be accepted.
+func (t *Table) resolve(scope string, name ast.FullIdent, accept func(Kind) bool) (*Symbol, bool) {
+	if len(name.Parts) == 0 {
+		return nil, false
+	}
+
+	full := name.String()
+	if name.Absolute {
+		return t.lookup(full[1:], accept)
+	}
+
+	first := name.Parts[0].Text
+	for {
+		candidate := join(scope, first)
+		sym, ok := t.symbols[candidate]
+		switch {
+		case len(name.Parts) == 1 && ok && accept(sym.Kind):
+			return sym, true
+		case len(name.Parts) > 1 && ((ok && sym.Kind.isScope()) || t.IsPackage(candidate)):
+			return t.lookup(join(scope, full), accept)
+		}
+
+		if scope == "" {
+			return nil, false
+		}
+		scope = parentScope(scope)
+	}
+}
+
+func (t *Table) lookup(name string, accept func(Kind) bool) (*Symbol, bool) {
+	sym, ok := t.symbols[name]
+	if !ok || !accept(sym.Kind) {
+		return nil, false
+	}
+	return sym, true
+}
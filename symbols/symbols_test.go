@@ -0,0 +1,182 @@
+package symbols_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/Clement-Jean/protein/ast"
+	"github.com/Clement-Jean/protein/internal/asttest"
+	"github.com/Clement-Jean/protein/symbols"
+)
+
+func TestSymbols(t *testing.T) {
+	a := asttest.NewFile(t, `package pkg.v1;
+message Outer {
+  message Inner {}
+  enum Kind { KIND_UNSPECIFIED = 0; }
+  int32 a = 1;
+  oneof choice { string b = 2; }
+}
+`)
+	b := asttest.NewFile(t, `package pkg.v1;
+enum Status { STATUS_UNSPECIFIED = 0; }
+service Svc { rpc Get (Outer) returns (Outer); }
+`)
+
+	table, errs := symbols.New(a, b)
+	if len(errs) != 0 {
+		t.Fatal(errs)
+	}
+
+	var got []string
+	for sym := range table.Symbols() {
+		got = append(got, fmt.Sprintf("%s %s", sym.Kind, sym.Name))
+	}
+
+	expected := []string{
+		"message pkg.v1.Outer",
+		"field pkg.v1.Outer.a",
+		"field pkg.v1.Outer.b",
+		"oneof pkg.v1.Outer.choice",
+		"message pkg.v1.Outer.Inner",
+		"enum pkg.v1.Outer.Kind",
+		"enum value pkg.v1.Outer.Kind.KIND_UNSPECIFIED",
+		"enum pkg.v1.Status",
+		"enum value pkg.v1.Status.STATUS_UNSPECIFIED",
+		"service pkg.v1.Svc",
+		"rpc pkg.v1.Svc.Get",
+	}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+
+	sym, ok := table.Lookup(".pkg.v1.Svc.Get")
+	if !ok || sym.File != b || sym.Package != "pkg.v1" || sym.Ident.Text != "Get" {
+		t.Errorf("expected the Get rpc in the second file, got %+v", sym)
+	}
+	if !table.IsPackage("pkg") || table.IsPackage("pkg.v1.Outer") {
+		t.Error("expected pkg to be a package and pkg.v1.Outer not to be")
+	}
+}
+
+func TestDuplicates(t *testing.T) {
+	tests := []struct {
+		name     string
+		inputs   []string
+		expected []string
+	}{
+		{
+			name: "across files",
+			inputs: []string{
+				`package pkg; message A {}`,
+				`package pkg; message A {} enum B { B_X = 0; }`,
+				`package other; message A {}`,
+			},
+			expected: []string{`"pkg.A" is already defined`},
+		},
+		{
+			name:     "members",
+			inputs:   []string{`message A { int32 a = 1; oneof a { int32 b = 2; } message b {} }`},
+			expected: []string{`"A.a" is already defined`, `"A.b" is already defined`},
+		},
+		{
+			name: "enum values in the same scope",
+			inputs: []string{`package pkg;
+enum A { UNKNOWN = 0; }
+enum B { UNKNOWN = 0; }
+message M {
+  enum C { UNKNOWN = 0; }
+}
+`},
+			expected: []string{
+				`"UNKNOWN" is already defined in "pkg", enum values are siblings of their enum and must be unique in its scope`,
+			},
+		},
+		{
+			name:   "enum value and declaration",
+			inputs: []string{`package pkg; enum A { B = 0; } message B {}`, `package pkg; enum C { A = 0; }`},
+			expected: []string{
+				`"B" is already defined in "pkg", enum values are siblings of their enum and must be unique in its scope`,
+				`"A" is already defined in "pkg", enum values are siblings of their enum and must be unique in its scope`,
+			},
+		},
+		{
+			name:     "package",
+			inputs:   []string{`package a.b;`, `package a; message b {}`},
+			expected: []string{`"a.b" is already defined as a package`},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var files []*ast.File
+			for _, input := range test.inputs {
+				files = append(files, asttest.NewFile(t, input))
+			}
+
+			_, errs := symbols.New(files...)
+			var got []string
+			for _, err := range errs {
+				got = append(got, err.Error())
+			}
+			if diff := cmp.Diff(test.expected, got); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	table, errs := symbols.New(
+		asttest.NewFile(t, `package pkg;
+message A { message B {} }
+message C {
+  message A {}
+  int32 D = 1;
+}
+message D {}
+`),
+		asttest.NewFile(t, `package other; enum E { V = 0; }`),
+	)
+	if len(errs) != 0 {
+		t.Fatal(errs)
+	}
+
+	ident := func(name string) ast.FullIdent {
+		var fi ast.FullIdent
+		fi.Absolute = strings.HasPrefix(name, ".")
+		for _, part := range strings.Split(strings.TrimPrefix(name, "."), ".") {
+			fi.Parts = append(fi.Parts, ast.Token{Text: part})
+		}
+		return fi
+	}
+
+	tests := []struct {
+		scope    string
+		name     string
+		expected string // empty when not found
+	}{
+		{"pkg.C", "A", "pkg.C.A"},
+		{"pkg.C", "A.B", ""}, // A is pkg.C.A
+		{"pkg.C", ".pkg.A.B", "pkg.A.B"},
+		{"pkg.C", "D", "pkg.D"}, // pkg.C.D is a field
+		{"pkg", "other.E", "other.E"},
+		{"pkg", "E", ""},
+		{"pkg", "other.E.V", ""}, // not a type
+	}
+
+	for _, test := range tests {
+		t.Run(test.scope+" "+test.name, func(t *testing.T) {
+			got := ""
+			if sym, ok := table.Resolve(test.scope, ident(test.name)); ok {
+				got = sym.Name
+			}
+			if got != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+// Package wellknown embeds the google/protobuf/*.proto files, the
+// well-known types and descriptor.proto, and the plugin.proto of
+// google/protobuf/compiler bundled with protoc.
+package wellknown
+
+import "embed"
+
+//go:generate sh -c "cd ../corpus && cp any.proto api.proto descriptor.proto duration.proto empty.proto field_mask.proto source_context.proto struct.proto timestamp.proto type.proto wrappers.proto ../wellknown/google/protobuf/ && cp plugin.proto ../wellknown/google/protobuf/compiler/"
+
+// FS contains the files under their import path (e.g.
+// google/protobuf/timestamp.proto).
+//
+//go:embed google/protobuf/*.proto google/protobuf/compiler/*.proto
+var FS embed.FS
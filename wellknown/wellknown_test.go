@@ -0,0 +1,60 @@
+package wellknown_test
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/Clement-Jean/protein/lexer"
+	"github.com/Clement-Jean/protein/parser"
+	"github.com/Clement-Jean/protein/source"
+	"github.com/Clement-Jean/protein/wellknown"
+)
+
+func TestFiles(t *testing.T) {
+	var files []string
+	for _, pattern := range []string{"google/protobuf/*.proto", "google/protobuf/compiler/*.proto"} {
+		matches, err := fs.Glob(wellknown.FS, pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, matches...)
+	}
+	if len(files) != 12 {
+		t.Fatalf("expected 12 files, got %d", len(files))
+	}
+
+	for _, file := range files {
+		t.Run(file, func(t *testing.T) {
+			embedded, err := fs.ReadFile(wellknown.FS, file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			corpus, err := os.ReadFile(path.Join("../corpus", path.Base(file)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(embedded, corpus) {
+				t.Error("out of date, run go generate")
+			}
+
+			src, err := source.NewFromFS(wellknown.FS, file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			l, err := lexer.NewFromSource(src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tb, errs := l.Lex()
+			if len(errs) != 0 {
+				t.Fatal(errs)
+			}
+			if _, errs := parser.New(tb).Parse(); len(errs) != 0 {
+				t.Fatal(errs)
+			}
+		})
+	}
+}
@@ -0,0 +1,253 @@
+// Package wire encodes and decodes the protobuf binary wire format.
+// It is the runtime of the Go code generated by the gogen package.
+//
+// The Consume functions return the number of bytes read, or a
+// negative number to be converted to an error with ParseError.
+package wire
+
+import (
+	"errors"
+	"math/bits"
+)
+
+// Number is a field number.
+type Number int32
+
+const (
+	MinValidNumber Number = 1
+	MaxValidNumber Number = 1<<29 - 1
+)
+
+// Type is the wire type of a field.
+type Type int8
+
+const (
+	VarintType     Type = 0
+	Fixed64Type    Type = 1
+	BytesType      Type = 2
+	StartGroupType Type = 3
+	EndGroupType   Type = 4
+	Fixed32Type    Type = 5
+)
+
+const (
+	errCodeTruncated = -(iota + 1)
+	errCodeOverflow
+	errCodeFieldNumber
+	errCodeReserved
+	errCodeEndGroup
+)
+
+var (
+	ErrTruncated   = errors.New("unexpected end of input")
+	ErrOverflow    = errors.New("variable length integer overflow")
+	ErrFieldNumber = errors.New("invalid field number")
+	ErrReserved    = errors.New("invalid wire type")
+	ErrEndGroup    = errors.New("mismatching end group marker")
+)
+
+// ParseError converts the negative number returned by a Consume
+// function to an error.
+func ParseError(n int) error {
+	switch n {
+	case errCodeTruncated:
+		return ErrTruncated
+	case errCodeOverflow:
+		return ErrOverflow
+	case errCodeFieldNumber:
+		return ErrFieldNumber
+	case errCodeReserved:
+		return ErrReserved
+	case errCodeEndGroup:
+		return ErrEndGroup
+	}
+	return nil
+}
+
+func AppendTag(b []byte, num Number, typ Type) []byte {
+	return AppendVarint(b, uint64(num)<<3|uint64(typ&7))
+}
+
+func AppendVarint(b []byte, v uint64) []byte {
+	for v >= 0x80 {
+		b = append(b, byte(v)|0x80)
+		v >>= 7
+	}
+	return append(b, byte(v))
+}
+
+func AppendFixed32(b []byte, v uint32) []byte {
+	return append(b, byte(v), byte(v>>8), byte(v>>16), byte(v>>24))
+}
+
+func AppendFixed64(b []byte, v uint64) []byte {
+	return append(b,
+		byte(v), byte(v>>8), byte(v>>16), byte(v>>24),
+		byte(v>>32), byte(v>>40), byte(v>>48), byte(v>>56),
+	)
+}
+
+func AppendBytes(b []byte, v []byte) []byte {
+	return append(AppendVarint(b, uint64(len(v))), v...)
+}
+
+func AppendString(b []byte, v string) []byte {
+	return append(AppendVarint(b, uint64(len(v))), v...)
+}
+
+// Marshaler is implemented by the generated messages. MarshalAppend
+// appends the encoding of the message to b.
+type Marshaler interface {
+	MarshalAppend(b []byte) []byte
+}
+
+// AppendMessage appends the length-prefixed encoding of m.
+func AppendMessage(b []byte, m Marshaler) []byte {
+	start := len(b)
+	b = m.MarshalAppend(b)
+	return InsertLength(b, start)
+}
+
+// InsertLength inserts the length of b[start:] at start, as a
+// varint. This lets the callers encode a length-prefixed value
+// before knowing its size.
+func InsertLength(b []byte, start int) []byte {
+	n := len(b) - start
+	size := SizeVarint(uint64(n))
+	for range size {
+		b = append(b, 0)
+	}
+	copy(b[start+size:], b[start:start+n])
+	AppendVarint(b[:start], uint64(n))
+	return b
+}
+
+// SizeVarint returns the size of the encoding of v.
+func SizeVarint(v uint64) int {
+	// 1 byte per 7 bits, at least 1 byte
+	return int(9*uint32(bits.Len64(v))+64) / 64
+}
+
+// EncodeZigZag maps the signed integers to unsigned integers with
+// small encodings for small absolute values (sint32 and sint64).
+func EncodeZigZag(v int64) uint64 {
+	return uint64(v<<1) ^ uint64(v>>63)
+}
+
+func DecodeZigZag(v uint64) int64 {
+	return int64(v>>1) ^ int64(v)<<63>>63
+}
+
+func EncodeBool(v bool) uint64 {
+	if v {
+		return 1
+	}
+	return 0
+}
+
+func DecodeBool(v uint64) bool {
+	return v != 0
+}
+
+func ConsumeTag(b []byte) (Number, Type, int) {
+	v, n := ConsumeVarint(b)
+	if n < 0 {
+		return 0, 0, n
+	}
+	num, typ := Number(v>>3), Type(v&7)
+	if v>>3 > uint64(MaxValidNumber) || num < MinValidNumber {
+		return 0, 0, errCodeFieldNumber
+	}
+	return num, typ, n
+}
+
+func ConsumeVarint(b []byte) (uint64, int) {
+	var v uint64
+	for i := 0; i < len(b); i++ {
+		if i == 10 {
+			return 0, errCodeOverflow
+		}
+		c := b[i]
+		if i == 9 && c > 1 {
+			return 0, errCodeOverflow
+		}
+		v |= uint64(c&0x7f) << (7 * i)
+		if c < 0x80 {
+			return v, i + 1
+		}
+	}
+	return 0, errCodeTruncated
+}
+
+func ConsumeFixed32(b []byte) (uint32, int) {
+	if len(b) < 4 {
+		return 0, errCodeTruncated
+	}
+	return uint32(b[0]) | uint32(b[1])<<8 | uint32(b[2])<<16 | uint32(b[3])<<24, 4
+}
+
+func ConsumeFixed64(b []byte) (uint64, int) {
+	if len(b) < 8 {
+		return 0, errCodeTruncated
+	}
+	lo, _ := ConsumeFixed32(b)
+	hi, _ := ConsumeFixed32(b[4:])
+	return uint64(lo) | uint64(hi)<<32, 8
+}
+
+// ConsumeBytes returns the length-prefixed value at the start of b,
+// it aliases b.
+func ConsumeBytes(b []byte) ([]byte, int) {
+	size, n := ConsumeVarint(b)
+	if n < 0 {
+		return nil, n
+	}
+	if size > uint64(len(b)-n) {
+		return nil, errCodeTruncated
+	}
+	return b[n : n+int(size)], n + int(size)
+}
+
+// ConsumeFieldValue returns the size of the value of the field num,
+// whose tag was just consumed. Groups are consumed up to their end
+// group marker.
+func ConsumeFieldValue(num Number, typ Type, b []byte) int {
+	switch typ {
+	case VarintType:
+		_, n := ConsumeVarint(b)
+		return n
+	case Fixed32Type:
+		_, n := ConsumeFixed32(b)
+		return n
+	case Fixed64Type:
+		_, n := ConsumeFixed64(b)
+		return n
+	case BytesType:
+		_, n := ConsumeBytes(b)
+		return n
+	case StartGroupType:
+		total := 0
+		for {
+			gnum, gtyp, n := ConsumeTag(b[total:])
+			if n < 0 {
+				return n
+			}
+			total += n
+			if gtyp == EndGroupType {
+				if gnum != num {
+					return errCodeEndGroup
+				}
+				return total
+			}
+
+			n = ConsumeFieldValue(gnum, gtyp, b[total:])
+			if n < 0 {
+				return n
+			}
+			total += n
+		}
+	case EndGroupType:
+		return errCodeEndGroup
+	}
+	return errCodeReserved
+}
@@ -0,0 +1,136 @@
+package wire_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/Clement-Jean/protein/wire"
+)
+
+func TestVarint(t *testing.T) {
+	tests := []struct {
+		v       uint64
+		encoded []byte
+	}{
+		{0, []byte{0x00}},
+		{1, []byte{0x01}},
+		{127, []byte{0x7f}},
+		{128, []byte{0x80, 0x01}},
+		{300, []byte{0xac, 0x02}},
+		{math.MaxUint64, []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01}},
+	}
+
+	for _, test := range tests {
+		b := wire.AppendVarint(nil, test.v)
+		if diff := cmp.Diff(test.encoded, b); diff != "" {
+			t.Errorf("%d (-want +got):\n%s", test.v, diff)
+		}
+		if size := wire.SizeVarint(test.v); size != len(b) {
+			t.Errorf("expected size %d for %d, got %d", len(b), test.v, size)
+		}
+
+		v, n := wire.ConsumeVarint(b)
+		if v != test.v || n != len(b) {
+			t.Errorf("expected %d (%d bytes), got %d (%d bytes)", test.v, len(b), v, n)
+		}
+	}
+}
+
+func TestConsumeErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		consume  func() int
+		expected error
+	}{
+		{"truncated varint", func() int { _, n := wire.ConsumeVarint([]byte{0x80}); return n }, wire.ErrTruncated},
+		{"overflow", func() int {
+			_, n := wire.ConsumeVarint([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x02})
+			return n
+		}, wire.ErrOverflow},
+		{"truncated fixed32", func() int { _, n := wire.ConsumeFixed32([]byte{1, 2}); return n }, wire.ErrTruncated},
+		{"truncated bytes", func() int { _, n := wire.ConsumeBytes([]byte{0x03, 'a'}); return n }, wire.ErrTruncated},
+		{"field number", func() int { _, _, n := wire.ConsumeTag([]byte{0x02}); return n }, wire.ErrFieldNumber},
+		{"reserved type", func() int { return wire.ConsumeFieldValue(1, 6, nil) }, wire.ErrReserved},
+		{"end group", func() int {
+			// group 1 closed by the end of group 2
+			return wire.ConsumeFieldValue(1, wire.StartGroupType, []byte{0x08, 0x01, 0x14})
+		}, wire.ErrEndGroup},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			n := test.consume()
+			if n >= 0 {
+				t.Fatalf("expected an error, got %d", n)
+			}
+			if err := wire.ParseError(n); err != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, err)
+			}
+		})
+	}
+}
+
+func TestConsumeFieldValue(t *testing.T) {
+	var b []byte
+	b = wire.AppendTag(b, 2, wire.VarintType)
+	b = wire.AppendVarint(b, 300)
+	b = wire.AppendTag(b, 3, wire.Fixed64Type)
+	b = wire.AppendFixed64(b, 1)
+	b = wire.AppendTag(b, 4, wire.BytesType)
+	b = wire.AppendString(b, "abc")
+	b = wire.AppendTag(b, 1, wire.EndGroupType)
+	group := len(b)
+	b = append(b, 0xff) // after the group
+
+	if n := wire.ConsumeFieldValue(1, wire.StartGroupType, b); n != group {
+		t.Errorf("expected the group to be %d bytes, got %d", group, n)
+	}
+}
+
+func TestZigZag(t *testing.T) {
+	tests := []struct {
+		v       int64
+		encoded uint64
+	}{
+		{0, 0},
+		{-1, 1},
+		{1, 2},
+		{-2, 3},
+		{math.MaxInt32, 0xfffffffe},
+		{math.MinInt32, 0xffffffff},
+		{math.MinInt64, math.MaxUint64},
+	}
+
+	for _, test := range tests {
+		if got := wire.EncodeZigZag(test.v); got != test.encoded {
+			t.Errorf("expected %d to be encoded as %d, got %d", test.v, test.encoded, got)
+		}
+		if got := wire.DecodeZigZag(test.encoded); got != test.v {
+			t.Errorf("expected %d to be decoded as %d, got %d", test.encoded, test.v, got)
+		}
+	}
+}
+
+type raw []byte
+
+func (r raw) MarshalAppend(b []byte) []byte { return append(b, r...) }
+
+func TestAppendMessage(t *testing.T) {
+	for _, size := range []int{0, 1, 127, 128, 20000} {
+		payload := make(raw, size)
+		for i := range payload {
+			payload[i] = byte(i)
+		}
+
+		b := wire.AppendMessage([]byte{0xaa}, payload)
+		v, n := wire.ConsumeBytes(b[1:])
+		if n != len(b)-1 {
+			t.Fatalf("expected %d bytes, got %d", len(b)-1, n)
+		}
+		if diff := cmp.Diff([]byte(payload), v); diff != "" {
+			t.Errorf("size %d (-want +got):\n%s", size, diff)
+		}
+	}
+}